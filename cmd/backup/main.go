@@ -42,7 +42,8 @@ func printUsage() {
 
 Environment:
   DATABASE_URL   PostgreSQL connection string
-  USER_ID        User UUID`)
+  USER_ID        User UUID
+  CONFIG_FILE    Server config file, if settings live there`)
 }
 
 func runBackup() {
@@ -52,10 +53,10 @@ func runBackup() {
 	}
 
 	filename := os.Args[2]
-	cfg := config.Load()
+	cfg := loadConfig()
 	userID := getUserID()
 
-	store, err := db.New(cfg.DatabaseURL)
+	store, err := db.New(cfg.DatabaseURL, cfg.DatabaseMaxConns)
 	if err != nil {
 		log.Fatal("database connection failed", "err", err)
 	}
@@ -93,10 +94,10 @@ func runRestore() {
 	}
 
 	filename := os.Args[2]
-	cfg := config.Load()
+	cfg := loadConfig()
 	userID := getUserID()
 
-	store, err := db.New(cfg.DatabaseURL)
+	store, err := db.New(cfg.DatabaseURL, cfg.DatabaseMaxConns)
 	if err != nil {
 		log.Fatal("database connection failed", "err", err)
 	}
@@ -125,6 +126,16 @@ func runRestore() {
 	fmt.Printf("  Rules:        %d\n", len(data.Rules))
 }
 
+// loadConfig reads the server's config (file and env, no flags); backups
+// only need the database, but a broken config should still be reported
+func loadConfig() config.Config {
+	cfg, err := config.Load("null", nil)
+	if err != nil {
+		log.Fatal("invalid config", "err", err)
+	}
+	return cfg
+}
+
 func getUserID() uuid.UUID {
 	userIDStr := os.Getenv("USER_ID")
 	if userIDStr == "" {
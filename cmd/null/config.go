@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"null-core/internal/config"
+)
+
+// runConfig handles `null config check [flags]`: it loads the config the
+// same way the server would, reports every problem at once and otherwise
+// prints the effective settings. Returns the exit code.
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "Usage: null config check [-config file] [flags]")
+		return 2
+	}
+
+	cfg, err := config.Load("null config check", args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		printProblems(os.Stderr, err)
+		return 1
+	}
+
+	cfg.Dump(os.Stdout)
+	fmt.Println()
+	for _, warning := range cfg.Warnings {
+		fmt.Println("warning:", warning)
+	}
+	fmt.Println("config ok")
+	return 0
+}
+
+// printProblems lists each line of a joined config error
+func printProblems(w io.Writer, err error) {
+	fmt.Fprintln(w, "invalid config:")
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintf(w, "  - %s\n", line)
+	}
+}
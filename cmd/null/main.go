@@ -2,28 +2,53 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"io"
+	"maps"
+	"math"
 	"net/http"
 	api "null-core/internal/api"
 	"null-core/internal/api/middleware"
 	"null-core/internal/config"
 	"null-core/internal/db"
+	"null-core/internal/health"
+	"null-core/internal/lifecycle"
+	"null-core/internal/logging"
+	"null-core/internal/metrics"
 	"null-core/internal/service"
+	"null-core/internal/tracing"
 	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
 	"github.com/charmbracelet/log"
-	"golang.org/x/net/http2"
-	"golang.org/x/net/http2/h2c"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 func main() {
-	cfg := config.Load()
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfig(os.Args[2:]))
+	}
+
+	cfg, err := config.Load("null", os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		printProblems(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// registered first so it runs last, after traces and logs are flushed
+	exitCode := 0
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
 
 	// ----- logger -----------------
 	var logger *log.Logger
-	var logFile *os.File
 
 	// create a log file only when using json
 	// cause why would anyone point monitoring tools to a non json log file
@@ -31,15 +56,21 @@ func main() {
 	logFormatter := log.TextFormatter
 
 	if cfg.LogFormat != "text" {
-		var err error
-		logFile, err = os.OpenFile("app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-		if err != nil {
-			log.Fatal("failed to create log file", "err", err)
+		logFormatter = log.JSONFormatter
+	}
+
+	if cfg.LogFormat != "text" && cfg.LogFile != "" {
+		// rotated by size; lumberjack opens the file on the first write
+		logFile := &lumberjack.Logger{
+			Filename:   cfg.LogFile,
+			MaxSize:    cfg.LogMaxSize,
+			MaxBackups: cfg.LogMaxBackups,
+			MaxAge:     int(math.Ceil(cfg.LogMaxAge.Hours() / 24)),
+			Compress:   cfg.LogCompress,
 		}
 		defer logFile.Close()
 
 		logWriter = io.MultiWriter(os.Stdout, logFile)
-		logFormatter = log.JSONFormatter
 	}
 
 	logger = log.NewWithOptions(
@@ -51,6 +82,28 @@ func main() {
 		},
 	)
 
+	for _, warning := range cfg.Warnings {
+		logger.Warn(warning)
+	}
+
+	// ----- tracing ----------------
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    cfg.TracingExporter,
+		File:        cfg.TracingFile,
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		logger.Fatal("failed to set up tracing", "err", err)
+	}
+	// after everything else has stopped, so spans from draining work are kept
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Warn("failed to flush traces", "err", err)
+		}
+	}()
+
 	// ----- migrations -------------
 	logger.Info("running database migrations")
 	if err := db.RunMigrations(cfg.DatabaseURL); err != nil {
@@ -59,7 +112,7 @@ func main() {
 	logger.Info("database migrations completed successfully")
 
 	// ----- database ---------------
-	store, err := db.New(cfg.DatabaseURL)
+	store, err := db.New(cfg.DatabaseURL, cfg.DatabaseMaxConns)
 	if err != nil {
 		logger.Fatal("database connection failed", "err", err)
 	}
@@ -73,40 +126,135 @@ func main() {
 	}
 	logger.Info("services initialized")
 
+	// ----- lifecycle --------------
+	lc := lifecycle.New(logger.WithPrefix("lifecycle"))
+
 	// ----- receipt OCR worker ----
-	go services.Receipts.StartWorker(context.Background())
+	lc.Go("receipt worker", services.Receipts.StartWorker)
 
 	// ----- api layer --------
-	srv := api.NewServer(services, logger.WithPrefix("api"))
+	healthCheck := health.NewChecker(api.ServiceNames, services.HealthChecks(), cfg.HealthCheckInterval, logger.WithPrefix("health"))
+	lc.Go("health checks", healthCheck.Run)
+
+	srv := api.NewServer(services, healthCheck, logger.WithPrefix("api"))
+	// the JWKS cache outlives the root context: requests still draining
+	// after it is cancelled need their keys, so it stops after the server
+	jwksCtx, stopJWKS := context.WithCancel(context.Background())
+	defer stopJWKS()
+	jwtValidator, err := middleware.NewJWTValidator(jwksCtx, middleware.JWTConfig{
+		JWKSURL:         cfg.NullGatewayURL + "/api/auth/jwks",
+		Issuer:          cfg.JWTIssuer,
+		Audience:        cfg.JWTAudience,
+		ClockSkew:       cfg.JWTClockSkew,
+		RefreshInterval: cfg.JWKSRefresh,
+	}, logger.WithPrefix("jwt"))
+	if err != nil {
+		logger.Fatal("failed to set up JWT validation", "error", err)
+	}
+
+	internalServices := make([]middleware.InternalService, len(cfg.InternalCredentials))
+	for i, cred := range cfg.InternalCredentials {
+		internalServices[i] = middleware.InternalService{
+			Name:       cred.Name,
+			Key:        cred.Key,
+			Procedures: cred.Procedures,
+			Users:      cred.Users,
+		}
+	}
+
 	authConfig := &middleware.AuthConfig{
-		InternalAPIKey: cfg.APIKey,
-		WebURL:         cfg.NullGatewayURL,
+		InternalServices: internalServices,
+		JWT:              jwtValidator,
+		APITokens:        services.Users,
 	}
 
-	handler := srv.GetHandler(authConfig)
+	var rateLimit *middleware.RateLimitConfig
+	if cfg.RateLimitRate > 0 {
+		costs := maps.Clone(middleware.DefaultRateLimitCosts)
+		maps.Copy(costs, cfg.RateLimitCosts)
 
-	serverErrors := make(chan error, 1)
+		limits := middleware.NewMemoryRateLimitStore()
+		if cfg.RateLimitBackend == "postgres" {
+			limits = middleware.NewPostgresRateLimitStore(store.Queries, logger.WithPrefix("ratelimit"))
+		}
 
-	go func() {
-		server := &http.Server{
-			Addr:    cfg.ListenAddress,
-			Handler: h2c.NewHandler(handler, &http2.Server{}),
+		rateLimit = &middleware.RateLimitConfig{
+			Limit: middleware.RateLimit{Rate: cfg.RateLimitRate, Burst: cfg.RateLimitBurst},
+			Costs: costs,
+			Store: limits,
 		}
+	}
+
+	handler := srv.GetHandler(authConfig, middleware.CORSConfig{
+		AllowedOrigins: cfg.CORSAllowedOrigins,
+		AllowedHeaders: cfg.CORSAllowedHeaders,
+	}, logging.NewRedactor(cfg.LogPayloadFields), rateLimit)
+
+	// serve h2c natively rather than through x/net's h2c handler, which
+	// hijacks connections and so hides them from Shutdown
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	server := &http.Server{
+		Addr:      cfg.ListenAddress,
+		Handler:   handler,
+		Protocols: protocols,
+	}
 
+	// in order: stop advertising health so load balancers move on, let
+	// in-flight requests finish, then stop refreshing keys; the worker is
+	// waited for after the hooks
+	lc.OnShutdown("health", func(context.Context) error {
+		srv.SetServing(false)
+		return nil
+	})
+	lc.OnShutdown("http server", server.Shutdown)
+	lc.OnShutdown("jwks cache", func(context.Context) error {
+		stopJWKS()
+		return nil
+	})
+
+	// ----- metrics ----------------
+	if cfg.MetricsAddress != "" {
+		metrics.RegisterPool(store.Pool())
+		metrics.RegisterReceiptQueue(func(ctx context.Context) (metrics.QueueDepth, error) {
+			row, err := store.Queries.CountReceiptQueue(ctx)
+			return metrics.QueueDepth{Pending: row.Pending, Due: row.Due, NeedsReview: row.NeedsReview}, err
+		})
+
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("GET /metrics", metrics.Handler())
+		metricsServer := &http.Server{Addr: cfg.MetricsAddress, Handler: metricsMux}
+		lc.OnShutdown("metrics server", metricsServer.Shutdown)
+
+		go func() {
+			logger.Info("metrics are listening", "addr", cfg.MetricsAddress)
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("metrics server failed", "err", err)
+			}
+		}()
+	}
+
+	serverErrors := make(chan error, 1)
+
+	go func() {
 		logger.Info("server is listening", "addr", cfg.ListenAddress)
 		serverErrors <- server.ListenAndServe()
 	}()
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
-
 	select {
 	case err := <-serverErrors:
 		logger.Fatal("server error", "err", err)
 
-	case <-quit:
-		logger.Info("shutdown signal received")
-		logger.Info("server stopping...")
+	case <-lc.Done():
+		logger.Info("shutdown signal received, draining", "timeout", cfg.ShutdownTimeout)
+	}
+
+	if err := lc.Shutdown(cfg.ShutdownTimeout); err != nil {
+		logger.Error("shutdown incomplete", "error", err)
+		exitCode = 1
+		return
 	}
 
 	logger.Info("server shutdown complete")
@@ -381,3 +381,19 @@ func mapGranularity(g pb.Granularity) int32 {
 		return 1 // default to day
 	}
 }
+
+func (s *Server) GetItemCategorySpending(ctx context.Context, req *connect.Request[pb.GetItemCategorySpendingRequest]) (*connect.Response[pb.GetItemCategorySpendingResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.services.Dashboard.ItemCategorySpending(ctx, userID, req.Msg)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.GetItemCategorySpendingResponse{
+		Categories: categories,
+	}), nil
+}
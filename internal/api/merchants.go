@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+
+	pb "null-core/internal/gen/null/v1"
+
+	"connectrpc.com/connect"
+)
+
+func (s *Server) ListMerchants(ctx context.Context, req *connect.Request[pb.ListMerchantsRequest]) (*connect.Response[pb.ListMerchantsResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	merchants, err := s.services.Merchants.List(ctx, userID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.ListMerchantsResponse{Merchants: merchants}), nil
+}
+
+func (s *Server) GetMerchant(ctx context.Context, req *connect.Request[pb.GetMerchantRequest]) (*connect.Response[pb.GetMerchantResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	merchant, err := s.services.Merchants.Get(ctx, userID, req.Msg.GetId())
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.GetMerchantResponse{Merchant: merchant}), nil
+}
+
+func (s *Server) CreateMerchant(ctx context.Context, req *connect.Request[pb.CreateMerchantRequest]) (*connect.Response[pb.CreateMerchantResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	merchant, linked, err := s.services.Merchants.Create(ctx, userID, req.Msg)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.CreateMerchantResponse{
+		Merchant:           merchant,
+		TransactionsLinked: linked,
+	}), nil
+}
+
+func (s *Server) UpdateMerchant(ctx context.Context, req *connect.Request[pb.UpdateMerchantRequest]) (*connect.Response[pb.UpdateMerchantResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.services.Merchants.Update(ctx, userID, req.Msg)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.UpdateMerchantResponse{TransactionsLinked: linked}), nil
+}
+
+func (s *Server) DeleteMerchant(ctx context.Context, req *connect.Request[pb.DeleteMerchantRequest]) (*connect.Response[pb.DeleteMerchantResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := s.services.Merchants.Delete(ctx, userID, req.Msg.GetId())
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.DeleteMerchantResponse{AffectedRows: affected}), nil
+}
+
+func (s *Server) MergeMerchants(ctx context.Context, req *connect.Request[pb.MergeMerchantsRequest]) (*connect.Response[pb.MergeMerchantsResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	merchant, moved, err := s.services.Merchants.Merge(ctx, userID, req.Msg.GetTargetId(), req.Msg.GetSourceIds())
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.MergeMerchantsResponse{
+		Merchant:          merchant,
+		TransactionsMoved: moved,
+	}), nil
+}
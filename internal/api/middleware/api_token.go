@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	pb "null-core/internal/gen/null/v1"
+
+	"github.com/google/uuid"
+)
+
+// APIToken is put in the context of requests made with a personal API token
+type APIToken struct {
+	ID     int64
+	UserID uuid.UUID
+	Scopes []pb.ApiTokenScope
+}
+
+type APITokenAuthenticator interface {
+	AuthenticateAPIToken(ctx context.Context, secret string) (uuid.UUID, *pb.ApiToken, error)
+}
+
+// procedures that need more than read access
+var writeScopes = map[string]pb.ApiTokenScope{
+	"TransactionService/CreateTransaction":      pb.ApiTokenScope_API_TOKEN_SCOPE_TRANSACTIONS_WRITE,
+	"TransactionService/UpdateTransaction":      pb.ApiTokenScope_API_TOKEN_SCOPE_TRANSACTIONS_WRITE,
+	"TransactionService/DeleteTransaction":      pb.ApiTokenScope_API_TOKEN_SCOPE_TRANSACTIONS_WRITE,
+	"TransactionService/CategorizeTransactions": pb.ApiTokenScope_API_TOKEN_SCOPE_TRANSACTIONS_WRITE,
+	"ReceiptService/UploadReceipt":              pb.ApiTokenScope_API_TOKEN_SCOPE_RECEIPTS_UPLOAD,
+}
+
+// requiredScope returns the scope a token needs for r; ok is false for
+// anything tokens may never do (user and token management, backups, other
+// writes)
+func requiredScope(r *http.Request) (scope pb.ApiTokenScope, ok bool) {
+	rest, isRPC := strings.CutPrefix(r.URL.Path, "/null.v1.")
+	if !isRPC {
+		// plain HTTP endpoints only serve receipt images
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			return pb.ApiTokenScope_API_TOKEN_SCOPE_READ, true
+		}
+		return 0, false
+	}
+
+	service, method, _ := strings.Cut(rest, "/")
+	if service == "UserService" || service == "BackupService" {
+		return 0, false
+	}
+	if scope, ok := writeScopes[rest]; ok {
+		return scope, true
+	}
+	if strings.HasPrefix(method, "Get") || strings.HasPrefix(method, "List") {
+		return pb.ApiTokenScope_API_TOKEN_SCOPE_READ, true
+	}
+	return 0, false
+}
+
+func (t *APIToken) allows(r *http.Request) bool {
+	scope, ok := requiredScope(r)
+	return ok && slices.Contains(t.Scopes, scope)
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pb "null-core/internal/gen/null/v1"
+)
+
+const (
+	scopeRead   = pb.ApiTokenScope_API_TOKEN_SCOPE_READ
+	scopeTxs    = pb.ApiTokenScope_API_TOKEN_SCOPE_TRANSACTIONS_WRITE
+	scopeUpload = pb.ApiTokenScope_API_TOKEN_SCOPE_RECEIPTS_UPLOAD
+)
+
+func TestAPITokenAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		scopes []pb.ApiTokenScope
+		want   bool
+	}{
+		{"read list", http.MethodPost, "/null.v1.TransactionService/ListTransactions", []pb.ApiTokenScope{scopeRead}, true},
+		{"read get", http.MethodPost, "/null.v1.AccountService/GetAccount", []pb.ApiTokenScope{scopeRead}, true},
+		{"read needs scope", http.MethodPost, "/null.v1.AccountService/GetAccount", []pb.ApiTokenScope{scopeTxs}, false},
+		{"write with write scope", http.MethodPost, "/null.v1.TransactionService/CreateTransaction", []pb.ApiTokenScope{scopeTxs}, true},
+		{"write with read scope", http.MethodPost, "/null.v1.TransactionService/CreateTransaction", []pb.ApiTokenScope{scopeRead}, false},
+		{"categorize is a write", http.MethodPost, "/null.v1.TransactionService/CategorizeTransactions", []pb.ApiTokenScope{scopeRead}, false},
+		{"upload with upload scope", http.MethodPost, "/null.v1.ReceiptService/UploadReceipt", []pb.ApiTokenScope{scopeUpload}, true},
+		{"upload with write scope", http.MethodPost, "/null.v1.ReceiptService/UploadReceipt", []pb.ApiTokenScope{scopeTxs}, false},
+		{"other writes never", http.MethodPost, "/null.v1.AccountService/DeleteAccount", []pb.ApiTokenScope{scopeRead, scopeTxs, scopeUpload}, false},
+		{"user service never", http.MethodPost, "/null.v1.UserService/GetUser", []pb.ApiTokenScope{scopeRead}, false},
+		{"backups never", http.MethodPost, "/null.v1.BackupService/ListBackups", []pb.ApiTokenScope{scopeRead}, false},
+		{"image GET", http.MethodGet, "/receipts/1/image", []pb.ApiTokenScope{scopeRead}, true},
+		{"image HEAD", http.MethodHead, "/receipts/1/image", []pb.ApiTokenScope{scopeRead}, true},
+		{"image GET needs read", http.MethodGet, "/receipts/1/image", []pb.ApiTokenScope{scopeUpload}, false},
+		{"plain HTTP POST", http.MethodPost, "/receipts/1/image", []pb.ApiTokenScope{scopeRead, scopeTxs, scopeUpload}, false},
+		{"no scopes", http.MethodPost, "/null.v1.TransactionService/ListTransactions", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := &APIToken{ID: 1, Scopes: tt.scopes}
+			if got := token.allows(httptest.NewRequest(tt.method, tt.path, nil)); got != tt.want {
+				t.Errorf("Expected allows=%v for %s %s with %v, got %v", tt.want, tt.method, tt.path, tt.scopes, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"null-core/internal/logging"
+
+	"connectrpc.com/connect"
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+)
+
+// AuditInterceptor records every call made with an internal credential:
+// which service, on behalf of which user, and how it ended. Unlike the
+// request log it doesn't depend on LOG_LEVEL=debug.
+func AuditInterceptor(logger *log.Logger) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			svc, ok := ctx.Value(InternalAuthKey).(*InternalService)
+			if !ok {
+				return next(ctx, req)
+			}
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			userID := extractUserIDFromMessage(req.Any())
+			if id, ok := ctx.Value(UserIDKey).(uuid.UUID); ok && userID == "" {
+				userID = id.String()
+			}
+
+			fields := append(logging.Fields(ctx),
+				"service", svc.Name,
+				"procedure", req.Spec().Procedure,
+				"user_id", userID,
+				"peer", req.Peer().Addr,
+				"duration_ms", time.Since(start).Milliseconds(),
+			)
+			if err != nil {
+				fields = append(fields, "code", connect.CodeOf(err).String())
+			} else {
+				fields = append(fields, "code", "ok")
+			}
+			logger.Info("internal call", fields...)
+
+			return resp, err
+		}
+	}
+}
@@ -2,53 +2,19 @@ package middleware
 
 import (
 	"context"
-	"crypto/subtle"
-	"errors"
-	"fmt"
 	"net/http"
 	"strings"
 
+	"null-core/internal/logging"
+	"null-core/internal/service"
+
 	"github.com/charmbracelet/log"
-	"github.com/lestrrat-go/jwx/v3/jwk"
-	"github.com/lestrrat-go/jwx/v3/jwt"
 )
 
 type AuthConfig struct {
-	InternalAPIKey string
-	WebURL         string
-}
-
-func validateJWT(ctx context.Context, tokenString, webURL string) (*User, error) {
-	if webURL == "" {
-		return nil, fmt.Errorf("web URL not configured")
-	}
-
-	jwksURL := fmt.Sprintf("%s/api/auth/jwks", webURL)
-
-	keyset, err := jwk.Fetch(ctx, jwksURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch JWKS from %s: %w", jwksURL, err)
-	}
-
-	token, err := jwt.Parse([]byte(tokenString), jwt.WithKeySet(keyset))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse JWT: %w", err)
-	}
-
-	userID, exists := token.Subject()
-	if !exists {
-		return nil, errors.New("missing user id")
-	}
-
-	var email, name string
-	token.Get("email", &email)
-	token.Get("name", &name)
-
-	return &User{
-		ID:    userID,
-		Email: email,
-		Name:  name,
-	}, nil
+	InternalServices []InternalService
+	JWT              *JWTValidator
+	APITokens        APITokenAuthenticator
 }
 
 func Auth(config *AuthConfig, logger *log.Logger) Middleware {
@@ -58,22 +24,28 @@ func Auth(config *AuthConfig, logger *log.Logger) Middleware {
 				next.ServeHTTP(w, r)
 				return
 			}
+			logger := logging.Logger(r.Context(), logger)
 
 			if internalKey := r.Header.Get("X-Internal-Key"); internalKey != "" {
-				if config.InternalAPIKey == "" {
-					logger.Error("internal API key not configured")
-					w.WriteHeader(http.StatusInternalServerError)
+				svc := matchInternalKey(config.InternalServices, internalKey)
+				if svc == nil {
+					logger.Warn("invalid internal API key", "remote_addr", r.RemoteAddr)
+					w.WriteHeader(http.StatusUnauthorized)
 					return
 				}
 
-				if subtle.ConstantTimeCompare([]byte(internalKey), []byte(config.InternalAPIKey)) != 1 {
-					logger.Warn("invalid internal API key", "remote_addr", r.RemoteAddr)
-					w.WriteHeader(http.StatusUnauthorized)
+				if !svc.allowsProcedure(r.URL.Path) {
+					logger.Warn("internal service not allowed",
+						"service", svc.Name,
+						"path", r.URL.Path,
+						"remote_addr", r.RemoteAddr,
+					)
+					w.WriteHeader(http.StatusForbidden)
 					return
 				}
 
-				ctx := context.WithValue(r.Context(), InternalAuthKey, true)
-				logger.Debug("internal service authenticated", "path", r.URL.Path)
+				ctx := context.WithValue(r.Context(), InternalAuthKey, svc)
+				logger.Debug("internal service authenticated", "service", svc.Name, "path", r.URL.Path)
 				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
@@ -82,13 +54,18 @@ func Auth(config *AuthConfig, logger *log.Logger) Middleware {
 			if after, ok := strings.CutPrefix(authHeader, "Bearer "); ok {
 				tokenString := after
 
-				if config.WebURL == "" {
-					logger.Error("web URL not configured")
+				if strings.HasPrefix(tokenString, service.APITokenPrefix) {
+					serveAPIToken(w, r, next, config, logger, tokenString)
+					return
+				}
+
+				if config.JWT == nil {
+					logger.Error("JWT validation not configured")
 					w.WriteHeader(http.StatusInternalServerError)
 					return
 				}
 
-				user, err := validateJWT(r.Context(), tokenString, config.WebURL)
+				user, err := config.JWT.Validate(r.Context(), tokenString)
 				if err != nil {
 					logger.Warn("JWT validation failed", "error", err, "remote_addr", r.RemoteAddr)
 					w.WriteHeader(http.StatusUnauthorized)
@@ -98,7 +75,6 @@ func Auth(config *AuthConfig, logger *log.Logger) Middleware {
 				ctx := context.WithValue(r.Context(), UserContextKey, user)
 				logger.Debug("user authenticated via JWT",
 					"user_id", user.ID,
-					"email", user.Email,
 					"path", r.URL.Path,
 				)
 
@@ -111,3 +87,42 @@ func Auth(config *AuthConfig, logger *log.Logger) Middleware {
 		})
 	}
 }
+
+// serveAPIToken authenticates a personal API token and checks its scopes.
+// The token's owner goes straight into UserIDKey; there is no UserContextKey,
+// so EnsureUserInterceptor leaves the profile alone.
+func serveAPIToken(w http.ResponseWriter, r *http.Request, next http.Handler, config *AuthConfig, logger *log.Logger, secret string) {
+	if config.APITokens == nil {
+		logger.Error("API tokens not configured")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	userID, pbToken, err := config.APITokens.AuthenticateAPIToken(r.Context(), secret)
+	if err != nil {
+		logger.Warn("API token rejected", "error", err, "remote_addr", r.RemoteAddr)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	token := &APIToken{ID: pbToken.GetId(), UserID: userID, Scopes: pbToken.GetScopes()}
+	if !token.allows(r) {
+		logger.Warn("API token lacks scope",
+			"token_id", token.ID,
+			"user_id", userID,
+			"path", r.URL.Path,
+		)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), APITokenKey, token)
+	ctx = context.WithValue(ctx, UserIDKey, userID)
+	logger.Debug("user authenticated via API token",
+		"user_id", userID,
+		"token_id", token.ID,
+		"path", r.URL.Path,
+	)
+
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
@@ -0,0 +1,218 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "null-core/internal/gen/null/v1"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v3/jwa"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+)
+
+var (
+	alice = uuid.MustParse("00000000-0000-0000-0000-00000000a11c")
+	bob   = uuid.MustParse("00000000-0000-0000-0000-000000000b0b")
+)
+
+// fakeTokens authenticates "nul_" secrets; every token belongs to alice
+type fakeTokens map[string][]pb.ApiTokenScope
+
+func (f fakeTokens) AuthenticateAPIToken(_ context.Context, secret string) (uuid.UUID, *pb.ApiToken, error) {
+	scopes, ok := f[secret]
+	if !ok {
+		return uuid.Nil, nil, errors.New("unknown token")
+	}
+	return alice, &pb.ApiToken{Id: 1, Scopes: scopes}, nil
+}
+
+// testIssuer signs gateway-style JWTs and serves the matching JWKS
+type testIssuer struct {
+	key jwk.Key
+	srv *httptest.Server
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	raw, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := jwk.Import(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key.Set(jwk.KeyIDKey, "test-key")
+	key.Set(jwk.AlgorithmKey, jwa.ES256())
+
+	public, err := key.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := jwk.NewSet()
+	set.AddKey(public)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(srv.Close)
+
+	return &testIssuer{key: key, srv: srv}
+}
+
+func (i *testIssuer) sign(t *testing.T, audience string, expires time.Time) string {
+	t.Helper()
+
+	token, err := jwt.NewBuilder().
+		Subject(alice.String()).
+		Issuer("https://gateway.test").
+		Audience([]string{audience}).
+		IssuedAt(expires.Add(-time.Hour)).
+		Expiration(expires).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.ES256(), i.key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(signed)
+}
+
+// echoIdentity answers 200 and reports who the request was authenticated as
+func echoIdentity() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, ok := r.Context().Value(UserIDKey).(uuid.UUID); ok {
+			w.Header().Set("X-Test-User", id.String())
+		}
+		if svc, ok := r.Context().Value(InternalAuthKey).(*InternalService); ok {
+			w.Header().Set("X-Test-Service", svc.Name)
+		}
+	})
+}
+
+func TestAuth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	issuer := newTestIssuer(t)
+	validator, err := NewJWTValidator(ctx, JWTConfig{
+		JWKSURL:         issuer.srv.URL,
+		Issuer:          "https://gateway.test",
+		Audience:        "null-core",
+		RefreshInterval: time.Hour,
+	}, log.New(io.Discard))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &AuthConfig{
+		InternalServices: []InternalService{
+			{
+				Name:       "importer",
+				Key:        "importer-key",
+				Procedures: []string{"/null.v1.TransactionService/*", "/receipts/*"},
+				Users:      []uuid.UUID{alice},
+			},
+			{Name: "admin", Key: "admin-key", Procedures: []string{"*"}},
+		},
+		JWT: validator,
+		APITokens: fakeTokens{
+			"nul_read":  {scopeRead},
+			"nul_write": {scopeRead, scopeTxs},
+		},
+	}
+	handler := CreateStack(Auth(config, log.New(io.Discard)), UserContext())(echoIdentity())
+
+	valid := issuer.sign(t, "null-core", time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		header      string // "X-Internal-Key" or "Authorization"
+		credential  string
+		wantStatus  int
+		wantUser    uuid.UUID
+		wantService string
+	}{
+		{name: "no credentials", path: "/null.v1.AccountService/ListAccounts", wantStatus: http.StatusUnauthorized},
+		{name: "health needs none", path: "/grpc.health.v1.Health/Check", wantStatus: http.StatusOK},
+		{name: "not a bearer token", path: "/null.v1.AccountService/ListAccounts", header: "Authorization", credential: "Basic Zm9vOmJhcg==", wantStatus: http.StatusUnauthorized},
+
+		// internal keys
+		{name: "internal unknown key", path: "/null.v1.TransactionService/ListTransactions", header: "X-Internal-Key", credential: "nope", wantStatus: http.StatusUnauthorized},
+		{name: "internal allowed procedure and user", path: "/null.v1.TransactionService/ListTransactions", body: `{"userId":"` + alice.String() + `"}`, header: "X-Internal-Key", credential: "importer-key", wantStatus: http.StatusOK, wantUser: alice, wantService: "importer"},
+		{name: "internal user_id spelling", path: "/null.v1.TransactionService/ListTransactions", body: `{"user_id":"` + alice.String() + `"}`, header: "X-Internal-Key", credential: "importer-key", wantStatus: http.StatusOK, wantUser: alice, wantService: "importer"},
+		{name: "internal procedure not allowlisted", path: "/null.v1.AccountService/ListAccounts", body: `{"userId":"` + alice.String() + `"}`, header: "X-Internal-Key", credential: "importer-key", wantStatus: http.StatusForbidden},
+		{name: "internal user not allowlisted", path: "/null.v1.TransactionService/ListTransactions", body: `{"userId":"` + bob.String() + `"}`, header: "X-Internal-Key", credential: "importer-key", wantStatus: http.StatusForbidden},
+		{name: "internal image query user", method: http.MethodGet, path: "/receipts/1/image?user_id=" + alice.String(), header: "X-Internal-Key", credential: "importer-key", wantStatus: http.StatusOK, wantUser: alice, wantService: "importer"},
+		{name: "internal image query user not allowlisted", method: http.MethodGet, path: "/receipts/1/image?user_id=" + bob.String(), header: "X-Internal-Key", credential: "importer-key", wantStatus: http.StatusForbidden},
+		{name: "internal unrestricted service any user", method: http.MethodGet, path: "/receipts/1/image?user_id=" + bob.String(), header: "X-Internal-Key", credential: "admin-key", wantStatus: http.StatusOK, wantUser: bob, wantService: "admin"},
+
+		// personal API tokens
+		{name: "api token read", path: "/null.v1.TransactionService/ListTransactions", header: "Authorization", credential: "Bearer nul_read", wantStatus: http.StatusOK, wantUser: alice},
+		{name: "api token image", method: http.MethodGet, path: "/receipts/1/image", header: "Authorization", credential: "Bearer nul_read", wantStatus: http.StatusOK, wantUser: alice},
+		{name: "api token write with read scope", path: "/null.v1.TransactionService/CreateTransaction", header: "Authorization", credential: "Bearer nul_read", wantStatus: http.StatusForbidden},
+		{name: "api token write with write scope", path: "/null.v1.TransactionService/CreateTransaction", header: "Authorization", credential: "Bearer nul_write", wantStatus: http.StatusOK, wantUser: alice},
+		{name: "api token user management", path: "/null.v1.UserService/DeleteUser", header: "Authorization", credential: "Bearer nul_write", wantStatus: http.StatusForbidden},
+		{name: "api token unknown", path: "/null.v1.TransactionService/ListTransactions", header: "Authorization", credential: "Bearer nul_revoked", wantStatus: http.StatusUnauthorized},
+
+		// gateway JWTs
+		{name: "jwt valid", path: "/null.v1.AccountService/ListAccounts", header: "Authorization", credential: "Bearer " + valid, wantStatus: http.StatusOK, wantUser: alice},
+		{name: "jwt expired", path: "/null.v1.AccountService/ListAccounts", header: "Authorization", credential: "Bearer " + issuer.sign(t, "null-core", time.Now().Add(-time.Hour)), wantStatus: http.StatusUnauthorized},
+		{name: "jwt wrong audience", path: "/null.v1.AccountService/ListAccounts", header: "Authorization", credential: "Bearer " + issuer.sign(t, "someone-else", time.Now().Add(time.Hour)), wantStatus: http.StatusUnauthorized},
+		{name: "jwt garbage", path: "/null.v1.AccountService/ListAccounts", header: "Authorization", credential: "Bearer not.a.jwt", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, tt.path, strings.NewReader(tt.body))
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.credential)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			wantUser := ""
+			if tt.wantUser != uuid.Nil {
+				wantUser = tt.wantUser.String()
+			}
+			if got := rec.Header().Get("X-Test-User"); got != wantUser {
+				t.Errorf("Expected user %q, got %q", wantUser, got)
+			}
+			if got := rec.Header().Get("X-Test-Service"); got != tt.wantService {
+				t.Errorf("Expected service %q, got %q", tt.wantService, got)
+			}
+		})
+	}
+}
@@ -6,12 +6,19 @@ import (
 	"github.com/rs/cors"
 )
 
-func CORS() Middleware {
+// CORSConfig says which browser origins may call the API and with which
+// request headers; "*" allows any
+type CORSConfig struct {
+	AllowedOrigins []string
+	AllowedHeaders []string
+}
+
+func CORS(cfg CORSConfig) Middleware {
 	c := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"}, // TODO: harden
+		AllowedOrigins:   cfg.AllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"*"}, // TODO: harden
-		ExposedHeaders:   []string{"Connect-Protocol-Version"},
+		AllowedHeaders:   cfg.AllowedHeaders,
+		ExposedHeaders:   []string{"Connect-Protocol-Version", RequestIDHeader},
 		AllowCredentials: true,
 	})
 
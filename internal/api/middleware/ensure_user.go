@@ -3,6 +3,8 @@ package middleware
 import (
 	"context"
 
+	"null-core/internal/logging"
+
 	"connectrpc.com/connect"
 	"github.com/charmbracelet/log"
 )
@@ -20,7 +22,7 @@ func EnsureUserInterceptor(ensurer UserEnsurer, logger *log.Logger) connect.Unar
 			}
 
 			if err := ensurer.EnsureExists(ctx, user.ID, user.Email, user.Name); err != nil {
-				logger.Error("failed to ensure user exists", "user_id", user.ID, "error", err)
+				logging.Logger(ctx, logger).Error("failed to ensure user exists", "user_id", user.ID, "error", err)
 				return nil, connect.NewError(connect.CodeInternal, err)
 			}
 
@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pb "null-core/internal/gen/null/v1"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+)
+
+func TestInternalServiceAllowsProcedure(t *testing.T) {
+	tests := []struct {
+		name       string
+		procedures []string
+		path       string
+		want       bool
+	}{
+		{"wildcard", []string{"*"}, "/null.v1.BackupService/CreateBackup", true},
+		{"exact", []string{"/null.v1.TransactionService/ListTransactions"}, "/null.v1.TransactionService/ListTransactions", true},
+		{"exact other method", []string{"/null.v1.TransactionService/ListTransactions"}, "/null.v1.TransactionService/DeleteTransaction", false},
+		{"service prefix", []string{"/null.v1.ReceiptService/*"}, "/null.v1.ReceiptService/UploadReceipt", true},
+		{"service prefix other service", []string{"/null.v1.ReceiptService/*"}, "/null.v1.ReceiptItemService/List", false},
+		{"second entry", []string{"/null.v1.AccountService/*", "/receipts/*"}, "/receipts/4/image", true},
+		{"none", nil, "/null.v1.AccountService/ListAccounts", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &InternalService{Name: "svc", Procedures: tt.procedures}
+			if got := svc.allowsProcedure(tt.path); got != tt.want {
+				t.Errorf("Expected allowsProcedure(%q)=%v with %v, got %v", tt.path, tt.want, tt.procedures, got)
+			}
+		})
+	}
+}
+
+func TestMatchInternalKey(t *testing.T) {
+	services := []InternalService{
+		{Name: "importer", Key: "importer-key"},
+		{Name: "backup", Key: "backup-key"},
+	}
+
+	if svc := matchInternalKey(services, "backup-key"); svc == nil || svc.Name != "backup" {
+		t.Errorf("Expected backup to match, got %v", svc)
+	}
+	for _, key := range []string{"", "backup", "backup-key-2"} {
+		if svc := matchInternalKey(services, key); svc != nil {
+			t.Errorf("Expected %q to match nothing, got %s", key, svc.Name)
+		}
+	}
+}
+
+// asService serves next as an authenticated internal service, the way Auth
+// leaves the request for the interceptors
+func asService(svc *InternalService, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if svc != nil {
+			r = r.WithContext(context.WithValue(r.Context(), InternalAuthKey, svc))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestInternalAccessInterceptor(t *testing.T) {
+	restricted := &InternalService{Name: "importer", Procedures: []string{"*"}, Users: []uuid.UUID{alice}}
+	unrestricted := &InternalService{Name: "admin", Procedures: []string{"*"}}
+
+	interceptors := connect.WithInterceptors(InternalAccessInterceptor())
+	listTransactions := connect.NewUnaryHandler("/null.v1.TransactionService/ListTransactions",
+		func(context.Context, *connect.Request[pb.ListTransactionsRequest]) (*connect.Response[pb.ListTransactionsResponse], error) {
+			return connect.NewResponse(&pb.ListTransactionsResponse{}), nil
+		}, interceptors)
+	getUser := connect.NewUnaryHandler("/null.v1.UserService/GetUser",
+		func(context.Context, *connect.Request[pb.GetUserRequest]) (*connect.Response[pb.GetUserResponse], error) {
+			return connect.NewResponse(&pb.GetUserResponse{}), nil
+		}, interceptors)
+
+	tests := []struct {
+		name     string
+		svc      *InternalService
+		userID   string
+		getUser  bool         // call UserService/GetUser, which names the user as id
+		wantCode connect.Code // 0 means allowed
+	}{
+		{name: "not internal", userID: bob.String()},
+		{name: "unrestricted any user", svc: unrestricted, userID: bob.String()},
+		{name: "unrestricted no user", svc: unrestricted},
+		{name: "allowlisted user", svc: restricted, userID: alice.String()},
+		{name: "other user", svc: restricted, userID: bob.String(), wantCode: connect.CodePermissionDenied},
+		{name: "names no user", svc: restricted, wantCode: connect.CodePermissionDenied},
+		{name: "user is not a uuid", svc: restricted, userID: "alice", wantCode: connect.CodePermissionDenied},
+		{name: "user service id", svc: restricted, userID: alice.String(), getUser: true},
+		{name: "user service other id", svc: restricted, userID: bob.String(), getUser: true, wantCode: connect.CodePermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.Handle("/null.v1.TransactionService/ListTransactions", listTransactions)
+			mux.Handle("/null.v1.UserService/GetUser", getUser)
+			srv := httptest.NewServer(asService(tt.svc, mux))
+			defer srv.Close()
+
+			var err error
+			if tt.getUser {
+				client := connect.NewClient[pb.GetUserRequest, pb.GetUserResponse](srv.Client(), srv.URL+"/null.v1.UserService/GetUser")
+				_, err = client.CallUnary(context.Background(), connect.NewRequest(&pb.GetUserRequest{Id: tt.userID}))
+			} else {
+				client := connect.NewClient[pb.ListTransactionsRequest, pb.ListTransactionsResponse](srv.Client(), srv.URL+"/null.v1.TransactionService/ListTransactions")
+				_, err = client.CallUnary(context.Background(), connect.NewRequest(&pb.ListTransactionsRequest{UserId: tt.userID}))
+			}
+
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("Expected the call to be allowed, got %v", err)
+				}
+				return
+			}
+			if got := connect.CodeOf(err); got != tt.wantCode {
+				t.Errorf("Expected %v, got %v (%v)", tt.wantCode, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/lestrrat-go/httprc/v3"
+	"github.com/lestrrat-go/httprc/v3/errsink"
+	"github.com/lestrrat-go/jwx/v3/jwk"
+	"github.com/lestrrat-go/jwx/v3/jws"
+	"github.com/lestrrat-go/jwx/v3/jwt"
+)
+
+// minForcedRefresh limits how often tokens with an unknown kid can make us
+// refetch the JWKS; otherwise junk tokens would hammer the gateway
+const minForcedRefresh = 30 * time.Second
+
+// JWTConfig says where the gateway publishes its keys and what its tokens
+// must look like. Empty Issuer/Audience skip that check.
+type JWTConfig struct {
+	JWKSURL         string
+	Issuer          string
+	Audience        string
+	ClockSkew       time.Duration // leeway for exp/nbf/iat
+	RefreshInterval time.Duration // how long fetched keys are trusted
+}
+
+// JWTValidator verifies gateway-issued tokens against a cached JWKS that is
+// refreshed in the background, so the gateway isn't on every request's path
+type JWTValidator struct {
+	cache *jwk.Cache
+	url   string
+	opts  []jwt.ParseOption
+	log   *log.Logger
+
+	mu         sync.Mutex
+	lastForced time.Time
+}
+
+// NewJWTValidator starts the background refresh, which runs until ctx is
+// done. It doesn't wait for the first fetch: if the gateway is down at
+// startup, keys are fetched again when the first token arrives.
+func NewJWTValidator(ctx context.Context, cfg JWTConfig, logger *log.Logger) (*JWTValidator, error) {
+	if cfg.JWKSURL == "" {
+		return nil, errors.New("JWKS URL not configured")
+	}
+
+	client := httprc.NewClient(
+		httprc.WithErrorSink(errsink.NewFunc(func(_ context.Context, err error) {
+			logger.Warn("JWKS refresh failed", "url", cfg.JWKSURL, "error", err)
+		})),
+	)
+
+	cache, err := jwk.NewCache(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start JWKS cache: %w", err)
+	}
+
+	err = cache.Register(ctx, cfg.JWKSURL,
+		jwk.WithConstantInterval(cfg.RefreshInterval),
+		jwk.WithWaitReady(false),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register JWKS %s: %w", cfg.JWKSURL, err)
+	}
+
+	opts := []jwt.ParseOption{
+		jwt.WithValidate(true),
+		jwt.WithAcceptableSkew(cfg.ClockSkew),
+		jwt.WithRequiredClaim("exp"),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &JWTValidator{
+		cache: cache,
+		url:   cfg.JWKSURL,
+		opts:  opts,
+		log:   logger,
+	}, nil
+}
+
+// Validate checks the token's signature, exp/nbf and, when configured, iss
+// and aud, and returns the user it was issued to
+func (v *JWTValidator) Validate(ctx context.Context, tokenString string) (*User, error) {
+	keyset, err := v.keySet(ctx, tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]jwt.ParseOption{jwt.WithKeySet(keyset)}, v.opts...)
+	token, err := jwt.Parse([]byte(tokenString), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT: %w", err)
+	}
+
+	userID, exists := token.Subject()
+	if !exists {
+		return nil, errors.New("missing user id")
+	}
+
+	var email, name string
+	token.Get("email", &email)
+	token.Get("name", &name)
+
+	return &User{
+		ID:    userID,
+		Email: email,
+		Name:  name,
+	}, nil
+}
+
+// keySet returns the cached keys, refetching them when the token was signed
+// with a key we haven't seen (the gateway rotated) or nothing is cached yet
+func (v *JWTValidator) keySet(ctx context.Context, tokenString string) (jwk.Set, error) {
+	kid := tokenKeyID(tokenString)
+
+	keyset, err := v.cache.Lookup(ctx, v.url)
+	if err == nil {
+		if _, ok := keyset.LookupKeyID(kid); ok || kid == "" {
+			return keyset, nil
+		}
+	}
+
+	if !v.claimForcedRefresh() {
+		if err != nil {
+			return nil, fmt.Errorf("JWKS not available: %w", err)
+		}
+		// verification fails with the usual "key not found"
+		return keyset, nil
+	}
+
+	v.log.Debug("refreshing JWKS", "kid", kid, "cached", err == nil)
+	refreshed, refreshErr := v.cache.Refresh(ctx, v.url)
+	if refreshErr != nil {
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch JWKS from %s: %w", v.url, refreshErr)
+		}
+		v.log.Warn("JWKS refresh failed, using cached keys", "error", refreshErr)
+		return keyset, nil
+	}
+	return refreshed, nil
+}
+
+func (v *JWTValidator) claimForcedRefresh() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if time.Since(v.lastForced) < minForcedRefresh {
+		return false
+	}
+	v.lastForced = time.Now()
+	return true
+}
+
+// tokenKeyID reads the kid header without verifying anything
+func tokenKeyID(tokenString string) string {
+	msg, err := jws.ParseString(tokenString)
+	if err != nil || len(msg.Signatures()) == 0 {
+		return ""
+	}
+	kid, _ := msg.Signatures()[0].ProtectedHeaders().KeyID()
+	return kid
+}
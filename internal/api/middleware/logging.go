@@ -5,9 +5,10 @@ import (
 	"errors"
 	"time"
 
+	"null-core/internal/logging"
+
 	"connectrpc.com/connect"
 	"github.com/charmbracelet/log"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -23,6 +24,7 @@ const (
 	UserContextKey  contextKey = "user"
 	InternalAuthKey contextKey = "internal_auth"
 	UserIDKey       contextKey = "user_id"
+	APITokenKey     contextKey = "api_token"
 )
 
 const maxPayloadLogBytes = 2048
@@ -34,14 +36,9 @@ func truncatePayload(s string) string {
 	return s[:maxPayloadLogBytes] + "... (truncated)"
 }
 
-// ConnectLoggingInterceptor creates a Connect unary interceptor for structured logging
-func ConnectLoggingInterceptor(logger *log.Logger) connect.UnaryInterceptorFunc {
-	marshaler := protojson.MarshalOptions{
-		UseProtoNames:   true,
-		EmitUnpopulated: false,
-		Indent:          "",
-	}
-
+// ConnectLoggingInterceptor creates a Connect unary interceptor for structured logging.
+// Payloads go through redactor, so only allowlisted fields are logged as-is.
+func ConnectLoggingInterceptor(logger *log.Logger, redactor *logging.Redactor) connect.UnaryInterceptorFunc {
 	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
 		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
 			start := time.Now()
@@ -52,11 +49,13 @@ func ConnectLoggingInterceptor(logger *log.Logger) connect.UnaryInterceptorFunc
 			contentType := req.Header().Get("Content-Type")
 
 			// Extract user info from context if available
-			var logFields []any
+			logFields := logging.Fields(ctx)
 			if user, ok := ctx.Value(UserContextKey).(*User); ok {
-				logFields = append(logFields, "user_id", user.ID, "user_email", user.Email)
-			} else if internal, ok := ctx.Value(InternalAuthKey).(bool); ok && internal {
-				logFields = append(logFields, "auth_type", "internal")
+				logFields = append(logFields, "user_id", user.ID)
+			} else if token, ok := ctx.Value(APITokenKey).(*APIToken); ok {
+				logFields = append(logFields, "user_id", token.UserID, "auth_type", "token", "token_id", token.ID)
+			} else if svc, ok := ctx.Value(InternalAuthKey).(*InternalService); ok {
+				logFields = append(logFields, "auth_type", "internal", "service", svc.Name)
 			}
 
 			// Log incoming request with comprehensive info
@@ -68,8 +67,8 @@ func ConnectLoggingInterceptor(logger *log.Logger) connect.UnaryInterceptorFunc
 			}, logFields...)
 
 			if reqMsg, ok := req.Any().(proto.Message); ok {
-				if jsonBytes, err := marshaler.Marshal(reqMsg); err == nil {
-					requestFields = append(requestFields, "request", truncatePayload(string(jsonBytes)))
+				if payload, err := redactor.Payload(reqMsg); err == nil {
+					requestFields = append(requestFields, "request", truncatePayload(payload))
 				}
 			}
 
@@ -103,8 +102,8 @@ func ConnectLoggingInterceptor(logger *log.Logger) connect.UnaryInterceptorFunc
 			// Log successful response with status info
 			responseFields = append(responseFields, "status", "success")
 			if respMsg, ok := resp.Any().(proto.Message); ok {
-				if jsonBytes, err := marshaler.Marshal(respMsg); err == nil {
-					responseFields = append(responseFields, "response", truncatePayload(string(jsonBytes)))
+				if payload, err := redactor.Payload(respMsg); err == nil {
+					responseFields = append(responseFields, "response", truncatePayload(payload))
 				}
 			}
 
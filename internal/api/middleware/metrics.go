@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"null-core/internal/metrics"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsInterceptor counts RPCs and times them by procedure
+func MetricsInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			procedure := req.Spec().Procedure
+			start := time.Now()
+
+			resp, err := next(ctx, req)
+
+			metrics.RPCDuration.WithLabelValues(procedure).Observe(time.Since(start).Seconds())
+			metrics.RPCRequests.WithLabelValues(procedure, errorCode(err)).Inc()
+			return resp, err
+		}
+	}
+}
+
+// errorCode names err's code; handlers return both connect errors and grpc
+// statuses, whose codes line up
+func errorCode(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	if connectErr := new(connect.Error); errors.As(err, &connectErr) {
+		return connectErr.Code().String()
+	}
+	if s, ok := status.FromError(err); ok {
+		return connect.Code(s.Code()).String()
+	}
+	return connect.CodeUnknown.String()
+}
@@ -0,0 +1,229 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"null-core/internal/db/sqlc"
+	"null-core/internal/logging"
+
+	"connectrpc.com/connect"
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimit is a token bucket: Burst tokens, refilled at Rate per second
+type RateLimit struct {
+	Rate  float64
+	Burst float64
+}
+
+// seconds it takes to refill n tokens
+func (l RateLimit) seconds(n float64) float64 {
+	return n / l.Rate
+}
+
+// RateLimitStore takes cost tokens from key's bucket. When there aren't
+// enough it takes nothing and says how long until there will be.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, cost float64, limit RateLimit) (retryAfter time.Duration, err error)
+}
+
+type RateLimitConfig struct {
+	Limit RateLimit
+	// per-procedure cost; everything else costs 1
+	Costs map[string]float64
+	Store RateLimitStore
+}
+
+// DefaultRateLimitCosts weighs calls that can touch a user's whole history
+var DefaultRateLimitCosts = map[string]float64{
+	"/null.v1.RuleService/CreateRule":                    10,
+	"/null.v1.RuleService/UpdateRule":                    10,
+	"/null.v1.RuleService/SuggestRules":                  5,
+	"/null.v1.MerchantService/CreateMerchant":            10,
+	"/null.v1.MerchantService/UpdateMerchant":            10,
+	"/null.v1.MerchantService/MergeMerchants":            10,
+	"/null.v1.TransactionService/CategorizeTransactions": 5,
+	"/null.v1.ReceiptService/UploadReceipt":              5,
+	"/null.v1.BackupService/ExportBackup":                20,
+	"/null.v1.BackupService/ImportBackup":                20,
+}
+
+// RateLimitInterceptor gives every user, and every internal service, its
+// own bucket. Requests over the limit fail with ResourceExhausted, a
+// Retry-After header and a RetryInfo detail. If the store itself fails the
+// request is let through rather than taking the API down with it.
+func RateLimitInterceptor(cfg RateLimitConfig, logger *log.Logger) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			key := rateLimitKey(ctx)
+			if key == "" {
+				return next(ctx, req)
+			}
+
+			procedure := req.Spec().Procedure
+			cost, ok := cfg.Costs[procedure]
+			if !ok {
+				cost = 1
+			}
+			// anything costlier than the burst could never go through
+			cost = math.Min(cost, cfg.Limit.Burst)
+
+			retryAfter, err := cfg.Store.Take(ctx, key, cost, cfg.Limit)
+			if err != nil {
+				logging.Logger(ctx, logger).Warn("rate limit check failed", "key", key, "error", err)
+				return next(ctx, req)
+			}
+			if retryAfter <= 0 {
+				return next(ctx, req)
+			}
+
+			logging.Logger(ctx, logger).Warn("rate limited", "key", key, "procedure", procedure, "retry_after", retryAfter)
+			return nil, rateLimitedError(retryAfter)
+		}
+	}
+}
+
+// rateLimitKey is the internal service's name, or else the user making the
+// request however they authenticated; tokens share their owner's bucket
+func rateLimitKey(ctx context.Context) string {
+	if svc, ok := ctx.Value(InternalAuthKey).(*InternalService); ok {
+		return "service:" + svc.Name
+	}
+	if userID, ok := ctx.Value(UserIDKey).(uuid.UUID); ok {
+		return "user:" + userID.String()
+	}
+	if user, ok := ctx.Value(UserContextKey).(*User); ok {
+		return "user:" + user.ID
+	}
+	return ""
+}
+
+func rateLimitedError(retryAfter time.Duration) error {
+	err := connect.NewError(connect.CodeResourceExhausted,
+		fmt.Errorf("rate limit exceeded, retry in %s", retryAfter.Round(time.Millisecond)))
+	err.Meta().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	if detail, detailErr := connect.NewErrorDetail(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); detailErr == nil {
+		err.AddDetail(detail)
+	}
+	return err
+}
+
+// ----- in-memory ---------------------------------------------------------------------------
+
+// memoryRateLimitStore implements GCRA: each bucket is just the time it
+// will be full again (its theoretical arrival time)
+type memoryRateLimitStore struct {
+	mu        sync.Mutex
+	tat       map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimitStore keeps buckets in this process, so each replica
+// enforces the limit on its own
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{tat: make(map[string]time.Time)}
+}
+
+func (m *memoryRateLimitStore) Take(_ context.Context, key string, cost float64, limit RateLimit) (time.Duration, error) {
+	now := time.Now()
+	step := seconds(limit.seconds(cost))
+	window := seconds(limit.seconds(limit.Burst))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now)
+
+	tat := m.tat[key]
+	if tat.Before(now) {
+		tat = now
+	}
+	next := tat.Add(step)
+	if wait := next.Sub(now) - window; wait > 0 {
+		return wait, nil
+	}
+
+	m.tat[key] = next
+	return 0, nil
+}
+
+// sweep drops full buckets once a minute so idle users don't pile up
+func (m *memoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < time.Minute {
+		return
+	}
+	m.lastSweep = now
+
+	for key, tat := range m.tat {
+		if tat.Before(now) {
+			delete(m.tat, key)
+		}
+	}
+}
+
+// ----- postgres ----------------------------------------------------------------------------
+
+type postgresRateLimitStore struct {
+	queries   *sqlc.Queries
+	log       *log.Logger
+	lastSweep atomic.Int64
+}
+
+// NewPostgresRateLimitStore shares buckets between replicas through the
+// rate_limit_buckets table, at the cost of a query per request
+func NewPostgresRateLimitStore(queries *sqlc.Queries, logger *log.Logger) RateLimitStore {
+	return &postgresRateLimitStore{queries: queries, log: logger}
+}
+
+func (p *postgresRateLimitStore) Take(ctx context.Context, key string, cost float64, limit RateLimit) (time.Duration, error) {
+	p.sweep(ctx)
+
+	_, err := p.queries.TakeRateLimit(ctx, sqlc.TakeRateLimitParams{
+		Key:          key,
+		CostSeconds:  limit.seconds(cost),
+		BurstSeconds: limit.seconds(limit.Burst),
+	})
+	if err == nil {
+		return 0, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+
+	backlog, err := p.queries.GetRateLimitBacklog(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	wait := seconds(backlog + limit.seconds(cost) - limit.seconds(limit.Burst))
+	// the bucket refilled between the two queries; try again right away
+	return max(wait, time.Millisecond), nil
+}
+
+// sweep deletes full buckets every few minutes; whichever replica gets
+// there first does it
+func (p *postgresRateLimitStore) sweep(ctx context.Context) {
+	now := time.Now().Unix()
+	last := p.lastSweep.Load()
+	if now-last < 300 || !p.lastSweep.CompareAndSwap(last, now) {
+		return
+	}
+
+	if _, err := p.queries.DeleteExpiredRateLimits(ctx); err != nil {
+		p.log.Warn("failed to delete expired rate limits", "error", err)
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
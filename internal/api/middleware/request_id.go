@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+
+	"null-core/internal/logging"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request ID in both directions: the gateway's
+// is kept when it sends one, and every response says which ID it got
+const RequestIDHeader = "X-Request-Id"
+
+// RequestID tags each request with an ID that every log line for it carries
+func RequestID() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = uuid.NewString()
+			}
+
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), id)))
+		})
+	}
+}
+
+// validRequestID keeps caller-chosen IDs short and free of anything that
+// could forge a log line
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
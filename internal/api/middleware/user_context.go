@@ -20,10 +20,14 @@ func UserContext() Middleware {
 			ctx := r.Context()
 
 			// Check if this is an internal service request
-			if isInternalAuth := ctx.Value(InternalAuthKey); isInternalAuth != nil {
+			if svc, ok := ctx.Value(InternalAuthKey).(*InternalService); ok {
 				// For internal services, extract user_id from the request body
 				if userID := extractUserIDFromRequest(r); userID != "" {
 					if parsedID, err := uuid.Parse(userID); err == nil {
+						if !svc.allowsUser(parsedID) {
+							w.WriteHeader(http.StatusForbidden)
+							return
+						}
 						ctx = context.WithValue(ctx, UserIDKey, parsedID)
 						r = r.WithContext(ctx)
 					}
@@ -45,9 +49,9 @@ func UserContext() Middleware {
 
 // extractUserIDFromRequest extracts user_id from Connect-RPC request body
 func extractUserIDFromRequest(r *http.Request) string {
-	// Only process Connect-RPC requests
+	// Plain HTTP endpoints (receipt images) take user_id as a query param
 	if !strings.Contains(r.URL.Path, "/null.v1.") {
-		return ""
+		return r.URL.Query().Get("user_id")
 	}
 
 	// Skip health checks and user service requests that don't need user_id
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	pb "null-core/internal/gen/null/v1"
+	"null-core/internal/logging"
+)
+
+// receiptImagePattern serves receipt images as plain HTTP so clients can
+// stream them straight into an <img> or download, with range support
+const receiptImagePattern = "GET /receipts/{id}/image"
+
+var imageSizeParam = map[string]pb.ReceiptImageSize{
+	"":         pb.ReceiptImageSize_RECEIPT_IMAGE_SIZE_UNSPECIFIED,
+	"original": pb.ReceiptImageSize_RECEIPT_IMAGE_SIZE_UNSPECIFIED,
+	"small":    pb.ReceiptImageSize_RECEIPT_IMAGE_SIZE_SMALL,
+	"medium":   pb.ReceiptImageSize_RECEIPT_IMAGE_SIZE_MEDIUM,
+	"large":    pb.ReceiptImageSize_RECEIPT_IMAGE_SIZE_LARGE,
+}
+
+func (s *Server) serveReceiptImage(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserID(r.Context())
+	if err != nil {
+		http.Error(w, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid receipt id", http.StatusBadRequest)
+		return
+	}
+
+	size, ok := imageSizeParam[r.URL.Query().Get("size")]
+	if !ok {
+		http.Error(w, "size must be one of original, small, medium, large", http.StatusBadRequest)
+		return
+	}
+
+	req := &pb.GetReceiptImageRequest{Id: id, Size: &size}
+	if p := r.URL.Query().Get("page"); p != "" {
+		page, err := strconv.ParseInt(p, 10, 32)
+		if err != nil || page < 1 {
+			http.Error(w, "invalid page", http.StatusBadRequest)
+			return
+		}
+		pageNo := int32(page)
+		req.Page = &pageNo
+	}
+
+	image, err := s.services.Receipts.GetImage(r.Context(), userID, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, "receipt not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logging.Logger(r.Context(), s.log).Error("failed to serve receipt image", "id", id, "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", image.ContentType)
+	// image keys are content hashes, so a receipt's bytes never change
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(image.Data))
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 
 	pb "null-core/internal/gen/null/v1"
+	"null-core/internal/service"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *Server) UploadReceipt(ctx context.Context, req *connect.Request[pb.UploadReceiptRequest]) (*connect.Response[pb.UploadReceiptResponse], error) {
@@ -14,7 +16,21 @@ func (s *Server) UploadReceipt(ctx context.Context, req *connect.Request[pb.Uplo
 		return nil, err
 	}
 
-	receipt, err := s.services.Receipts.Upload(ctx, userID, req.Msg.GetImageData(), req.Msg.GetContentType())
+	var pages []service.ReceiptUpload
+	if len(req.Msg.GetImageData()) > 0 {
+		pages = append(pages, service.ReceiptUpload{
+			Data:        req.Msg.GetImageData(),
+			ContentType: req.Msg.GetContentType(),
+		})
+	}
+	for _, page := range req.Msg.GetPages() {
+		pages = append(pages, service.ReceiptUpload{
+			Data:        page.GetData(),
+			ContentType: page.GetContentType(),
+		})
+	}
+
+	receipt, err := s.services.Receipts.Upload(ctx, userID, pages)
 	if err != nil {
 		return nil, wrapErr(err)
 	}
@@ -87,3 +103,56 @@ func (s *Server) DeleteReceipt(ctx context.Context, req *connect.Request[pb.Dele
 
 	return connect.NewResponse(&pb.DeleteReceiptResponse{}), nil
 }
+
+func (s *Server) RetryReceipt(ctx context.Context, req *connect.Request[pb.RetryReceiptRequest]) (*connect.Response[pb.RetryReceiptResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := s.services.Receipts.Retry(ctx, userID, req.Msg.GetId())
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.RetryReceiptResponse{Receipt: receipt}), nil
+}
+
+func (s *Server) GetReceiptImage(ctx context.Context, req *connect.Request[pb.GetReceiptImageRequest]) (*connect.Response[pb.GetReceiptImageResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := s.services.Receipts.GetImage(ctx, userID, req.Msg)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	resp := &pb.GetReceiptImageResponse{
+		ImageData:   image.Data,
+		ContentType: image.ContentType,
+	}
+	if image.URL != "" {
+		resp.Url = &image.URL
+		resp.UrlExpiresAt = timestamppb.New(image.ExpiresAt)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+func (s *Server) GetPriceHistory(ctx context.Context, req *connect.Request[pb.GetPriceHistoryRequest]) (*connect.Response[pb.GetPriceHistoryResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.services.Receipts.PriceHistory(ctx, userID, req.Msg)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.GetPriceHistoryResponse{
+		Items: items,
+	}), nil
+}
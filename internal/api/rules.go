@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	pb "null-core/internal/gen/null/v1"
+	"null-core/internal/logging"
 	"null-core/internal/rules"
 
 	"connectrpc.com/connect"
@@ -63,6 +64,11 @@ func (s *Server) CreateRule(ctx context.Context, req *connect.Request[pb.CreateR
 		return nil, status.Error(codes.InvalidArgument, "At least one action (category_id or merchant) must be specified")
 	}
 
+	// clients may only create their own rules or accept a suggestion
+	if source := req.Msg.RuleSource; source != nil && *source != "user_created" && *source != "suggested" {
+		return nil, status.Errorf(codes.InvalidArgument, "rule_source must be user_created or suggested, got %q", *source)
+	}
+
 	conditionsBytes, err := req.Msg.GetConditions().MarshalJSON()
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "Invalid conditions JSON")
@@ -88,7 +94,7 @@ func (s *Server) CreateRule(ctx context.Context, req *connect.Request[pb.CreateR
 	}
 	conditionsBytes = normalizedBytes
 
-	rule, err := s.services.Rules.Create(ctx, userID, req.Msg.GetRuleName(), conditionsBytes, req.Msg.CategoryId, req.Msg.Merchant)
+	rule, err := s.services.Rules.Create(ctx, userID, req.Msg.GetRuleName(), conditionsBytes, req.Msg.CategoryId, req.Msg.Merchant, req.Msg.RuleSource)
 	if err != nil {
 		return nil, wrapErr(err)
 	}
@@ -98,9 +104,9 @@ func (s *Server) CreateRule(ctx context.Context, req *connect.Request[pb.CreateR
 		count, err := s.services.Rules.ApplyToExisting(ctx, userID, nil)
 		if err != nil {
 			// log but don't fail the request
-			s.log.Warn("failed to apply rule to existing transactions", "rule_id", rule.RuleId, "error", err)
+			logging.Logger(ctx, s.log).Warn("failed to apply rule to existing transactions", "rule_id", rule.RuleId, "error", err)
 		} else {
-			s.log.Info("applied rule to existing transactions", "rule_id", rule.RuleId, "count", count)
+			logging.Logger(ctx, s.log).Info("applied rule to existing transactions", "rule_id", rule.RuleId, "count", count)
 		}
 	}
 
@@ -157,9 +163,9 @@ func (s *Server) UpdateRule(ctx context.Context, req *connect.Request[pb.UpdateR
 	if req.Msg.ApplyToExisting != nil && *req.Msg.ApplyToExisting {
 		count, err := s.services.Rules.ApplyToExisting(ctx, userID, nil)
 		if err != nil {
-			s.log.Warn("failed to apply rule to existing transactions", "rule_id", ruleID, "error", err)
+			logging.Logger(ctx, s.log).Warn("failed to apply rule to existing transactions", "rule_id", ruleID, "error", err)
 		} else {
-			s.log.Info("applied rule to existing transactions", "rule_id", ruleID, "count", count)
+			logging.Logger(ctx, s.log).Info("applied rule to existing transactions", "rule_id", ruleID, "count", count)
 		}
 	}
 
@@ -230,3 +236,23 @@ func (s *Server) ValidateRule(ctx context.Context, req *connect.Request[pb.Valid
 
 	return connect.NewResponse(response), nil
 }
+
+func (s *Server) SuggestRules(ctx context.Context, req *connect.Request[pb.SuggestRulesRequest]) (*connect.Response[pb.SuggestRulesResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions, err := s.services.Rules.Suggest(ctx, userID, rules.SuggestOptions{
+		MinSupport:   int(req.Msg.GetMinSupport()),
+		MinPrecision: req.Msg.GetMinPrecision(),
+		Limit:        int(req.Msg.GetLimit()),
+	})
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.SuggestRulesResponse{
+		Suggestions: suggestions,
+	}), nil
+}
@@ -4,7 +4,10 @@ import (
 	"net/http"
 	"null-core/internal/api/middleware"
 	"null-core/internal/gen/null/v1/nullv1connect"
+	"null-core/internal/health"
+	"null-core/internal/logging"
 	"null-core/internal/service"
+	"null-core/internal/tracing"
 
 	"connectrpc.com/connect"
 	"connectrpc.com/grpchealth"
@@ -12,24 +15,26 @@ import (
 	"github.com/charmbracelet/log"
 )
 
+// ServiceNames lists every connect service the server registers
+var ServiceNames = []string{
+	"null.v1.UserService",
+	"null.v1.AccountService",
+	"null.v1.TransactionService",
+	"null.v1.CategoryService",
+	"null.v1.RuleService",
+	"null.v1.DashboardService",
+	"null.v1.BackupService",
+	"null.v1.ReceiptService",
+	"null.v1.MerchantService",
+}
+
 type Server struct {
 	services    *service.Services
 	log         *log.Logger
-	healthCheck grpchealth.Checker
+	healthCheck *health.Checker
 }
 
-func NewServer(services *service.Services, logger *log.Logger) *Server {
-	healthCheck := grpchealth.NewStaticChecker(
-		"null.v1.UserService",
-		"null.v1.AccountService",
-		"null.v1.TransactionService",
-		"null.v1.CategoryService",
-		"null.v1.RuleService",
-		"null.v1.DashboardService",
-		"null.v1.BackupService",
-		"null.v1.ReceiptService",
-	)
-
+func NewServer(services *service.Services, healthCheck *health.Checker, logger *log.Logger) *Server {
 	return &Server{
 		services:    services,
 		log:         logger,
@@ -37,57 +42,72 @@ func NewServer(services *service.Services, logger *log.Logger) *Server {
 	}
 }
 
+// SetServingStatus overrides what the dependency probes say about service
 func (s *Server) SetServingStatus(service string, healthy bool) {
-	if checker, ok := s.healthCheck.(*grpchealth.StaticChecker); ok {
-		if healthy {
-			checker.SetStatus(service, grpchealth.StatusServing)
-		} else {
-			checker.SetStatus(service, grpchealth.StatusNotServing)
-		}
-	}
+	s.healthCheck.SetServing(service, healthy)
+}
+
+// SetServing flips the overall status and every service's at once
+func (s *Server) SetServing(healthy bool) {
+	s.SetServingStatus("", healthy)
 }
 
-func (s *Server) GetHandler(authConfig *middleware.AuthConfig) http.Handler {
+// GetHandler builds the API; redactor decides what request and response
+// payloads the debug log shows, and a nil rateLimit turns rate limiting off
+func (s *Server) GetHandler(authConfig *middleware.AuthConfig, cors middleware.CORSConfig, redactor *logging.Redactor, rateLimit *middleware.RateLimitConfig) http.Handler {
 	if authConfig == nil {
 		s.log.Fatal("auth configuration is required")
 	}
 
 	mux := http.NewServeMux()
-	s.registerServices(mux)
+	s.registerServices(mux, redactor, rateLimit)
 
 	stack := middleware.CreateStack(
-		middleware.CORS(),
+		middleware.RequestID(),
+		middleware.CORS(cors),
 		middleware.Auth(authConfig, s.log),
 		middleware.UserContext(),
 	)
 
-	return stack(mux)
+	// probes for the orchestrator, outside auth
+	root := http.NewServeMux()
+	root.Handle("GET /livez", s.healthCheck.LivenessHandler())
+	root.Handle("GET /readyz", s.healthCheck.ReadinessHandler())
+	root.Handle("/", stack(mux))
+
+	return root
 }
 
-func (s *Server) registerServices(mux *http.ServeMux) {
+func (s *Server) registerServices(mux *http.ServeMux, redactor *logging.Redactor, rateLimit *middleware.RateLimitConfig) {
 	healthPath, healthHandler := grpchealth.NewHandler(s.healthCheck)
 	mux.Handle(healthPath, healthHandler)
 
-	reflector := grpcreflect.NewStaticReflector(
-		"null.v1.UserService",
-		"null.v1.AccountService",
-		"null.v1.TransactionService",
-		"null.v1.CategoryService",
-		"null.v1.RuleService",
-		"null.v1.DashboardService",
-		"null.v1.BackupService",
-		"null.v1.ReceiptService",
-	)
+	reflector := grpcreflect.NewStaticReflector(ServiceNames...)
 	reflectPath, reflectHandler := grpcreflect.NewHandlerV1(reflector)
 	mux.Handle(reflectPath, reflectHandler)
 	reflectPathAlpha, reflectHandlerAlpha := grpcreflect.NewHandlerV1Alpha(reflector)
 	mux.Handle(reflectPathAlpha, reflectHandlerAlpha)
 
-	interceptors := connect.WithInterceptors(
-		middleware.ConnectLoggingInterceptor(s.log),
+	tracer, err := tracing.ConnectInterceptor()
+	if err != nil {
+		s.log.Fatal("failed to set up RPC tracing", "error", err)
+	}
+
+	chain := []connect.Interceptor{
+		tracer,
+		middleware.MetricsInterceptor(),
+		middleware.ConnectLoggingInterceptor(s.log, redactor),
+		middleware.AuditInterceptor(s.log.WithPrefix("audit")),
+	}
+	if rateLimit != nil {
+		chain = append(chain, middleware.RateLimitInterceptor(*rateLimit, s.log.WithPrefix("ratelimit")))
+	}
+	chain = append(chain,
+		middleware.InternalAccessInterceptor(),
 		middleware.EnsureUserInterceptor(s.services.Users, s.log),
 		middleware.UserIDExtractor(),
 	)
+	interceptors := connect.WithInterceptors(chain...)
 
 	path, handler := nullv1connect.NewUserServiceHandler(s, interceptors)
 	mux.Handle(path, handler)
@@ -113,6 +133,11 @@ func (s *Server) registerServices(mux *http.ServeMux) {
 	path, handler = nullv1connect.NewReceiptServiceHandler(s, interceptors)
 	mux.Handle(path, handler)
 
+	path, handler = nullv1connect.NewMerchantServiceHandler(s, interceptors)
+	mux.Handle(path, handler)
+
+	mux.HandleFunc(receiptImagePattern, s.serveReceiptImage)
+
 	s.log.Info("all connect-go services registered",
 		"health_endpoint", healthPath,
 	)
@@ -104,3 +104,20 @@ func (s *Server) CategorizeTransactions(ctx context.Context, req *connect.Reques
 		AffectedRows: int64(len(req.Msg.TransactionIds)),
 	}), nil
 }
+
+func (s *Server) ListUncategorizedTransactions(ctx context.Context, req *connect.Request[pb.ListUncategorizedTransactionsRequest]) (*connect.Response[pb.ListUncategorizedTransactionsResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, nextCursor, err := s.services.Transactions.ListUncategorized(ctx, userID, req.Msg.Limit, req.Msg.Cursor)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.ListUncategorizedTransactionsResponse{
+		Transactions: transactions,
+		NextCursor:   nextCursor,
+	}), nil
+}
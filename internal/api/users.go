@@ -43,3 +43,44 @@ func (s *Server) DeleteUser(ctx context.Context, req *connect.Request[pb.DeleteU
 
 	return connect.NewResponse(&pb.DeleteUserResponse{}), nil
 }
+
+func (s *Server) CreateApiToken(ctx context.Context, req *connect.Request[pb.CreateApiTokenRequest]) (*connect.Response[pb.CreateApiTokenResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, secret, err := s.services.Users.CreateAPIToken(ctx, userID, req.Msg)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.CreateApiTokenResponse{Token: token, Secret: secret}), nil
+}
+
+func (s *Server) ListApiTokens(ctx context.Context, req *connect.Request[pb.ListApiTokensRequest]) (*connect.Response[pb.ListApiTokensResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.services.Users.ListAPITokens(ctx, userID)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.ListApiTokensResponse{Tokens: tokens}), nil
+}
+
+func (s *Server) DeleteApiToken(ctx context.Context, req *connect.Request[pb.DeleteApiTokenRequest]) (*connect.Response[pb.DeleteApiTokenResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.services.Users.DeleteAPIToken(ctx, userID, req.Msg.GetId()); err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return connect.NewResponse(&pb.DeleteApiTokenResponse{}), nil
+}
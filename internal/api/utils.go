@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"null-core/internal/api/middleware"
@@ -16,19 +17,23 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// wrapErr maps a service error to the connect code the client sees: wrapped
+// ErrValidation is InvalidArgument, ErrUnimplemented is Unimplemented and
+// anything else is Internal
 func wrapErr(err error) error {
 	if err == nil {
 		return nil
 	}
 
-	if err == service.ErrValidation {
-		return status.Error(codes.InvalidArgument, err.Error())
+	// service errors wrap these with the operation that failed
+	if errors.Is(err, service.ErrValidation) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
 	}
-	if err == service.ErrUnimplemented {
-		return status.Error(codes.Unimplemented, err.Error())
+	if errors.Is(err, service.ErrUnimplemented) {
+		return connect.NewError(connect.CodeUnimplemented, err)
 	}
 
-	return status.Errorf(codes.Internal, "internal error: %v", err)
+	return connect.NewError(connect.CodeInternal, fmt.Errorf("internal error: %w", err))
 }
 
 func getUserID(ctx context.Context) (uuid.UUID, error) {
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"null-core/internal/service"
+
+	"connectrpc.com/connect"
+)
+
+func TestWrapErr(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want connect.Code
+	}{
+		{"validation", service.ErrValidation, connect.CodeInvalidArgument},
+		{"wrapped validation", fmt.Errorf("ReceiptService.Update: invalid currency %q: %w", "CA", service.ErrValidation), connect.CodeInvalidArgument},
+		{"doubly wrapped validation", fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", service.ErrValidation)), connect.CodeInvalidArgument},
+		{"wrapped unimplemented", fmt.Errorf("BackupService.Restore: %w", service.ErrUnimplemented), connect.CodeUnimplemented},
+		{"anything else", errors.New("connection refused"), connect.CodeInternal},
+		{"validation in the message only", errors.New("validation failed"), connect.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrapErr(tt.err)
+			if code := connect.CodeOf(got); code != tt.want {
+				t.Errorf("Expected %v, got %v (%v)", tt.want, code, got)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("Expected %v to still wrap %v", got, tt.err)
+			}
+		})
+	}
+
+	if err := wrapErr(nil); err != nil {
+		t.Errorf("Expected nil to stay nil, got %v", err)
+	}
+}
@@ -247,6 +247,7 @@ func importRules(ctx context.Context, db *sqlc.Queries, userID uuid.UUID, rules
 			CategoryID: categoryID,
 			Conditions: conditionsBytes,
 			Merchant:   merchant,
+			RuleSource: rule.RuleSource,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create rule %q: %w", rule.RuleName, err)
@@ -0,0 +1,176 @@
+// Package classifier implements a small per-user naive Bayes model that
+// predicts a transaction's category from the user's own categorized history.
+// Everything runs in-process; no external service is involved.
+package classifier
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"null-core/internal/db/sqlc"
+	"null-core/internal/rules"
+)
+
+// Prediction is a candidate category with its normalized probability
+type Prediction struct {
+	CategoryID  int64
+	Probability float64
+}
+
+// Model is a multinomial naive Bayes classifier over transaction features
+type Model struct {
+	classes map[int64]*classStats
+	vocab   map[string]struct{}
+	docs    int
+}
+
+type classStats struct {
+	docs     int
+	features map[string]int
+	total    int
+}
+
+// Train builds a model from every transaction that has a category.
+// Manually set categories and rule-assigned ones are treated the same.
+func Train(txs []sqlc.Transaction) *Model {
+	m := &Model{
+		classes: make(map[int64]*classStats),
+		vocab:   make(map[string]struct{}),
+	}
+
+	for i := range txs {
+		tx := &txs[i]
+		if tx.CategoryID == nil {
+			continue
+		}
+		m.add(*tx.CategoryID, Features(tx))
+	}
+
+	return m
+}
+
+// Docs returns how many transactions the model was trained on
+func (m *Model) Docs() int {
+	if m == nil {
+		return 0
+	}
+	return m.docs
+}
+
+// Predict returns up to k categories ordered by probability
+func (m *Model) Predict(tx *sqlc.Transaction, k int) []Prediction {
+	if m == nil || m.docs == 0 || k <= 0 {
+		return nil
+	}
+
+	features := Features(tx)
+	vocabSize := float64(len(m.vocab))
+
+	predictions := make([]Prediction, 0, len(m.classes))
+	for categoryID, class := range m.classes {
+		logProb := math.Log(float64(class.docs) / float64(m.docs))
+		denom := float64(class.total) + vocabSize
+
+		for _, f := range features {
+			if _, known := m.vocab[f]; !known {
+				continue
+			}
+			// laplace smoothing so unseen feature/class pairs don't zero out
+			logProb += math.Log((float64(class.features[f]) + 1) / denom)
+		}
+
+		predictions = append(predictions, Prediction{CategoryID: categoryID, Probability: logProb})
+	}
+
+	normalize(predictions)
+
+	sort.Slice(predictions, func(i, j int) bool {
+		if predictions[i].Probability != predictions[j].Probability {
+			return predictions[i].Probability > predictions[j].Probability
+		}
+		return predictions[i].CategoryID < predictions[j].CategoryID
+	})
+
+	if len(predictions) > k {
+		predictions = predictions[:k]
+	}
+
+	return predictions
+}
+
+// Features extracts the model inputs for a transaction: description and
+// merchant tokens, a log-scale amount bucket, direction and account
+func Features(tx *sqlc.Transaction) []string {
+	var features []string
+
+	if tx.TxDesc != nil {
+		for _, token := range rules.Tokenize(*tx.TxDesc) {
+			features = append(features, "d:"+token)
+		}
+	}
+	if tx.Merchant != nil {
+		for _, token := range rules.Tokenize(*tx.Merchant) {
+			features = append(features, "m:"+token)
+		}
+	}
+
+	features = append(features,
+		"amt:"+amountBucket(tx.TxAmountCents),
+		"dir:"+strconv.Itoa(int(tx.TxDirection)),
+		"acct:"+strconv.FormatInt(tx.AccountID, 10),
+	)
+
+	return features
+}
+
+// ----- internal helpers --------------------------------------------------------------------
+
+func (m *Model) add(categoryID int64, features []string) {
+	class, ok := m.classes[categoryID]
+	if !ok {
+		class = &classStats{features: make(map[string]int)}
+		m.classes[categoryID] = class
+	}
+
+	class.docs++
+	m.docs++
+
+	for _, f := range features {
+		class.features[f]++
+		class.total++
+		m.vocab[f] = struct{}{}
+	}
+}
+
+// amountBucket groups amounts by power of two in dollars, so $4 coffee and
+// $1,200 rent land in different buckets while $11 and $14 share one
+func amountBucket(cents int64) string {
+	dollars := math.Abs(float64(cents)) / 100
+	if dollars < 1 {
+		return "0"
+	}
+	return strconv.Itoa(int(math.Log2(dollars)) + 1)
+}
+
+// normalize turns log probabilities into probabilities summing to 1
+func normalize(predictions []Prediction) {
+	if len(predictions) == 0 {
+		return
+	}
+
+	maxLog := predictions[0].Probability
+	for _, p := range predictions[1:] {
+		maxLog = math.Max(maxLog, p.Probability)
+	}
+
+	sum := 0.0
+	for i := range predictions {
+		predictions[i].Probability = math.Exp(predictions[i].Probability - maxLog)
+		sum += predictions[i].Probability
+	}
+
+	for i := range predictions {
+		predictions[i].Probability /= sum
+	}
+}
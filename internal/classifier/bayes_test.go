@@ -0,0 +1,77 @@
+package classifier
+
+import (
+	"testing"
+
+	"null-core/internal/db/sqlc"
+)
+
+func categorizedTx(desc string, cents int64, categoryID int64) sqlc.Transaction {
+	tx := sqlc.Transaction{TxDesc: &desc, TxAmountCents: cents, AccountID: 1}
+	if categoryID > 0 {
+		tx.CategoryID = &categoryID
+	}
+	return tx
+}
+
+func TestPredict(t *testing.T) {
+	const (
+		coffee    = 1
+		groceries = 2
+		rent      = 3
+	)
+
+	history := []sqlc.Transaction{
+		categorizedTx("STARBUCKS #1234", 550, coffee),
+		categorizedTx("STARBUCKS #9921", 620, coffee),
+		categorizedTx("TIM HORTONS #22", 310, coffee),
+		categorizedTx("LOBLAWS 1021", 8450, groceries),
+		categorizedTx("LOBLAWS 1021", 10230, groceries),
+		categorizedTx("NO FRILLS 554", 6420, groceries),
+		categorizedTx("PROPERTY MGMT ETRANSFER", 180000, rent),
+		categorizedTx("PROPERTY MGMT ETRANSFER", 180000, rent),
+		categorizedTx("UNCATEGORIZED THING", 1000, 0),
+	}
+
+	model := Train(history)
+	if model.Docs() != 8 {
+		t.Fatalf("Expected model trained on 8 transactions, got %d", model.Docs())
+	}
+
+	tests := []struct {
+		name     string
+		tx       sqlc.Transaction
+		expected int64
+	}{
+		{"Known coffee shop", categorizedTx("STARBUCKS #4410", 480, 0), coffee},
+		{"Known grocer", categorizedTx("LOBLAWS 2231", 9100, 0), groceries},
+		{"Rent by amount and tokens", categorizedTx("PROPERTY MGMT", 180000, 0), rent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predictions := model.Predict(&tt.tx, 3)
+			if len(predictions) != 3 {
+				t.Fatalf("Expected 3 predictions, got %d", len(predictions))
+			}
+			if predictions[0].CategoryID != tt.expected {
+				t.Errorf("Expected top category %d, got %+v", tt.expected, predictions)
+			}
+
+			sum := 0.0
+			for _, p := range predictions {
+				sum += p.Probability
+			}
+			if sum < 0.999 || sum > 1.001 {
+				t.Errorf("Expected probabilities to sum to 1, got %f", sum)
+			}
+		})
+	}
+}
+
+func TestPredictEmptyModel(t *testing.T) {
+	tx := categorizedTx("ANYTHING", 100, 0)
+	if predictions := Train(nil).Predict(&tx, 3); predictions != nil {
+		t.Errorf("Expected no predictions from an empty model, got %v", predictions)
+	}
+}
@@ -1,25 +1,142 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path"
+	"reflect"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
 )
 
 type Config struct {
-	ListenAddress string
-	APIKey        string // for for internal service communication
+	ListenAddress       string
+	InternalCredentials []InternalCredential // for internal service communication, built from the two below
 
-	DatabaseURL     string
-	NullGatewayURL  string
-	NullReceiptsURL string
-	ExchangeAPIURL  string
+	InternalCredentialsFile string
+	APIKey                  string // unrestricted legacy key, becomes the "default" credential
+
+	JWTIssuer    string        // expected iss; empty skips the check
+	JWTAudience  string        // expected aud; empty skips the check
+	JWTClockSkew time.Duration // leeway for exp/nbf
+	JWKSRefresh  time.Duration // how often the gateway's keys are refetched
+
+	CORSAllowedOrigins []string
+	CORSAllowedHeaders []string
+
+	DatabaseURL      string
+	DatabaseMaxConns int32 // 0 keeps pgx's default
+	NullGatewayURL   string
+	NullReceiptsURL  string
+	ReceiptParser    string // "ocr" | "manual" | "fake"
+	ExchangeAPIURL   string
+	ExchangeTimeout  time.Duration
 
 	DataDir string // local data directory for file storage
 
+	BlobStore   string // "fs" | "s3"
+	S3Endpoint  string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3Region    string
+	S3UseSSL    bool
+
+	RateLimitRate    float64            // requests per second per user/service; 0 disables
+	RateLimitBurst   float64            // bucket size
+	RateLimitBackend string             // "memory" | "postgres"
+	RateLimitCosts   map[string]float64 // procedure -> cost overrides
+
+	ReceiptWorkers          int           // concurrent OCR jobs per replica
+	ReceiptReviewConfidence float32       // parses below this go to NEEDS_REVIEW
+	ReceiptPollInterval     time.Duration // queue check when no NOTIFY arrives
+	ReceiptOCRTimeout       time.Duration
+	ReceiptMaxAttempts      int32
+	ReceiptMaxImageDim      int // longest side uploads are scaled down to
+
+	MetricsAddress string // where /metrics is served; empty disables it
+
+	ShutdownTimeout     time.Duration // how long to drain requests and jobs on SIGTERM
+	HealthCheckInterval time.Duration // how often dependencies are probed
+
+	TracingExporter    string  // "none" | "stdout" | "file" | "otlp"
+	TracingFile        string  // spans are appended here with the file exporter
+	TracingSampleRatio float64 // share of new traces recorded
+
 	LogLevel  log.Level
 	LogFormat string // "json" | "text"
+	LogFile   string // JSON logs are copied here; empty disables
+
+	LogMaxSize    int           // megabytes before the log file is rotated
+	LogMaxBackups int           // rotated files kept; 0 keeps all
+	LogMaxAge     time.Duration // rotated files older than this are removed; 0 keeps all
+	LogCompress   bool          // gzip rotated files
+
+	LogPayloadFields []string // payload fields logged as-is, e.g. id or *_id; the rest are redacted
+
+	Warnings []string // valid but probably unintended, for the caller to log
+
+	sources map[string]string // setting key -> where its value came from
+	invalid map[string]bool   // keys that failed to decode, already reported
+}
+
+// InternalCredential is the key one of our own services (email parser,
+// receipts) uses, and what it may do with it
+type InternalCredential struct {
+	Name       string      `json:"-"`
+	Key        string      `json:"key"`
+	Procedures []string    `json:"procedures"` // "/null.v1.Svc/Method", "/null.v1.Svc/*" or "*"
+	Users      []uuid.UUID `json:"users"`      // empty means any user
+}
+
+// loadInternalCredentials reads a JSON object of name -> credential, e.g.
+//
+//	{"email-parser": {"key": "...", "procedures": ["/null.v1.TransactionService/CreateTransaction"]}}
+func loadInternalCredentials(path string) ([]InternalCredential, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var byName map[string]InternalCredential
+	if err := json.Unmarshal(data, &byName); err != nil {
+		return nil, fmt.Errorf("not valid JSON: %w", err)
+	}
+
+	var problems []error
+	creds := make([]InternalCredential, 0, len(byName))
+	keys := make(map[string]string, len(byName))
+	for name, cred := range byName {
+		if len(cred.Key) < 16 {
+			problems = append(problems, fmt.Errorf("credential %s needs a key of at least 16 characters", name))
+		}
+		if len(cred.Procedures) == 0 {
+			problems = append(problems, fmt.Errorf("credential %s has no procedures", name))
+		}
+		if other, ok := keys[cred.Key]; ok {
+			problems = append(problems, fmt.Errorf("credentials %s and %s share a key", name, other))
+		}
+		keys[cred.Key] = name
+
+		for i, p := range cred.Procedures {
+			if p != "*" && !strings.HasPrefix(p, "/") {
+				cred.Procedures[i] = "/" + p
+			}
+		}
+		cred.Name = name
+		creds = append(creds, cred)
+	}
+
+	sort.Slice(creds, func(i, j int) bool { return creds[i].Name < creds[j].Name })
+	return creds, errors.Join(problems...)
 }
 
 // safely parse whatever port or address the user provides
@@ -32,63 +149,291 @@ func parseAddress(port string) string {
 	return ":" + port
 }
 
-func Load() Config {
-	apiKey := os.Getenv("API_KEY")
-	if apiKey == "" {
-		panic("API_KEY environment variable is required")
+// ----- loading -----------------------------------------------------------------------------
+
+// Load builds the config from defaults, then the YAML/TOML file named by
+// -config or CONFIG_FILE, then env vars, then flags in args. Every problem
+// found is reported together in the returned error, one per line.
+func Load(name string, args []string) (Config, error) {
+	cfg := Config{sources: make(map[string]string), invalid: make(map[string]bool)}
+
+	configFile := os.Getenv("CONFIG_FILE")
+	flagValues := make(map[string]value)
+	fs := newFlagSet(name, &configFile, flagValues)
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	if fs.NArg() > 0 {
+		return cfg, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
 	}
 
-	nullGatewayURL := os.Getenv("NULL_GATEWAY_URL")
-	if nullGatewayURL == "" {
-		panic("NULL_GATEWAY_URL environment variable is required")
+	var problems []error
+	layers := []map[string]value{defaults()}
+	if configFile != "" {
+		fileValues, err := readFile(configFile)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("config file: %w", err))
+		}
+		for key, v := range fileValues {
+			if lookup(key) == nil {
+				problems = append(problems, fmt.Errorf("%s: unknown setting (in %s)", key, v.source))
+				delete(fileValues, key)
+			}
+		}
+		layers = append(layers, fileValues)
 	}
+	layers = append(layers, readEnv(), flagValues)
 
-	nullReceiptsURL := os.Getenv("NULL_RECEIPTS_URL")
-	if nullReceiptsURL == "" {
-		// TODO: need to make this log print uniform with the app-wide logger
-		// configuration. perhaps create the logger here, use it and then return it?
-		log.Warn("NULL_RECEIPTS_URL is not set!")
+	for _, s := range settings {
+		var v value
+		var ok bool
+		for _, layer := range layers {
+			if lv, found := layer[s.key]; found {
+				v, ok = lv, true
+			}
+		}
+		if !ok {
+			continue
+		}
+		if err := decode(s.target(&cfg), v.raw); err != nil {
+			problems = append(problems, fmt.Errorf("%s %v (from %s)", s.key, err, v.source))
+			cfg.invalid[s.key] = true
+			continue
+		}
+		cfg.sources[s.key] = v.source
 	}
 
-	databaseURL := os.Getenv("DATABASE_URL")
-	if databaseURL == "" {
-		panic("DATABASE_URL environment variable is required")
+	problems = append(problems, cfg.resolve()...)
+	return cfg, errors.Join(problems...)
+}
+
+func defaults() map[string]value {
+	values := make(map[string]value)
+	for _, s := range settings {
+		if s.def != "" {
+			values[s.key] = value{raw: s.def, source: "default"}
+		}
 	}
+	return values
+}
 
-	exchangeAPIURL := os.Getenv("EXCHANGE_API_URL")
-	if exchangeAPIURL == "" {
-		panic("EXCHANGE_API_URL environment variable is required")
+// resolve fills in values that depend on others and checks everything
+// decode can't: required settings, ranges and combinations
+func (c *Config) resolve() []error {
+	var problems []error
+	fail := func(key, format string, args ...any) {
+		if c.invalid[key] {
+			return
+		}
+		problems = append(problems, fmt.Errorf("%s %s", key, fmt.Sprintf(format, args...)))
+	}
+	atLeast := func(key string, d, min time.Duration) {
+		if d < min {
+			fail(key, "must be at least %s", min)
+		}
 	}
 
-	logLevel, err := log.ParseLevel(os.Getenv("LOG_LEVEL"))
-	if err != nil {
-		logLevel = log.InfoLevel
+	// ----- required ---------------
+	for _, required := range []struct{ key, value string }{
+		{"gateway_url", c.NullGatewayURL},
+		{"database.url", c.DatabaseURL},
+		{"exchange.url", c.ExchangeAPIURL},
+	} {
+		if required.value == "" {
+			fail(required.key, "is required ($%s)", lookup(required.key).env)
+		}
 	}
 
-	logFormat := strings.ToLower(strings.TrimSpace(os.Getenv("LOG_FORMAT")))
-	if logFormat != "json" && logFormat != "text" {
-		logFormat = "text"
+	// ----- server -----------------
+	c.ListenAddress = parseAddress(c.ListenAddress)
+	if c.MetricsAddress != "" {
+		c.MetricsAddress = parseAddress(c.MetricsAddress)
+	}
+	if c.MetricsAddress != "" && c.MetricsAddress == c.ListenAddress {
+		fail("metrics_address", "must differ from listen_address")
+	}
+	atLeast("shutdown_timeout", c.ShutdownTimeout, 0)
+	atLeast("health_check_interval", c.HealthCheckInterval, time.Second)
+	if len(c.CORSAllowedOrigins) == 0 {
+		fail("cors.allowed_origins", "must list at least one origin")
 	}
 
-	listenAddr := os.Getenv("LISTEN_ADDRESS")
-	if listenAddr == "" {
-		listenAddr = "127.0.0.1:55555"
+	// ----- auth -------------------
+	// iss/aud are only checked when configured; guessing them from
+	// gateway_url would reject every token a gateway issues under another name
+	if c.JWTIssuer == "" || c.JWTAudience == "" {
+		c.Warnings = append(c.Warnings, "jwt.issuer or jwt.audience is unset, tokens are accepted from any issuer and for any audience the JWKS signs")
 	}
+	atLeast("jwt.clock_skew", c.JWTClockSkew, 0)
+	atLeast("jwt.jwks_refresh_interval", c.JWKSRefresh, time.Minute)
 
-	dataDir := os.Getenv("DATA_DIR")
-	if dataDir == "" {
-		dataDir = "./data"
+	c.InternalCredentials = nil
+	if c.InternalCredentialsFile != "" {
+		creds, err := loadInternalCredentials(c.InternalCredentialsFile)
+		if err != nil {
+			fail("internal_credentials_file", "%s: %v", c.InternalCredentialsFile, err)
+		}
+		c.InternalCredentials = creds
+	}
+	// API_KEY predates per-service credentials and can do anything
+	if c.APIKey != "" {
+		for _, cred := range c.InternalCredentials {
+			if cred.Name == "default" || cred.Key == c.APIKey {
+				fail("api_key", "clashes with internal credential %s", cred.Name)
+			}
+		}
+		c.Warnings = append(c.Warnings, "API_KEY grants unrestricted access, prefer INTERNAL_CREDENTIALS_FILE")
+		c.InternalCredentials = append(c.InternalCredentials, InternalCredential{
+			Name:       "default",
+			Key:        c.APIKey,
+			Procedures: []string{"*"},
+		})
+	}
+
+	// ----- database and exchange --
+	if c.DatabaseMaxConns < 0 {
+		fail("database.max_conns", "must not be negative")
+	}
+	atLeast("exchange.timeout", c.ExchangeTimeout, time.Second)
+
+	// ----- receipts ---------------
+	c.ReceiptParser = strings.ToLower(c.ReceiptParser)
+	if c.ReceiptParser == "" {
+		c.ReceiptParser = "manual"
+		if c.NullReceiptsURL != "" {
+			c.ReceiptParser = "ocr"
+		}
+		c.sources["receipts.parser"] = "receipts.ocr_url"
+	}
+	switch c.ReceiptParser {
+	case "ocr":
+		if c.NullReceiptsURL == "" {
+			fail("receipts.ocr_url", "is required when receipts.parser is ocr")
+		}
+	case "manual":
+		c.Warnings = append(c.Warnings, "no OCR service configured, receipts will need manual entry")
+	case "fake":
+		c.Warnings = append(c.Warnings, "receipts.parser is fake, every receipt parses to the same canned result")
+	default:
+		fail("receipts.parser", "must be ocr, manual or fake")
+	}
+	if c.ReceiptWorkers < 1 {
+		fail("receipts.workers", "must be at least 1")
+	}
+	if c.ReceiptReviewConfidence < 0 || c.ReceiptReviewConfidence > 1 {
+		fail("receipts.review_confidence", "must be between 0 and 1")
+	}
+	atLeast("receipts.poll_interval", c.ReceiptPollInterval, time.Second)
+	atLeast("receipts.ocr_timeout", c.ReceiptOCRTimeout, time.Second)
+	if c.ReceiptMaxAttempts < 1 {
+		fail("receipts.max_attempts", "must be at least 1")
+	}
+	if c.ReceiptMaxImageDim < 256 {
+		fail("receipts.max_image_dim", "must be at least 256")
+	}
+
+	// ----- storage ----------------
+	c.BlobStore = strings.ToLower(c.BlobStore)
+	switch c.BlobStore {
+	case "fs":
+	case "s3":
+		if c.S3Endpoint == "" {
+			fail("storage.s3.endpoint", "is required when storage.backend is s3")
+		}
+		if c.S3Bucket == "" {
+			fail("storage.s3.bucket", "is required when storage.backend is s3")
+		}
+	default:
+		fail("storage.backend", "must be fs or s3")
+	}
+
+	// ----- rate limiting ----------
+	if c.RateLimitRate < 0 {
+		fail("rate_limit.rate", "must not be negative")
+	}
+	if c.RateLimitBurst < 1 {
+		fail("rate_limit.burst", "must be at least 1")
+	}
+	c.RateLimitBackend = strings.ToLower(c.RateLimitBackend)
+	if c.RateLimitBackend != "memory" && c.RateLimitBackend != "postgres" {
+		fail("rate_limit.backend", "must be memory or postgres")
 	}
+	for procedure, cost := range c.RateLimitCosts {
+		if cost < 0 {
+			fail("rate_limit.costs", "cost for %s must not be negative", procedure)
+		}
+	}
+
+	// ----- tracing and logs -------
+	c.TracingExporter = strings.ToLower(c.TracingExporter)
+	switch c.TracingExporter {
+	case "none", "stdout", "otlp":
+	case "file":
+		if c.TracingFile == "" {
+			fail("tracing.file", "is required when tracing.exporter is file")
+		}
+	default:
+		fail("tracing.exporter", "must be none, stdout, file or otlp")
+	}
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		fail("tracing.sample_ratio", "must be between 0 and 1")
+	}
+	c.LogFormat = strings.ToLower(c.LogFormat)
+	if c.LogFormat != "json" && c.LogFormat != "text" {
+		fail("log.format", "must be json or text")
+	}
+	if c.LogMaxSize < 1 {
+		fail("log.max_size", "must be at least 1")
+	}
+	if c.LogMaxBackups < 0 {
+		fail("log.max_backups", "must not be negative")
+	}
+	if c.LogMaxAge < 0 {
+		fail("log.max_age", "must not be negative")
+	}
+	for _, pattern := range c.LogPayloadFields {
+		if _, err := path.Match(pattern, ""); err != nil {
+			fail("log.payload_fields", "has a bad pattern %q", pattern)
+		}
+	}
+
+	return problems
+}
+
+// ----- reporting ---------------------------------------------------------------------------
+
+// Dump writes every setting with its value and where it came from, secrets
+// masked, as `null config check` shows it
+func (c *Config) Dump(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, s := range settings {
+		source := c.sources[s.key]
+		if source == "" {
+			source = "unset"
+		}
+
+		v := formatValue(s.target(c))
+		if s.secret && v != "" {
+			v = "********"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", s.key, v, source)
+	}
+	tw.Flush()
+}
 
-	return Config{
-		ListenAddress:   parseAddress(listenAddr),
-		APIKey:          apiKey,
-		DatabaseURL:     databaseURL,
-		NullGatewayURL:  nullGatewayURL,
-		NullReceiptsURL: nullReceiptsURL,
-		ExchangeAPIURL:  exchangeAPIURL,
-		DataDir:         dataDir,
-		LogLevel:        logLevel,
-		LogFormat:       logFormat,
+func formatValue(target any) string {
+	switch t := target.(type) {
+	case *[]string:
+		return strings.Join(*t, ",")
+	case *map[string]float64:
+		pairs := make([]string, 0, len(*t))
+		for procedure, cost := range *t {
+			pairs = append(pairs, fmt.Sprintf("%s=%g", procedure, cost))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	case *log.Level:
+		return t.String()
 	}
+	return fmt.Sprint(reflect.ValueOf(target).Elem().Interface())
 }
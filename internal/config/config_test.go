@@ -0,0 +1,276 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// isolateEnv unsets every setting's env var for the test, so the
+// environment running the tests can't leak in
+func isolateEnv(t *testing.T) {
+	t.Helper()
+	for _, s := range append(settings, setting{env: "CONFIG_FILE"}) {
+		t.Setenv(s.env, "")
+		os.Unsetenv(s.env)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const requiredYAML = `
+gateway_url: http://gateway:55550
+database:
+  url: postgres://localhost/null
+exchange:
+  url: https://api.frankfurter.dev/v1
+`
+
+func TestLoadPrecedence(t *testing.T) {
+	yamlFile := requiredYAML + `
+listen_address: 8080
+receipts:
+  workers: 4
+  max_attempts: 7
+log:
+  level: debug
+`
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		args        []string
+		wantWorkers int
+		wantSource  string
+	}{
+		{name: "file beats default", wantWorkers: 4},
+		{name: "env beats file", env: map[string]string{"RECEIPT_WORKERS": "6"}, wantWorkers: 6, wantSource: "$RECEIPT_WORKERS"},
+		{name: "flag beats env", env: map[string]string{"RECEIPT_WORKERS": "6"}, args: []string{"-receipts.workers=8"}, wantWorkers: 8, wantSource: "-receipts.workers"},
+		{name: "empty env is unset", env: map[string]string{"RECEIPT_WORKERS": ""}, wantWorkers: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateEnv(t)
+			path := writeFile(t, "null.yaml", yamlFile)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg, err := Load("null", append([]string{"-config", path}, tt.args...))
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+
+			if cfg.ReceiptWorkers != tt.wantWorkers {
+				t.Errorf("Expected %d workers, got %d", tt.wantWorkers, cfg.ReceiptWorkers)
+			}
+			wantSource := tt.wantSource
+			if wantSource == "" {
+				wantSource = path
+			}
+			if got := cfg.sources["receipts.workers"]; got != wantSource {
+				t.Errorf("Expected workers from %s, got %s", wantSource, got)
+			}
+
+			// untouched by env and flags, so still from the file or defaults
+			if cfg.ListenAddress != ":8080" {
+				t.Errorf("Expected a bare YAML port to become :8080, got %q", cfg.ListenAddress)
+			}
+			if cfg.ReceiptMaxAttempts != 7 || cfg.LogLevel != log.DebugLevel {
+				t.Errorf("Expected file values, got max_attempts=%d level=%s", cfg.ReceiptMaxAttempts, cfg.LogLevel)
+			}
+			if cfg.ShutdownTimeout != 30*time.Second || cfg.sources["shutdown_timeout"] != "default" {
+				t.Errorf("Expected the default shutdown timeout, got %s from %s", cfg.ShutdownTimeout, cfg.sources["shutdown_timeout"])
+			}
+		})
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	isolateEnv(t)
+	path := writeFile(t, "null.toml", `
+gateway_url = "http://gateway:55550"
+
+[database]
+url = "postgres://localhost/null"
+max_conns = 20
+
+[exchange]
+url = "https://api.frankfurter.dev/v1"
+
+[cors]
+allowed_origins = ["https://app.example", "https://admin.example"]
+
+[rate_limit]
+burst = 80
+
+[rate_limit.costs]
+"null.v1.ReceiptService/UploadReceipt" = 5
+
+[storage.s3]
+use_ssl = false
+`)
+
+	cfg, err := Load("null", []string{"-config", path})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.DatabaseMaxConns != 20 {
+		t.Errorf("Expected max_conns 20, got %d", cfg.DatabaseMaxConns)
+	}
+	if want := []string{"https://app.example", "https://admin.example"}; !reflect.DeepEqual(cfg.CORSAllowedOrigins, want) {
+		t.Errorf("Expected origins %v, got %v", want, cfg.CORSAllowedOrigins)
+	}
+	if cfg.RateLimitBurst != 80 {
+		t.Errorf("Expected burst 80, got %g", cfg.RateLimitBurst)
+	}
+	if want := map[string]float64{"/null.v1.ReceiptService/UploadReceipt": 5}; !reflect.DeepEqual(cfg.RateLimitCosts, want) {
+		t.Errorf("Expected the costs table kept whole, got %v", cfg.RateLimitCosts)
+	}
+	if cfg.S3UseSSL {
+		t.Error("Expected nested storage.s3.use_ssl to be flattened and applied")
+	}
+}
+
+func TestLoadAllowEmpty(t *testing.T) {
+	isolateEnv(t)
+	path := writeFile(t, "null.yaml", requiredYAML)
+	t.Setenv("METRICS_ADDRESS", "")
+	t.Setenv("LISTEN_ADDRESS", "")
+
+	cfg, err := Load("null", []string{"-config", path})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.MetricsAddress != "" || cfg.sources["metrics_address"] != "$METRICS_ADDRESS" {
+		t.Errorf("Expected an empty METRICS_ADDRESS to turn metrics off, got %q from %s", cfg.MetricsAddress, cfg.sources["metrics_address"])
+	}
+	if cfg.ListenAddress != "127.0.0.1:55555" {
+		t.Errorf("Expected an empty LISTEN_ADDRESS to be ignored, got %q", cfg.ListenAddress)
+	}
+
+	if _, err := Load("null", []string{"-config", path, "-listen-address="}); err == nil {
+		t.Error("Expected an empty flag for a setting that can't be empty to fail")
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	isolateEnv(t)
+	path := writeFile(t, "null.yaml", `
+database:
+  url: postgres://localhost/null
+recipts:
+  workers: 2
+rate_limit:
+  backend: redis
+`)
+	t.Setenv("SHUTDOWN_TIMEOUT", "soon")
+	t.Setenv("EXCHANGE_API_URL", "https://api.frankfurter.dev/v1")
+
+	_, err := Load("null", []string{"-config", path, "-receipts.workers=many"})
+	if err == nil {
+		t.Fatal("Expected Load to fail")
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"recipts.workers: unknown setting",
+		"shutdown_timeout must be a duration like 30s or 15m (from $SHUTDOWN_TIMEOUT)",
+		"receipts.workers must be a whole number (from -receipts.workers)",
+		"gateway_url is required ($NULL_GATEWAY_URL)",
+		"rate_limit.backend must be memory or postgres",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected %q among the problems, got:\n%s", want, msg)
+		}
+	}
+
+	// a value that failed to decode isn't reported a second time by resolve
+	if strings.Contains(msg, "shutdown_timeout must be at least") {
+		t.Errorf("Expected shutdown_timeout reported once, got:\n%s", msg)
+	}
+	if lines := strings.Count(msg, "\n") + 1; lines != 5 {
+		t.Errorf("Expected 5 problems, one per line, got %d:\n%s", lines, msg)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var (
+		s     string
+		b     bool
+		i     int
+		i32   int32
+		f64   float64
+		f32   float32
+		d     time.Duration
+		list  []string
+		costs map[string]float64
+		level log.Level
+	)
+
+	tests := []struct {
+		name    string
+		target  any
+		raw     any
+		want    any
+		wantErr bool
+	}{
+		{"string trimmed", &s, " x ", "x", false},
+		{"string from number", &s, 8080, "8080", false},
+		{"string from list", &s, []any{"a"}, nil, true},
+		{"bool from file", &b, true, true, false},
+		{"bool from env", &b, "false", false, false},
+		{"bool junk", &b, "yes please", nil, true},
+		{"int from file", &i, 42, 42, false},
+		{"int from env", &i, " 7 ", 7, false},
+		{"int fraction", &i, "1.5", nil, true},
+		{"int32 out of range", &i32, "3000000000", nil, true},
+		{"float64 from int", &f64, 3, 3.0, false},
+		{"float32 from env", &f32, "0.6", float32(0.6), false},
+		{"float junk", &f64, "lots", nil, true},
+		{"duration", &d, "15m", 15 * time.Minute, false},
+		{"duration without unit", &d, 30, nil, true},
+		{"list from env", &list, "a, b,,c", []string{"a", "b", "c"}, false},
+		{"list from file", &list, []any{"a", 1}, []string{"a", "1"}, false},
+		{"list of tables", &list, []any{map[string]any{}}, nil, true},
+		{"costs", &costs, "/null.v1.A/B=2, null.v1.A/C=0.5", map[string]float64{"/null.v1.A/B": 2, "/null.v1.A/C": 0.5}, false},
+		{"costs from file", &costs, map[string]any{"/null.v1.A/B": 3}, map[string]float64{"/null.v1.A/B": 3}, false},
+		{"costs missing cost", &costs, "/null.v1.A/B", nil, true},
+		{"costs bad cost in file", &costs, map[string]any{"/null.v1.A/B": "lots"}, nil, true},
+		{"log level", &level, "warn", log.WarnLevel, false},
+		{"log level junk", &level, "loud", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := decode(tt.target, tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected decoding %v to fail", tt.raw)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected %v to decode, got %v", tt.raw, err)
+			}
+			if got := reflect.ValueOf(tt.target).Elem().Interface(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
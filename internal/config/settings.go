@@ -0,0 +1,137 @@
+package config
+
+// setting is one configurable value. key is its path in the config file
+// and, with underscores as dashes, its flag name.
+type setting struct {
+	key    string
+	env    string
+	def    string // applied like any other value; "" leaves the zero value
+	usage  string
+	target func(c *Config) any // pointer to the field the value lands in
+
+	allowEmpty bool // an empty env var or flag means "off" rather than "unset"
+	secret     bool // masked by Dump
+}
+
+// defaultPayloadFields are safe to log: identifiers, counts, timestamps and
+// paging. Amounts, descriptions, notes and emails stay redacted.
+const defaultPayloadFields = "id,ids,*_id,*_ids,*_at,*_count,currency,*_currency,limit,offset,cursor,next_cursor,granularity,period_type"
+
+// settings lists everything that can be configured, in the order Dump
+// prints them. Later sources win: defaults, then the file, then env, then
+// flags.
+var settings = []setting{
+	// ----- server ------------------------------------------------------------------------------
+	{key: "listen_address", env: "LISTEN_ADDRESS", def: "127.0.0.1:55555", usage: "API listen address (port or host:port)",
+		target: func(c *Config) any { return &c.ListenAddress }},
+	{key: "metrics_address", env: "METRICS_ADDRESS", def: "127.0.0.1:55556", usage: "Prometheus /metrics listen address; empty disables",
+		target: func(c *Config) any { return &c.MetricsAddress }, allowEmpty: true},
+	{key: "shutdown_timeout", env: "SHUTDOWN_TIMEOUT", def: "30s", usage: "how long to drain requests and jobs on stop",
+		target: func(c *Config) any { return &c.ShutdownTimeout }},
+	{key: "health_check_interval", env: "HEALTH_CHECK_INTERVAL", def: "15s", usage: "how often dependencies are probed",
+		target: func(c *Config) any { return &c.HealthCheckInterval }},
+	{key: "cors.allowed_origins", env: "CORS_ALLOWED_ORIGINS", def: "*", usage: "origins browsers may call from, comma separated",
+		target: func(c *Config) any { return &c.CORSAllowedOrigins }},
+	{key: "cors.allowed_headers", env: "CORS_ALLOWED_HEADERS", def: "*", usage: "request headers browsers may send, comma separated",
+		target: func(c *Config) any { return &c.CORSAllowedHeaders }},
+
+	// ----- auth --------------------------------------------------------------------------------
+	{key: "gateway_url", env: "NULL_GATEWAY_URL", usage: "null-gateway URL (auth + proxy)",
+		target: func(c *Config) any { return &c.NullGatewayURL }},
+	{key: "internal_credentials_file", env: "INTERNAL_CREDENTIALS_FILE", usage: "JSON file of per-service internal keys",
+		target: func(c *Config) any { return &c.InternalCredentialsFile }},
+	{key: "api_key", env: "API_KEY", usage: "internal key with unrestricted access",
+		target: func(c *Config) any { return &c.APIKey }, secret: true},
+	{key: "jwt.issuer", env: "JWT_ISSUER", usage: "expected iss; unset or empty skips the check",
+		target: func(c *Config) any { return &c.JWTIssuer }, allowEmpty: true},
+	{key: "jwt.audience", env: "JWT_AUDIENCE", usage: "expected aud; unset or empty skips the check",
+		target: func(c *Config) any { return &c.JWTAudience }, allowEmpty: true},
+	{key: "jwt.clock_skew", env: "JWT_CLOCK_SKEW", def: "30s", usage: "leeway for exp/nbf",
+		target: func(c *Config) any { return &c.JWTClockSkew }},
+	{key: "jwt.jwks_refresh_interval", env: "JWKS_REFRESH_INTERVAL", def: "15m", usage: "how often the gateway's JWKS is refetched",
+		target: func(c *Config) any { return &c.JWKSRefresh }},
+
+	// ----- database ----------------------------------------------------------------------------
+	{key: "database.url", env: "DATABASE_URL", usage: "PostgreSQL connection string",
+		target: func(c *Config) any { return &c.DatabaseURL }, secret: true},
+	{key: "database.max_conns", env: "DATABASE_MAX_CONNS", def: "0", usage: "connection pool size; 0 lets pgx pick",
+		target: func(c *Config) any { return &c.DatabaseMaxConns }},
+
+	// ----- exchange ----------------------------------------------------------------------------
+	{key: "exchange.url", env: "EXCHANGE_API_URL", usage: "exchange rate API endpoint",
+		target: func(c *Config) any { return &c.ExchangeAPIURL }},
+	{key: "exchange.timeout", env: "EXCHANGE_TIMEOUT", def: "10s", usage: "timeout for exchange API calls",
+		target: func(c *Config) any { return &c.ExchangeTimeout }},
+
+	// ----- receipts ----------------------------------------------------------------------------
+	{key: "receipts.ocr_url", env: "NULL_RECEIPTS_URL", usage: "gRPC endpoint of the receipt OCR service",
+		target: func(c *Config) any { return &c.NullReceiptsURL }},
+	{key: "receipts.parser", env: "RECEIPT_PARSER", usage: "ocr, manual or fake (default: ocr with an OCR URL, else manual)",
+		target: func(c *Config) any { return &c.ReceiptParser }},
+	{key: "receipts.workers", env: "RECEIPT_WORKERS", def: "2", usage: "concurrent OCR jobs per replica",
+		target: func(c *Config) any { return &c.ReceiptWorkers }},
+	{key: "receipts.review_confidence", env: "RECEIPT_REVIEW_CONFIDENCE", def: "0.6", usage: "parses below this go to the review queue",
+		target: func(c *Config) any { return &c.ReceiptReviewConfidence }},
+	{key: "receipts.poll_interval", env: "RECEIPT_POLL_INTERVAL", def: "30s", usage: "how often the worker checks the queue without a notification",
+		target: func(c *Config) any { return &c.ReceiptPollInterval }},
+	{key: "receipts.ocr_timeout", env: "RECEIPT_OCR_TIMEOUT", def: "5m", usage: "how long one OCR attempt may take",
+		target: func(c *Config) any { return &c.ReceiptOCRTimeout }},
+	{key: "receipts.max_attempts", env: "RECEIPT_MAX_ATTEMPTS", def: "5", usage: "OCR attempts before a receipt is marked failed",
+		target: func(c *Config) any { return &c.ReceiptMaxAttempts }},
+	{key: "receipts.max_image_dim", env: "RECEIPT_MAX_IMAGE_DIM", def: "1920", usage: "uploads are scaled down to this longest side, in pixels",
+		target: func(c *Config) any { return &c.ReceiptMaxImageDim }},
+
+	// ----- storage -----------------------------------------------------------------------------
+	{key: "storage.data_dir", env: "DATA_DIR", def: "./data", usage: "local directory for file storage",
+		target: func(c *Config) any { return &c.DataDir }},
+	{key: "storage.backend", env: "BLOB_STORE", def: "fs", usage: "receipt image storage: fs or s3",
+		target: func(c *Config) any { return &c.BlobStore }},
+	{key: "storage.s3.endpoint", env: "S3_ENDPOINT", usage: "S3-compatible endpoint (host:port)",
+		target: func(c *Config) any { return &c.S3Endpoint }},
+	{key: "storage.s3.bucket", env: "S3_BUCKET", usage: "bucket for receipt images",
+		target: func(c *Config) any { return &c.S3Bucket }},
+	{key: "storage.s3.access_key", env: "S3_ACCESS_KEY", usage: "S3 access key",
+		target: func(c *Config) any { return &c.S3AccessKey }, secret: true},
+	{key: "storage.s3.secret_key", env: "S3_SECRET_KEY", usage: "S3 secret key",
+		target: func(c *Config) any { return &c.S3SecretKey }, secret: true},
+	{key: "storage.s3.region", env: "S3_REGION", usage: "S3 region",
+		target: func(c *Config) any { return &c.S3Region }},
+	{key: "storage.s3.use_ssl", env: "S3_USE_SSL", def: "true", usage: "use HTTPS for the S3 endpoint",
+		target: func(c *Config) any { return &c.S3UseSSL }},
+
+	// ----- rate limiting -----------------------------------------------------------------------
+	{key: "rate_limit.rate", env: "RATE_LIMIT_RATE", def: "10", usage: "requests/second per user or service; 0 disables",
+		target: func(c *Config) any { return &c.RateLimitRate }},
+	{key: "rate_limit.burst", env: "RATE_LIMIT_BURST", def: "50", usage: "requests allowed in a burst",
+		target: func(c *Config) any { return &c.RateLimitBurst }},
+	{key: "rate_limit.backend", env: "RATE_LIMIT_BACKEND", def: "memory", usage: "limiter state: memory or postgres",
+		target: func(c *Config) any { return &c.RateLimitBackend }},
+	{key: "rate_limit.costs", env: "RATE_LIMIT_COSTS", usage: "per-procedure costs, /null.v1.Svc/Method=5,...",
+		target: func(c *Config) any { return &c.RateLimitCosts }},
+
+	// ----- tracing -----------------------------------------------------------------------------
+	{key: "tracing.exporter", env: "TRACING_EXPORTER", def: "none", usage: "span exporter: none, stdout, file or otlp",
+		target: func(c *Config) any { return &c.TracingExporter }},
+	{key: "tracing.file", env: "TRACING_FILE", def: "traces.json", usage: "where the file exporter appends spans",
+		target: func(c *Config) any { return &c.TracingFile }},
+	{key: "tracing.sample_ratio", env: "TRACING_SAMPLE_RATIO", def: "1", usage: "share of new traces recorded, 0 to 1",
+		target: func(c *Config) any { return &c.TracingSampleRatio }},
+
+	// ----- logging -----------------------------------------------------------------------------
+	{key: "log.level", env: "LOG_LEVEL", def: "info", usage: "debug, info, warn or error",
+		target: func(c *Config) any { return &c.LogLevel }},
+	{key: "log.format", env: "LOG_FORMAT", def: "text", usage: "json or text",
+		target: func(c *Config) any { return &c.LogFormat }},
+	{key: "log.file", env: "LOG_FILE", def: "app.log", usage: "JSON logs are also written here; empty disables",
+		target: func(c *Config) any { return &c.LogFile }, allowEmpty: true},
+	{key: "log.max_size", env: "LOG_MAX_SIZE", def: "100", usage: "megabytes before the log file is rotated",
+		target: func(c *Config) any { return &c.LogMaxSize }},
+	{key: "log.max_backups", env: "LOG_MAX_BACKUPS", def: "5", usage: "rotated log files kept; 0 keeps all",
+		target: func(c *Config) any { return &c.LogMaxBackups }},
+	{key: "log.max_age", env: "LOG_MAX_AGE", def: "720h", usage: "rotated log files older than this are removed; 0 keeps all",
+		target: func(c *Config) any { return &c.LogMaxAge }},
+	{key: "log.compress", env: "LOG_COMPRESS", def: "true", usage: "gzip rotated log files",
+		target: func(c *Config) any { return &c.LogCompress }},
+	{key: "log.payload_fields", env: "LOG_PAYLOAD_FIELDS", def: defaultPayloadFields, usage: "payload fields logged as-is (names, paths or globs); the rest are redacted",
+		target: func(c *Config) any { return &c.LogPayloadFields }, allowEmpty: true},
+}
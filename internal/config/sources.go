@@ -0,0 +1,324 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/log"
+	"go.yaml.in/yaml/v3"
+)
+
+// value is one raw setting and where it came from, for error messages
+type value struct {
+	raw    any
+	source string
+}
+
+// ----- file --------------------------------------------------------------------------------
+
+// readFile loads a YAML or TOML config file (picked by extension) into
+// dotted keys, e.g. receipts.workers
+func readFile(path string) (map[string]value, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		if err := dec.Decode(&tree); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, &tree); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s: config files must be .yaml, .yml or .toml", path)
+	}
+
+	values := make(map[string]value)
+	flatten("", tree, path, values)
+	return values, nil
+}
+
+// flatten walks nested tables down to settings; a table that is itself a
+// setting (rate_limit.costs) is kept whole
+func flatten(prefix string, tree map[string]any, path string, into map[string]value) {
+	for k, v := range tree {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if sub, ok := v.(map[string]any); ok && lookup(key) == nil {
+			flatten(key, sub, path, into)
+			continue
+		}
+		into[key] = value{raw: v, source: path}
+	}
+}
+
+// ----- env and flags -----------------------------------------------------------------------
+
+func readEnv() map[string]value {
+	values := make(map[string]value)
+	for _, s := range settings {
+		v, ok := os.LookupEnv(s.env)
+		if !ok || (v == "" && !s.allowEmpty) {
+			continue
+		}
+		values[s.key] = value{raw: v, source: "$" + s.env}
+	}
+	return values
+}
+
+func flagName(key string) string {
+	return strings.ReplaceAll(key, "_", "-")
+}
+
+// newFlagSet declares -config plus a flag per setting; parsed values land
+// in values
+func newFlagSet(name string, configFile *string, values map[string]value) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(configFile, "config", *configFile, "YAML or TOML config file (default: $CONFIG_FILE)")
+
+	for _, s := range settings {
+		name := flagName(s.key)
+		fs.Func(name, s.usage+" ($"+s.env+")", func(v string) error {
+			if v == "" && !s.allowEmpty {
+				return errors.New("must not be empty")
+			}
+			values[s.key] = value{raw: v, source: "-" + name}
+			return nil
+		})
+	}
+	return fs
+}
+
+func lookup(key string) *setting {
+	for i := range settings {
+		if settings[i].key == key {
+			return &settings[i]
+		}
+	}
+	return nil
+}
+
+// ----- decoding ----------------------------------------------------------------------------
+
+// decode stores raw in the field target points at. Env vars and flags are
+// always strings; file values arrive already typed.
+func decode(target any, raw any) error {
+	switch t := target.(type) {
+	case *string:
+		s, ok := scalar(raw)
+		if !ok {
+			return errors.New("must be a string")
+		}
+		*t = strings.TrimSpace(s)
+
+	case *bool:
+		switch v := raw.(type) {
+		case bool:
+			*t = v
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return errors.New("must be true or false")
+			}
+			*t = b
+		default:
+			return errors.New("must be true or false")
+		}
+
+	case *int:
+		n, err := integer(raw)
+		if err != nil {
+			return err
+		}
+		*t = int(n)
+
+	case *int32:
+		n, err := integer(raw)
+		if err != nil {
+			return err
+		}
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			return errors.New("is out of range")
+		}
+		*t = int32(n)
+
+	case *float64:
+		f, err := number(raw)
+		if err != nil {
+			return err
+		}
+		*t = f
+
+	case *float32:
+		f, err := number(raw)
+		if err != nil {
+			return err
+		}
+		*t = float32(f)
+
+	case *time.Duration:
+		s, ok := raw.(string)
+		if !ok {
+			return errors.New("must be a duration like 30s or 15m")
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			return errors.New("must be a duration like 30s or 15m")
+		}
+		*t = d
+
+	case *[]string:
+		list, err := stringList(raw)
+		if err != nil {
+			return err
+		}
+		*t = list
+
+	case *map[string]float64:
+		costs, err := costMap(raw)
+		if err != nil {
+			return err
+		}
+		*t = costs
+
+	case *log.Level:
+		s, _ := raw.(string)
+		level, err := log.ParseLevel(strings.TrimSpace(s))
+		if err != nil {
+			return errors.New("must be debug, info, warn or error")
+		}
+		*t = level
+
+	default:
+		return fmt.Errorf("unsupported setting type %T", target)
+	}
+	return nil
+}
+
+// scalar renders a string, number or bool as a string; YAML reads a bare
+// port like 8080 as a number
+func scalar(raw any) (string, bool) {
+	switch v := raw.(type) {
+	case string:
+		return v, true
+	case int, int64, uint64, float64, bool:
+		return fmt.Sprint(v), true
+	}
+	return "", false
+}
+
+func integer(raw any) (int64, error) {
+	switch v := raw.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case string:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err == nil {
+			return n, nil
+		}
+	}
+	return 0, errors.New("must be a whole number")
+}
+
+func number(raw any) (float64, error) {
+	switch v := raw.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err == nil {
+			return f, nil
+		}
+	}
+	return 0, errors.New("must be a number")
+}
+
+// stringList takes a list from a file or a comma separated string
+func stringList(raw any) ([]string, error) {
+	var items []string
+	switch v := raw.(type) {
+	case string:
+		items = strings.Split(v, ",")
+	case []any:
+		for _, item := range v {
+			s, ok := scalar(item)
+			if !ok {
+				return nil, errors.New("must be a list of strings")
+			}
+			items = append(items, s)
+		}
+	default:
+		return nil, errors.New("must be a list of strings")
+	}
+
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list, nil
+}
+
+// costMap takes a procedure -> cost table from a file, or
+// "/null.v1.Svc/Method=5,..." from env and flags
+func costMap(raw any) (map[string]float64, error) {
+	costs := make(map[string]float64)
+	add := func(procedure string, cost float64) {
+		procedure = strings.TrimSpace(procedure)
+		if !strings.HasPrefix(procedure, "/") {
+			procedure = "/" + procedure
+		}
+		costs[procedure] = cost
+	}
+
+	switch v := raw.(type) {
+	case map[string]any:
+		for procedure, c := range v {
+			cost, err := number(c)
+			if err != nil {
+				return nil, fmt.Errorf("cost for %s %w", procedure, err)
+			}
+			add(procedure, cost)
+		}
+	case string:
+		for _, pair := range strings.Split(v, ",") {
+			if pair = strings.TrimSpace(pair); pair == "" {
+				continue
+			}
+			procedure, cost, ok := strings.Cut(pair, "=")
+			c, err := strconv.ParseFloat(strings.TrimSpace(cost), 64)
+			if !ok || err != nil {
+				return nil, errors.New("must look like /null.v1.TransactionService/CreateTransaction=2,...")
+			}
+			add(procedure, c)
+		}
+	default:
+		return nil, errors.New("must map procedures to costs")
+	}
+	return costs, nil
+}
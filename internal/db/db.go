@@ -23,12 +23,22 @@ type DB struct {
 	pool *pgxpool.Pool
 }
 
-func New(dsn string) (*DB, error) {
+// New connects to dsn; maxConns of 0 keeps pgx's default pool size
+func New(dsn string, maxConns int32) (*DB, error) {
 	if dsn == "" {
 		return nil, fmt.Errorf("empty dsn")
 	}
 
-	pool, err := pgxpool.New(context.Background(), dsn)
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("pgxpool.parseconfig: %w", err)
+	}
+	poolConfig.ConnConfig.Tracer = queryTracer{}
+	if maxConns > 0 {
+		poolConfig.MaxConns = maxConns
+	}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("pgxpool.new: %w", err)
 	}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Listen subscribes to a postgres NOTIFY channel and calls onNotify with each
+// payload until ctx is cancelled. The dedicated connection is re-established
+// with backoff if it drops; notifications sent while disconnected are lost,
+// so callers should still poll occasionally.
+func (s *DB) Listen(ctx context.Context, channel string, onNotify func(payload string)) {
+	backoff := time.Second
+	const maxBackoff = 30 * time.Second
+
+	for {
+		err := s.listenOnce(ctx, channel, onNotify)
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.log.Warn("listen connection lost, reconnecting", "channel", channel, "error", err, "backoff", backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+func (s *DB) listenOnce(ctx context.Context, channel string, onNotify func(payload string)) error {
+	pooled, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire: %w", err)
+	}
+
+	// take the connection out of the pool so its LISTEN state never leaks
+	// into a connection handed to queries
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		onNotify(notification.Payload)
+	}
+}
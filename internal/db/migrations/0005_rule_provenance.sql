@@ -0,0 +1,16 @@
+-- +goose Up
+
+--- transactions: which rule set category / merchant ----------------------
+ALTER TABLE transactions
+  ADD COLUMN category_rule_id UUID REFERENCES transaction_rules(rule_id) ON DELETE SET NULL,
+  ADD COLUMN merchant_rule_id UUID REFERENCES transaction_rules(rule_id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tx_category_rule_id ON transactions(category_rule_id) WHERE category_rule_id IS NOT NULL;
+CREATE INDEX idx_tx_merchant_rule_id ON transactions(merchant_rule_id) WHERE merchant_rule_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tx_merchant_rule_id;
+DROP INDEX IF EXISTS idx_tx_category_rule_id;
+ALTER TABLE transactions
+  DROP COLUMN IF EXISTS merchant_rule_id,
+  DROP COLUMN IF EXISTS category_rule_id;
@@ -0,0 +1,35 @@
+-- +goose Up
+
+--- merchants ----------------------------------------------------------
+CREATE TABLE merchants (
+  id                  BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  user_id             UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name                TEXT        NOT NULL,
+  aliases             TEXT[]      NOT NULL DEFAULT '{}',
+  patterns            TEXT[]      NOT NULL DEFAULT '{}',
+  default_category_id BIGINT      REFERENCES categories(id) ON DELETE SET NULL,
+  logo_url            TEXT,
+  color               TEXT,
+  created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CONSTRAINT merchants_user_name_unique UNIQUE (user_id, name),
+  CONSTRAINT merchants_color_hex CHECK (color IS NULL OR color ~ '^#[0-9A-Fa-f]{6}$')
+);
+
+CREATE INDEX idx_merchants_user_id ON merchants(user_id);
+
+CREATE TRIGGER trg_merchants_update
+  BEFORE UPDATE ON merchants
+  FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+
+--- transactions: resolved merchant ------------------------------------
+ALTER TABLE transactions
+  ADD COLUMN merchant_id BIGINT REFERENCES merchants(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tx_merchant_id ON transactions(merchant_id) WHERE merchant_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tx_merchant_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS merchant_id;
+DROP TRIGGER IF EXISTS trg_merchants_update ON merchants;
+DROP TABLE IF EXISTS merchants;
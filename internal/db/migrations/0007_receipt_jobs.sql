@@ -0,0 +1,37 @@
+-- +goose Up
+
+--- receipts: OCR job queue state ------------------------------------------
+ALTER TABLE receipts
+  ADD COLUMN attempts        INT         NOT NULL DEFAULT 0,
+  ADD COLUMN next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  ADD COLUMN last_error      TEXT,
+  ADD COLUMN locked_until    TIMESTAMPTZ;
+
+CREATE INDEX idx_receipts_pending_jobs ON receipts(next_attempt_at, id) WHERE status = 1;
+
+-- wake workers as soon as a receipt becomes pending
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_receipt_pending()
+RETURNS TRIGGER LANGUAGE plpgsql AS $$
+BEGIN
+  PERFORM pg_notify('receipt_jobs', NEW.id::text);
+  RETURN NEW;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_receipts_notify
+  AFTER INSERT OR UPDATE OF status ON receipts
+  FOR EACH ROW
+  WHEN (NEW.status = 1)
+  EXECUTE FUNCTION notify_receipt_pending();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_receipts_notify ON receipts;
+DROP FUNCTION IF EXISTS notify_receipt_pending();
+DROP INDEX IF EXISTS idx_receipts_pending_jobs;
+ALTER TABLE receipts
+  DROP COLUMN IF EXISTS locked_until,
+  DROP COLUMN IF EXISTS last_error,
+  DROP COLUMN IF EXISTS next_attempt_at,
+  DROP COLUMN IF EXISTS attempts;
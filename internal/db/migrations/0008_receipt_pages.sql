@@ -0,0 +1,33 @@
+-- +goose Up
+
+--- receipt pages: multi-image uploads and PDFs ------------------------------
+CREATE TABLE receipt_pages (
+  id           BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  receipt_id   BIGINT      NOT NULL REFERENCES receipts(id) ON DELETE CASCADE,
+  page_no      INT         NOT NULL CHECK (page_no >= 1),
+  image_path   TEXT        NOT NULL,
+  content_type TEXT        NOT NULL,
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (receipt_id, page_no)
+);
+
+CREATE INDEX idx_receipt_pages_image_path ON receipt_pages(image_path);
+
+-- every existing receipt is a single image
+INSERT INTO receipt_pages (receipt_id, page_no, image_path, content_type)
+SELECT
+  id,
+  1,
+  image_path,
+  CASE lower(substring(image_path from '\.([^.]+)$'))
+    WHEN 'jpg'  THEN 'image/jpeg'
+    WHEN 'jpeg' THEN 'image/jpeg'
+    WHEN 'png'  THEN 'image/png'
+    WHEN 'webp' THEN 'image/webp'
+    WHEN 'heic' THEN 'image/heic'
+    ELSE 'application/octet-stream'
+  END
+FROM receipts;
+
+-- +goose Down
+DROP TABLE IF EXISTS receipt_pages;
@@ -0,0 +1,11 @@
+-- +goose Up
+
+--- receipt_items: per-item categories ---------------------------------------
+ALTER TABLE receipt_items
+  ADD COLUMN category_id BIGINT REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_receipt_items_category_id ON receipt_items(category_id) WHERE category_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_receipt_items_category_id;
+ALTER TABLE receipt_items DROP COLUMN IF EXISTS category_id;
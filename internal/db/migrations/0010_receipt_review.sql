@@ -0,0 +1,10 @@
+-- +goose Up
+
+--- receipts: review queue for doubtful OCR results --------------------------
+-- status 5 = needs review; reasons say which checks failed
+ALTER TABLE receipts
+  ADD COLUMN review_reasons TEXT[];
+
+-- +goose Down
+UPDATE receipts SET status = 2 WHERE status = 5;
+ALTER TABLE receipts DROP COLUMN IF EXISTS review_reasons;
@@ -0,0 +1,21 @@
+-- +goose Up
+
+--- api_tokens: personal access tokens ----------------------------------
+-- only the sha256 of the secret is kept; prefix is shown in listings so
+-- users can tell their tokens apart
+CREATE TABLE api_tokens (
+  id           BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  user_id      UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name         TEXT        NOT NULL,
+  token_hash   BYTEA       NOT NULL UNIQUE,
+  prefix       TEXT        NOT NULL,
+  scopes       TEXT[]      NOT NULL,
+  expires_at   TIMESTAMPTZ,
+  last_used_at TIMESTAMPTZ,
+  created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_tokens_user_id ON api_tokens(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_tokens;
@@ -0,0 +1,12 @@
+-- +goose Up
+
+--- rate_limit_buckets: shared limiter state across replicas ------------
+-- tat is the bucket's theoretical arrival time (GCRA). unlogged because
+-- losing it in a crash only resets everyone's limits
+CREATE UNLOGGED TABLE rate_limit_buckets (
+  key TEXT        PRIMARY KEY,
+  tat TIMESTAMPTZ NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS rate_limit_buckets;
@@ -0,0 +1,14 @@
+-- +goose Up
+
+--- transaction_rules: only known sources ----------------------------------
+-- anything the API used to let through becomes a plain user rule first
+UPDATE transaction_rules
+SET rule_source = 'user_created'
+WHERE rule_source NOT IN ('user_created', 'suggested', 'ai_suggested', 'ai_approved');
+
+ALTER TABLE transaction_rules
+  ADD CONSTRAINT check_rule_source
+  CHECK (rule_source IN ('user_created', 'suggested', 'ai_suggested', 'ai_approved'));
+
+-- +goose Down
+ALTER TABLE transaction_rules DROP CONSTRAINT IF EXISTS check_rule_source;
@@ -0,0 +1,35 @@
+-- name: CreateApiToken :one
+insert into api_tokens (user_id, name, token_hash, prefix, scopes, expires_at)
+values (
+  @user_id::uuid,
+  @name::text,
+  @token_hash::bytea,
+  @prefix::text,
+  @scopes::text[],
+  sqlc.narg('expires_at')::timestamptz
+)
+returning *;
+
+-- name: ListApiTokens :many
+select * from api_tokens
+where user_id = @user_id::uuid
+order by created_at desc;
+
+-- name: GetApiTokenByHash :one
+-- expired tokens are treated as missing
+select * from api_tokens
+where token_hash = @token_hash::bytea
+  and (expires_at is null or expires_at > now());
+
+-- name: DeleteApiToken :execrows
+delete from api_tokens
+where id = @id::bigint
+  and user_id = @user_id::uuid;
+
+-- name: TouchApiToken :exec
+-- last_used_at is only precise to the minute, so busy tokens don't write on
+-- every request
+update api_tokens
+set last_used_at = now()
+where id = @id::bigint
+  and (last_used_at is null or last_used_at < now() - interval '1 minute');
@@ -47,22 +47,56 @@ limit COALESCE(sqlc.narg('limit')::int, 10);
 
 -- name: GetTopMerchants :many
 select
-  t.merchant,
+  coalesce(m.name, t.merchant) as merchant,
+  m.id as merchant_id,
+  m.color,
+  m.logo_url,
   COUNT(t.id)::bigint as transaction_count,
   SUM(t.tx_amount_cents)::bigint as total_amount_cents,
   AVG(t.tx_amount_cents)::bigint as avg_amount_cents
 from transactions t
 join accounts a on t.account_id = a.id
 left join account_users au on a.id = au.account_id and au.user_id = @user_id::uuid
+left join merchants m on t.merchant_id = m.id
 where (a.owner_id = @user_id::uuid or au.user_id is not null)
-  and t.merchant is not null
+  and (t.merchant is not null or t.merchant_id is not null)
   and t.tx_direction = 2
   and (sqlc.narg('start')::timestamptz is null or t.tx_date >= sqlc.narg('start')::timestamptz)
   and (sqlc.narg('end')::timestamptz is null or t.tx_date <= sqlc.narg('end')::timestamptz)
-group by t.merchant
+-- transactions linked to a merchant group together regardless of raw text
+group by m.id, coalesce(m.name, t.merchant), m.color, m.logo_url
 order by total_amount_cents desc
 limit COALESCE(sqlc.narg('limit')::int, 10);
 
+-- name: GetItemCategorySpending :many
+-- receipt line items grouped by their own category; uncategorized items
+-- come back with a null category
+select
+  c.id as category_id,
+  c.slug,
+  c.color,
+  ri.unit_currency as currency,
+  COUNT(ri.id)::bigint as item_count,
+  COUNT(distinct r.id)::bigint as receipt_count,
+  SUM(round(ri.quantity * ri.unit_price_cents))::bigint as total_amount_cents
+from receipt_items ri
+join receipts r on ri.receipt_id = r.id
+left join categories c on ri.category_id = c.id
+left join transactions t on r.transaction_id = t.id
+left join categories tc on t.category_id = tc.id
+where r.user_id = @user_id::uuid
+  and (sqlc.narg('start')::date is null or coalesce(r.receipt_date, r.created_at::date) >= sqlc.narg('start')::date)
+  and (sqlc.narg('end')::date is null or coalesce(r.receipt_date, r.created_at::date) <= sqlc.narg('end')::date)
+  -- the linked transaction's category, children included
+  and (
+    sqlc.narg('transaction_category_id')::bigint is null
+    or tc.id = sqlc.narg('transaction_category_id')::bigint
+    or tc.slug like (select slug from categories where id = sqlc.narg('transaction_category_id')::bigint and user_id = @user_id::uuid) || '.%'
+  )
+group by c.id, c.slug, c.color, ri.unit_currency
+order by total_amount_cents desc
+limit COALESCE(sqlc.narg('limit')::int, 50);
+
 -- name: GetMonthlyComparison :many
 select
   to_char(t.tx_date, 'YYYY-MM') as month,
@@ -0,0 +1,104 @@
+-- name: ListMerchants :many
+select
+  *
+from
+  merchants
+where
+  user_id = @user_id::uuid
+order by
+  name;
+
+-- name: GetMerchant :one
+select
+  *
+from
+  merchants
+where
+  id = @id::bigint
+  and user_id = @user_id::uuid;
+
+-- name: CreateMerchant :one
+insert into
+  merchants (user_id, name, aliases, patterns, default_category_id, logo_url, color)
+values
+  (
+    @user_id::uuid,
+    @name::text,
+    @aliases::text[],
+    @patterns::text[],
+    sqlc.narg('default_category_id')::bigint,
+    sqlc.narg('logo_url')::text,
+    sqlc.narg('color')::text
+  )
+returning
+  *;
+
+-- name: UpdateMerchant :exec
+update
+  merchants
+set
+  name = coalesce(sqlc.narg('name')::text, name),
+  aliases = coalesce(sqlc.narg('aliases')::text[], aliases),
+  patterns = coalesce(sqlc.narg('patterns')::text[], patterns),
+  -- 0 / '' clear the field, null leaves it untouched
+  default_category_id = case
+    when sqlc.narg('default_category_id')::bigint is null then default_category_id
+    else nullif(sqlc.narg('default_category_id')::bigint, 0)
+  end,
+  logo_url = case
+    when sqlc.narg('logo_url')::text is null then logo_url
+    else nullif(sqlc.narg('logo_url')::text, '')
+  end,
+  color = case
+    when sqlc.narg('color')::text is null then color
+    else nullif(sqlc.narg('color')::text, '')
+  end
+where
+  id = @id::bigint
+  and user_id = @user_id::uuid;
+
+-- name: DeleteMerchants :execrows
+delete from
+  merchants
+where
+  id = ANY(@ids::bigint[])
+  and user_id = @user_id::uuid;
+
+-- name: BulkSetTransactionMerchant :execrows
+update transactions
+set
+  merchant_id = @merchant_id::bigint,
+  -- rules and manual edits take precedence over the canonical name
+  merchant = case
+    when merchant_manually_set = false and merchant_rule_id is null
+    then @merchant::text
+    else merchant
+  end,
+  category_id = case
+    when category_id is null and category_manually_set = false
+    then sqlc.narg('default_category_id')::bigint
+    else category_id
+  end
+where id = ANY(@transaction_ids::bigint[])
+  and account_id in (
+    select a.id
+    from accounts a
+    left join account_users au on a.id = au.account_id and au.user_id = @user_id::uuid
+    where a.owner_id = @user_id::uuid or au.user_id is not null
+  );
+
+-- name: ReassignMerchantTransactions :execrows
+update transactions
+set
+  merchant_id = @target_id::bigint,
+  merchant = case
+    when merchant_manually_set = false and merchant_rule_id is null
+    then @merchant::text
+    else merchant
+  end
+where merchant_id in (
+    select m.id
+    from merchants m
+    where m.id = ANY(@source_ids::bigint[])
+      and m.user_id = @user_id::uuid
+  );
@@ -0,0 +1,20 @@
+-- name: TakeRateLimit :one
+-- each call pushes tat forward by its cost; returns no row, and changes
+-- nothing, when that would put tat more than the burst ahead of now
+insert into rate_limit_buckets as b (key, tat)
+values (@key::text, now() + make_interval(secs => @cost_seconds::float8))
+on conflict (key) do update
+set tat = greatest(b.tat, now()) + make_interval(secs => @cost_seconds::float8)
+where greatest(b.tat, now()) + make_interval(secs => @cost_seconds::float8)
+  <= now() + make_interval(secs => @burst_seconds::float8)
+returning tat;
+
+-- name: GetRateLimitBacklog :one
+-- seconds the bucket's tat is ahead of now
+select extract(epoch from tat - now())::float8 as backlog
+from rate_limit_buckets
+where key = @key::text;
+
+-- name: DeleteExpiredRateLimits :execrows
+delete from rate_limit_buckets
+where tat < now();
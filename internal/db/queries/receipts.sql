@@ -54,7 +54,8 @@ SET
   tax_cents      = coalesce(sqlc.narg('tax_cents')::bigint, tax_cents),
   total_cents    = coalesce(sqlc.narg('total_cents')::bigint, total_cents),
   confidence     = coalesce(sqlc.narg('confidence')::real, confidence),
-  status         = coalesce(sqlc.narg('status')::smallint, status)
+  status         = coalesce(sqlc.narg('status')::smallint, status),
+  review_reasons = coalesce(sqlc.narg('review_reasons')::text[], review_reasons)
 WHERE id = sqlc.arg(id)::bigint
   AND user_id = sqlc.arg(user_id)::uuid
 RETURNING *;
@@ -64,12 +65,98 @@ DELETE FROM receipts
 WHERE id = sqlc.arg(id)::bigint
   AND user_id = sqlc.arg(user_id)::uuid;
 
--- name: GetPendingReceipts :many
-SELECT *
-FROM receipts
+-- name: CountBlobReferences :one
+-- identical uploads share a content-addressed blob; only delete it once
+-- no page references it
+SELECT count(*)
+FROM receipt_pages rp
+JOIN receipts r ON rp.receipt_id = r.id
+WHERE r.user_id = sqlc.arg(user_id)::uuid
+  AND rp.image_path = sqlc.arg(image_path)::text;
+
+-- name: LockBlob :exec
+-- serializes uploads and deletes sharing a blob until the transaction ends
+SELECT pg_advisory_xact_lock(hashtextextended(sqlc.arg(image_path)::text, 0));
+
+-- name: ClaimPendingReceipts :many
+-- leases due jobs to this worker; SKIP LOCKED keeps replicas from claiming
+-- the same receipt and the lease lets another worker pick it up after a crash
+UPDATE receipts
+SET
+  attempts     = attempts + 1,
+  locked_until = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::int)
+WHERE id IN (
+  SELECT id
+  FROM receipts
+  WHERE status = 1
+    AND attempts < sqlc.arg(max_attempts)::int
+    AND next_attempt_at <= NOW()
+    AND (locked_until IS NULL OR locked_until < NOW())
+  ORDER BY next_attempt_at ASC, id ASC
+  LIMIT sqlc.arg(lim)::int
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: FailExpiredReceiptLeases :execrows
+-- a lease that ran out means the worker crashed, was killed or hung; that
+-- used up the attempt, so a job out of attempts fails instead of being
+-- claimed forever
+UPDATE receipts
+SET
+  status       = 4,
+  locked_until = NULL,
+  last_error   = CASE WHEN locked_until IS NOT NULL THEN 'lease expired' ELSE last_error END
 WHERE status = 1
-ORDER BY created_at ASC
-LIMIT 20;
+  AND attempts >= sqlc.arg(max_attempts)::int
+  AND (locked_until IS NULL OR locked_until < NOW());
+
+-- name: FailReceiptAttempt :exec
+UPDATE receipts
+SET
+  last_error      = sqlc.arg(last_error)::text,
+  locked_until    = NULL,
+  next_attempt_at = NOW() + make_interval(secs => sqlc.arg(backoff_seconds)::int),
+  status          = CASE WHEN attempts >= sqlc.arg(max_attempts)::int THEN 4 ELSE status END
+WHERE id = sqlc.arg(id)::bigint;
+
+-- name: LockReceiptLease :one
+-- row-locks a job for its results only while this worker's lease is live
+SELECT id
+FROM receipts
+WHERE id = sqlc.arg(id)::bigint
+  AND status = 1
+  AND locked_until > NOW()
+FOR UPDATE;
+
+-- name: CompleteReceiptJob :exec
+UPDATE receipts
+SET
+  locked_until = NULL,
+  last_error   = NULL
+WHERE id = sqlc.arg(id)::bigint;
+
+-- name: CountReceiptQueue :one
+-- queue depth across all users, for metrics
+SELECT
+  count(*) FILTER (WHERE status = 1)::bigint                             AS pending,
+  count(*) FILTER (WHERE status = 1 AND next_attempt_at <= NOW())::bigint AS due,
+  count(*) FILTER (WHERE status = 5)::bigint                             AS needs_review
+FROM receipts;
+
+-- name: RetryReceipt :one
+UPDATE receipts
+SET
+  status          = 1,
+  attempts        = 0,
+  next_attempt_at = NOW(),
+  locked_until    = NULL,
+  last_error      = NULL
+WHERE id = sqlc.arg(id)::bigint
+  AND user_id = sqlc.arg(user_id)::uuid
+  -- a worker holding the lease would race a second OCR run
+  AND (status <> 1 OR locked_until IS NULL OR locked_until < NOW())
+RETURNING *;
 
 -- name: CreateReceiptItem :one
 INSERT INTO receipt_items (
@@ -79,7 +166,8 @@ INSERT INTO receipt_items (
   quantity,
   unit_price_cents,
   unit_currency,
-  sort_order
+  sort_order,
+  category_id
 )
 VALUES (
   sqlc.arg(receipt_id)::bigint,
@@ -88,10 +176,40 @@ VALUES (
   sqlc.arg(quantity)::double precision,
   sqlc.arg(unit_price_cents)::bigint,
   sqlc.arg(unit_currency)::char(3),
-  sqlc.arg(sort_order)::int
+  sqlc.arg(sort_order)::int,
+  sqlc.narg('category_id')::bigint
 )
 RETURNING *;
 
+-- name: UpdateReceiptItemsCurrency :exec
+UPDATE receipt_items
+SET unit_currency = sqlc.arg(unit_currency)::char(3)
+WHERE receipt_id = sqlc.arg(receipt_id)::bigint;
+
+-- name: ListItemPrices :many
+-- priced line items on the user's parsed receipts, oldest first. names are
+-- normalized in Go so the rules can improve without a backfill
+SELECT
+  ri.id,
+  ri.receipt_id,
+  ri.raw_name,
+  ri.name,
+  ri.quantity,
+  ri.unit_price_cents,
+  ri.unit_currency,
+  coalesce(r.receipt_date, r.created_at::date)::date AS purchased_on,
+  coalesce(m.name, r.merchant, t.merchant) AS merchant
+FROM receipt_items ri
+JOIN receipts r ON ri.receipt_id = r.id
+LEFT JOIN transactions t ON r.transaction_id = t.id
+LEFT JOIN merchants m ON t.merchant_id = m.id
+WHERE r.user_id = sqlc.arg(user_id)::uuid
+  AND r.status IN (2, 3)
+  AND ri.unit_price_cents > 0
+  AND (sqlc.narg('start')::date IS NULL OR coalesce(r.receipt_date, r.created_at::date) >= sqlc.narg('start')::date)
+  AND (sqlc.narg('end')::date IS NULL OR coalesce(r.receipt_date, r.created_at::date) <= sqlc.narg('end')::date)
+ORDER BY purchased_on ASC, ri.receipt_id ASC, ri.sort_order ASC;
+
 -- name: ListReceiptItems :many
 SELECT *
 FROM receipt_items
@@ -101,3 +219,62 @@ ORDER BY sort_order ASC, id ASC;
 -- name: DeleteReceiptItemsByReceipt :exec
 DELETE FROM receipt_items
 WHERE receipt_id = sqlc.arg(receipt_id)::bigint;
+
+-- name: CreateReceiptPage :one
+INSERT INTO receipt_pages (
+  receipt_id,
+  page_no,
+  image_path,
+  content_type
+)
+VALUES (
+  sqlc.arg(receipt_id)::bigint,
+  sqlc.arg(page_no)::int,
+  sqlc.arg(image_path)::text,
+  sqlc.arg(content_type)::text
+)
+RETURNING *;
+
+-- name: ListReceiptPages :many
+SELECT *
+FROM receipt_pages
+WHERE receipt_id = sqlc.arg(receipt_id)::bigint
+ORDER BY page_no ASC;
+
+-- name: GetReceiptPage :one
+SELECT *
+FROM receipt_pages
+WHERE receipt_id = sqlc.arg(receipt_id)::bigint
+  AND page_no = sqlc.arg(page_no)::int;
+
+-- name: ListReceiptLinkCandidates :many
+SELECT
+  t.id,
+  t.tx_date,
+  t.tx_amount_cents,
+  t.tx_currency,
+  t.tx_desc,
+  t.merchant,
+  t.foreign_amount_cents,
+  t.foreign_currency,
+  t.account_id,
+  a.name AS account_name
+FROM transactions t
+JOIN accounts a ON t.account_id = a.id
+LEFT JOIN account_users au ON a.id = au.account_id AND au.user_id = sqlc.arg(user_id)::uuid
+WHERE (a.owner_id = sqlc.arg(user_id)::uuid OR au.user_id IS NOT NULL)
+  AND t.tx_direction = 2
+  AND t.tx_date >= sqlc.arg(start_date)::timestamptz
+  AND t.tx_date < sqlc.arg(end_date)::timestamptz
+  AND (
+    t.tx_amount_cents BETWEEN sqlc.arg(min_cents)::bigint AND sqlc.arg(max_cents)::bigint
+    OR t.foreign_amount_cents BETWEEN sqlc.arg(min_cents)::bigint AND sqlc.arg(max_cents)::bigint
+  )
+  AND NOT EXISTS (
+    SELECT 1
+    FROM receipts r
+    WHERE r.transaction_id = t.id
+      AND r.id <> sqlc.arg(receipt_id)::bigint
+  )
+ORDER BY t.tx_date DESC, t.id DESC
+LIMIT 50;
@@ -11,8 +11,8 @@ where rule_id = @rule_id::uuid
   and user_id = @user_id::uuid;
 
 -- name: CreateRule :one
-insert into transaction_rules (user_id, rule_name, category_id, conditions, merchant)
-values (@user_id::uuid, @rule_name::text, @category_id::bigint, @conditions::jsonb, @merchant::text)
+insert into transaction_rules (user_id, rule_name, category_id, conditions, merchant, rule_source)
+values (@user_id::uuid, @rule_name::text, @category_id::bigint, @conditions::jsonb, @merchant::text, coalesce(sqlc.narg('rule_source')::text, 'user_created'))
 returning *;
 
 -- name: UpdateRule :exec
@@ -58,10 +58,20 @@ set
     then @category_id::bigint
     else category_id
   end,
+  category_rule_id = case
+    when @category_id::bigint > 0 and category_manually_set = false
+    then sqlc.narg('category_rule_id')::uuid
+    else category_rule_id
+  end,
   merchant = case
     when @merchant::text != '' and merchant_manually_set = false
     then @merchant::text
     else merchant
+  end,
+  merchant_rule_id = case
+    when @merchant::text != '' and merchant_manually_set = false
+    then sqlc.narg('merchant_rule_id')::uuid
+    else merchant_rule_id
   end
 where id = ANY(@transaction_ids::bigint[])
   and account_id in (
@@ -73,4 +83,13 @@ where id = ANY(@transaction_ids::bigint[])
   and (
     (@category_id::bigint > 0 and category_manually_set = false) or
     (@merchant::text != '' and merchant_manually_set = false)
-  );
\ No newline at end of file
+  );
+
+-- name: IncrementRuleApplications :exec
+update transaction_rules r
+set
+  times_applied = coalesce(r.times_applied, 0) + u.applied,
+  last_applied_at = now()
+from unnest(@rule_ids::uuid[], @counts::int[]) as u(rule_id, applied)
+where r.rule_id = u.rule_id
+  and r.user_id = @user_id::uuid;
@@ -201,7 +201,15 @@ set
   exchange_rate = coalesce(sqlc.narg('exchange_rate')::double precision, exchange_rate),
   suggestions = coalesce(sqlc.narg('suggestions')::text[], suggestions),
   category_manually_set = coalesce(sqlc.narg('category_manually_set')::boolean, category_manually_set),
-  merchant_manually_set = coalesce(sqlc.narg('merchant_manually_set')::boolean, merchant_manually_set)
+  merchant_manually_set = coalesce(sqlc.narg('merchant_manually_set')::boolean, merchant_manually_set),
+  category_rule_id = case
+    when sqlc.narg('category_manually_set')::boolean is not null then null
+    else category_rule_id
+  end,
+  merchant_rule_id = case
+    when sqlc.narg('merchant_manually_set')::boolean is not null then null
+    else merchant_rule_id
+  end
 where
   id = sqlc.arg(id)::bigint
   and account_id in (
@@ -239,6 +247,7 @@ update
 set
   category_id = sqlc.narg('category_id')::bigint,
   category_manually_set = sqlc.arg(category_manually_set)::boolean,
+  category_rule_id = null,
   suggestions = sqlc.arg(suggestions)::text []
 where
   id = sqlc.arg(id)::bigint
@@ -263,7 +272,8 @@ update
   transactions
 set
   category_id = sqlc.arg(category_id)::bigint,
-  category_manually_set = true
+  category_manually_set = true,
+  category_rule_id = null
 where
   id = ANY(sqlc.arg(transaction_ids)::bigint [])
   and account_id in (
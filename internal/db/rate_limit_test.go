@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"null-core/internal/db/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestTakeRateLimit(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+
+	// 1 token per second, burst of 3
+	key := "test:" + uuid.NewString()
+	take := func(cost float64) error {
+		_, err := tdb.TakeRateLimit(ctx, sqlc.TakeRateLimitParams{
+			Key:          key,
+			CostSeconds:  cost,
+			BurstSeconds: 3,
+		})
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := take(1); err != nil {
+			t.Fatalf("take %d: expected to pass, got %v", i+1, err)
+		}
+	}
+
+	if err := take(1); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("expected the 4th take to be refused, got %v", err)
+	}
+
+	backlog, err := tdb.GetRateLimitBacklog(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a refused take must not push tat further
+	if backlog <= 2 || backlog > 3 {
+		t.Errorf("Expected about 3s of backlog, got %v", backlog)
+	}
+}
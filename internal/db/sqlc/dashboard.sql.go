@@ -183,6 +183,87 @@ func (q *Queries) GetEarliestTransactionDate(ctx context.Context, userID uuid.UU
 	return earliest_date, err
 }
 
+const getItemCategorySpending = `-- name: GetItemCategorySpending :many
+select
+  c.id as category_id,
+  c.slug,
+  c.color,
+  ri.unit_currency as currency,
+  COUNT(ri.id)::bigint as item_count,
+  COUNT(distinct r.id)::bigint as receipt_count,
+  SUM(round(ri.quantity * ri.unit_price_cents))::bigint as total_amount_cents
+from receipt_items ri
+join receipts r on ri.receipt_id = r.id
+left join categories c on ri.category_id = c.id
+left join transactions t on r.transaction_id = t.id
+left join categories tc on t.category_id = tc.id
+where r.user_id = $1::uuid
+  and ($2::date is null or coalesce(r.receipt_date, r.created_at::date) >= $2::date)
+  and ($3::date is null or coalesce(r.receipt_date, r.created_at::date) <= $3::date)
+  and (
+    $4::bigint is null
+    or tc.id = $4::bigint
+    or tc.slug like (select slug from categories where id = $4::bigint and user_id = $1::uuid) || '.%'
+  )
+group by c.id, c.slug, c.color, ri.unit_currency
+order by total_amount_cents desc
+limit COALESCE($5::int, 50)
+`
+
+type GetItemCategorySpendingParams struct {
+	UserID                uuid.UUID  `db:"user_id" json:"user_id"`
+	Start                 *time.Time `db:"start" json:"start"`
+	End                   *time.Time `db:"end" json:"end"`
+	TransactionCategoryID *int64     `db:"transaction_category_id" json:"transaction_category_id"`
+	Limit                 *int32     `db:"limit" json:"limit"`
+}
+
+type GetItemCategorySpendingRow struct {
+	CategoryID       *int64  `db:"category_id" json:"category_id"`
+	Slug             *string `db:"slug" json:"slug"`
+	Color            *string `db:"color" json:"color"`
+	Currency         string  `db:"currency" json:"currency"`
+	ItemCount        int64   `db:"item_count" json:"item_count"`
+	ReceiptCount     int64   `db:"receipt_count" json:"receipt_count"`
+	TotalAmountCents int64   `db:"total_amount_cents" json:"total_amount_cents"`
+}
+
+// receipt line items grouped by their own category; uncategorized items
+// come back with a null category
+func (q *Queries) GetItemCategorySpending(ctx context.Context, arg GetItemCategorySpendingParams) ([]GetItemCategorySpendingRow, error) {
+	rows, err := q.db.Query(ctx, getItemCategorySpending,
+		arg.UserID,
+		arg.Start,
+		arg.End,
+		arg.TransactionCategoryID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemCategorySpendingRow
+	for rows.Next() {
+		var i GetItemCategorySpendingRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.Slug,
+			&i.Color,
+			&i.Currency,
+			&i.ItemCount,
+			&i.ReceiptCount,
+			&i.TotalAmountCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMonthlyComparison = `-- name: GetMonthlyComparison :many
 select
   to_char(t.tx_date, 'YYYY-MM') as month,
@@ -388,19 +469,23 @@ func (q *Queries) GetTopCategories(ctx context.Context, arg GetTopCategoriesPara
 
 const getTopMerchants = `-- name: GetTopMerchants :many
 select
-  t.merchant,
+  coalesce(m.name, t.merchant) as merchant,
+  m.id as merchant_id,
+  m.color,
+  m.logo_url,
   COUNT(t.id)::bigint as transaction_count,
   SUM(t.tx_amount_cents)::bigint as total_amount_cents,
   AVG(t.tx_amount_cents)::bigint as avg_amount_cents
 from transactions t
 join accounts a on t.account_id = a.id
 left join account_users au on a.id = au.account_id and au.user_id = $1::uuid
+left join merchants m on t.merchant_id = m.id
 where (a.owner_id = $1::uuid or au.user_id is not null)
-  and t.merchant is not null
+  and (t.merchant is not null or t.merchant_id is not null)
   and t.tx_direction = 2
   and ($2::timestamptz is null or t.tx_date >= $2::timestamptz)
   and ($3::timestamptz is null or t.tx_date <= $3::timestamptz)
-group by t.merchant
+group by m.id, coalesce(m.name, t.merchant), m.color, m.logo_url
 order by total_amount_cents desc
 limit COALESCE($4::int, 10)
 `
@@ -414,6 +499,9 @@ type GetTopMerchantsParams struct {
 
 type GetTopMerchantsRow struct {
 	Merchant         *string `db:"merchant" json:"merchant"`
+	MerchantID       *int64  `db:"merchant_id" json:"merchant_id"`
+	Color            *string `db:"color" json:"color"`
+	LogoUrl          *string `db:"logo_url" json:"logo_url"`
 	TransactionCount int64   `db:"transaction_count" json:"transaction_count"`
 	TotalAmountCents int64   `db:"total_amount_cents" json:"total_amount_cents"`
 	AvgAmountCents   int64   `db:"avg_amount_cents" json:"avg_amount_cents"`
@@ -435,6 +523,9 @@ func (q *Queries) GetTopMerchants(ctx context.Context, arg GetTopMerchantsParams
 		var i GetTopMerchantsRow
 		if err := rows.Scan(
 			&i.Merchant,
+			&i.MerchantID,
+			&i.Color,
+			&i.LogoUrl,
 			&i.TransactionCount,
 			&i.TotalAmountCents,
 			&i.AvgAmountCents,
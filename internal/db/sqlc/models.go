@@ -33,6 +33,18 @@ type AccountUser struct {
 	AddedAt   time.Time `db:"added_at" json:"added_at"`
 }
 
+type ApiToken struct {
+	ID         int64      `db:"id" json:"id"`
+	UserID     uuid.UUID  `db:"user_id" json:"user_id"`
+	Name       string     `db:"name" json:"name"`
+	TokenHash  []byte     `db:"token_hash" json:"token_hash"`
+	Prefix     string     `db:"prefix" json:"prefix"`
+	Scopes     []string   `db:"scopes" json:"scopes"`
+	ExpiresAt  *time.Time `db:"expires_at" json:"expires_at"`
+	LastUsedAt *time.Time `db:"last_used_at" json:"last_used_at"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+}
+
 type Category struct {
 	ID        int64     `db:"id" json:"id"`
 	UserID    uuid.UUID `db:"user_id" json:"user_id"`
@@ -42,6 +54,24 @@ type Category struct {
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
 }
 
+type Merchant struct {
+	ID                int64     `db:"id" json:"id"`
+	UserID            uuid.UUID `db:"user_id" json:"user_id"`
+	Name              string    `db:"name" json:"name"`
+	Aliases           []string  `db:"aliases" json:"aliases"`
+	Patterns          []string  `db:"patterns" json:"patterns"`
+	DefaultCategoryID *int64    `db:"default_category_id" json:"default_category_id"`
+	LogoUrl           *string   `db:"logo_url" json:"logo_url"`
+	Color             *string   `db:"color" json:"color"`
+	CreatedAt         time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type RateLimitBucket struct {
+	Key string    `db:"key" json:"key"`
+	Tat time.Time `db:"tat" json:"tat"`
+}
+
 type Receipt struct {
 	ID            int64              `db:"id" json:"id"`
 	UserID        uuid.UUID          `db:"user_id" json:"user_id"`
@@ -57,6 +87,11 @@ type Receipt struct {
 	Status        null.ReceiptStatus `db:"status" json:"status"`
 	CreatedAt     time.Time          `db:"created_at" json:"created_at"`
 	UpdatedAt     time.Time          `db:"updated_at" json:"updated_at"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	NextAttemptAt time.Time          `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError     *string            `db:"last_error" json:"last_error"`
+	LockedUntil   *time.Time         `db:"locked_until" json:"locked_until"`
+	ReviewReasons []string           `db:"review_reasons" json:"review_reasons"`
 }
 
 type ReceiptItem struct {
@@ -70,6 +105,16 @@ type ReceiptItem struct {
 	SortOrder      int32     `db:"sort_order" json:"sort_order"`
 	CreatedAt      time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
+	CategoryID     *int64    `db:"category_id" json:"category_id"`
+}
+
+type ReceiptPage struct {
+	ID          int64     `db:"id" json:"id"`
+	ReceiptID   int64     `db:"receipt_id" json:"receipt_id"`
+	PageNo      int32     `db:"page_no" json:"page_no"`
+	ImagePath   string    `db:"image_path" json:"image_path"`
+	ContentType string    `db:"content_type" json:"content_type"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
 }
 
 type Transaction struct {
@@ -94,6 +139,9 @@ type Transaction struct {
 	ExchangeRate        *float64                  `db:"exchange_rate" json:"exchange_rate"`
 	CreatedAt           time.Time                 `db:"created_at" json:"created_at"`
 	UpdatedAt           time.Time                 `db:"updated_at" json:"updated_at"`
+	CategoryRuleID      *uuid.UUID                `db:"category_rule_id" json:"category_rule_id"`
+	MerchantRuleID      *uuid.UUID                `db:"merchant_rule_id" json:"merchant_rule_id"`
+	MerchantID          *int64                    `db:"merchant_id" json:"merchant_id"`
 }
 
 type TransactionRule struct {
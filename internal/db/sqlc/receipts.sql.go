@@ -13,6 +13,130 @@ import (
 	null "null-core/internal/gen/null/v1"
 )
 
+const claimPendingReceipts = `-- name: ClaimPendingReceipts :many
+UPDATE receipts
+SET
+  attempts     = attempts + 1,
+  locked_until = NOW() + make_interval(secs => $1::int)
+WHERE id IN (
+  SELECT id
+  FROM receipts
+  WHERE status = 1
+    AND attempts < $2::int
+    AND next_attempt_at <= NOW()
+    AND (locked_until IS NULL OR locked_until < NOW())
+  ORDER BY next_attempt_at ASC, id ASC
+  LIMIT $3::int
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, user_id, transaction_id, image_path, merchant, receipt_date, currency, subtotal_cents, tax_cents, total_cents, confidence, status, created_at, updated_at, attempts, next_attempt_at, last_error, locked_until, review_reasons
+`
+
+type ClaimPendingReceiptsParams struct {
+	LeaseSeconds int32 `db:"lease_seconds" json:"lease_seconds"`
+	MaxAttempts  int32 `db:"max_attempts" json:"max_attempts"`
+	Lim          int32 `db:"lim" json:"lim"`
+}
+
+// leases due jobs to this worker; SKIP LOCKED keeps replicas from claiming
+// the same receipt and the lease lets another worker pick it up after a crash
+func (q *Queries) ClaimPendingReceipts(ctx context.Context, arg ClaimPendingReceiptsParams) ([]Receipt, error) {
+	rows, err := q.db.Query(ctx, claimPendingReceipts, arg.LeaseSeconds, arg.MaxAttempts, arg.Lim)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Receipt
+	for rows.Next() {
+		var i Receipt
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TransactionID,
+			&i.ImagePath,
+			&i.Merchant,
+			&i.ReceiptDate,
+			&i.Currency,
+			&i.SubtotalCents,
+			&i.TaxCents,
+			&i.TotalCents,
+			&i.Confidence,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.LockedUntil,
+			&i.ReviewReasons,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const completeReceiptJob = `-- name: CompleteReceiptJob :exec
+UPDATE receipts
+SET
+  locked_until = NULL,
+  last_error   = NULL
+WHERE id = $1::bigint
+`
+
+func (q *Queries) CompleteReceiptJob(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, completeReceiptJob, id)
+	return err
+}
+
+const countBlobReferences = `-- name: CountBlobReferences :one
+SELECT count(*)
+FROM receipt_pages rp
+JOIN receipts r ON rp.receipt_id = r.id
+WHERE r.user_id = $1::uuid
+  AND rp.image_path = $2::text
+`
+
+type CountBlobReferencesParams struct {
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	ImagePath string    `db:"image_path" json:"image_path"`
+}
+
+// identical uploads share a content-addressed blob; only delete it once
+// no page references it
+func (q *Queries) CountBlobReferences(ctx context.Context, arg CountBlobReferencesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countBlobReferences, arg.UserID, arg.ImagePath)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countReceiptQueue = `-- name: CountReceiptQueue :one
+SELECT
+  count(*) FILTER (WHERE status = 1)::bigint                             AS pending,
+  count(*) FILTER (WHERE status = 1 AND next_attempt_at <= NOW())::bigint AS due,
+  count(*) FILTER (WHERE status = 5)::bigint                             AS needs_review
+FROM receipts
+`
+
+type CountReceiptQueueRow struct {
+	Pending     int64 `db:"pending" json:"pending"`
+	Due         int64 `db:"due" json:"due"`
+	NeedsReview int64 `db:"needs_review" json:"needs_review"`
+}
+
+// queue depth across all users, for metrics
+func (q *Queries) CountReceiptQueue(ctx context.Context) (CountReceiptQueueRow, error) {
+	row := q.db.QueryRow(ctx, countReceiptQueue)
+	var i CountReceiptQueueRow
+	err := row.Scan(&i.Pending, &i.Due, &i.NeedsReview)
+	return i, err
+}
+
 const createReceipt = `-- name: CreateReceipt :one
 INSERT INTO receipts (
   user_id,
@@ -24,7 +148,7 @@ VALUES (
   $2::text,
   $3::smallint
 )
-RETURNING id, user_id, transaction_id, image_path, merchant, receipt_date, currency, subtotal_cents, tax_cents, total_cents, confidence, status, created_at, updated_at
+RETURNING id, user_id, transaction_id, image_path, merchant, receipt_date, currency, subtotal_cents, tax_cents, total_cents, confidence, status, created_at, updated_at, attempts, next_attempt_at, last_error, locked_until, review_reasons
 `
 
 type CreateReceiptParams struct {
@@ -51,6 +175,11 @@ func (q *Queries) CreateReceipt(ctx context.Context, arg CreateReceiptParams) (R
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.LockedUntil,
+		&i.ReviewReasons,
 	)
 	return i, err
 }
@@ -63,7 +192,8 @@ INSERT INTO receipt_items (
   quantity,
   unit_price_cents,
   unit_currency,
-  sort_order
+  sort_order,
+  category_id
 )
 VALUES (
   $1::bigint,
@@ -72,9 +202,10 @@ VALUES (
   $4::double precision,
   $5::bigint,
   $6::char(3),
-  $7::int
+  $7::int,
+  $8::bigint
 )
-RETURNING id, receipt_id, raw_name, name, quantity, unit_price_cents, unit_currency, sort_order, created_at, updated_at
+RETURNING id, receipt_id, raw_name, name, quantity, unit_price_cents, unit_currency, sort_order, created_at, updated_at, category_id
 `
 
 type CreateReceiptItemParams struct {
@@ -85,6 +216,7 @@ type CreateReceiptItemParams struct {
 	UnitPriceCents int64   `db:"unit_price_cents" json:"unit_price_cents"`
 	UnitCurrency   string  `db:"unit_currency" json:"unit_currency"`
 	SortOrder      int32   `db:"sort_order" json:"sort_order"`
+	CategoryID     *int64  `db:"category_id" json:"category_id"`
 }
 
 func (q *Queries) CreateReceiptItem(ctx context.Context, arg CreateReceiptItemParams) (ReceiptItem, error) {
@@ -96,6 +228,7 @@ func (q *Queries) CreateReceiptItem(ctx context.Context, arg CreateReceiptItemPa
 		arg.UnitPriceCents,
 		arg.UnitCurrency,
 		arg.SortOrder,
+		arg.CategoryID,
 	)
 	var i ReceiptItem
 	err := row.Scan(
@@ -109,6 +242,49 @@ func (q *Queries) CreateReceiptItem(ctx context.Context, arg CreateReceiptItemPa
 		&i.SortOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CategoryID,
+	)
+	return i, err
+}
+
+const createReceiptPage = `-- name: CreateReceiptPage :one
+INSERT INTO receipt_pages (
+  receipt_id,
+  page_no,
+  image_path,
+  content_type
+)
+VALUES (
+  $1::bigint,
+  $2::int,
+  $3::text,
+  $4::text
+)
+RETURNING id, receipt_id, page_no, image_path, content_type, created_at
+`
+
+type CreateReceiptPageParams struct {
+	ReceiptID   int64  `db:"receipt_id" json:"receipt_id"`
+	PageNo      int32  `db:"page_no" json:"page_no"`
+	ImagePath   string `db:"image_path" json:"image_path"`
+	ContentType string `db:"content_type" json:"content_type"`
+}
+
+func (q *Queries) CreateReceiptPage(ctx context.Context, arg CreateReceiptPageParams) (ReceiptPage, error) {
+	row := q.db.QueryRow(ctx, createReceiptPage,
+		arg.ReceiptID,
+		arg.PageNo,
+		arg.ImagePath,
+		arg.ContentType,
+	)
+	var i ReceiptPage
+	err := row.Scan(
+		&i.ID,
+		&i.ReceiptID,
+		&i.PageNo,
+		&i.ImagePath,
+		&i.ContentType,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -139,51 +315,57 @@ func (q *Queries) DeleteReceiptItemsByReceipt(ctx context.Context, receiptID int
 	return err
 }
 
-const getPendingReceipts = `-- name: GetPendingReceipts :many
-SELECT id, user_id, transaction_id, image_path, merchant, receipt_date, currency, subtotal_cents, tax_cents, total_cents, confidence, status, created_at, updated_at
-FROM receipts
+const failExpiredReceiptLeases = `-- name: FailExpiredReceiptLeases :execrows
+UPDATE receipts
+SET
+  status       = 4,
+  locked_until = NULL,
+  last_error   = CASE WHEN locked_until IS NOT NULL THEN 'lease expired' ELSE last_error END
 WHERE status = 1
-ORDER BY created_at ASC
-LIMIT 20
+  AND attempts >= $1::int
+  AND (locked_until IS NULL OR locked_until < NOW())
 `
 
-func (q *Queries) GetPendingReceipts(ctx context.Context) ([]Receipt, error) {
-	rows, err := q.db.Query(ctx, getPendingReceipts)
+// a lease that ran out means the worker crashed, was killed or hung; that
+// used up the attempt, so a job out of attempts fails instead of being
+// claimed forever
+func (q *Queries) FailExpiredReceiptLeases(ctx context.Context, maxAttempts int32) (int64, error) {
+	result, err := q.db.Exec(ctx, failExpiredReceiptLeases, maxAttempts)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	var items []Receipt
-	for rows.Next() {
-		var i Receipt
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.TransactionID,
-			&i.ImagePath,
-			&i.Merchant,
-			&i.ReceiptDate,
-			&i.Currency,
-			&i.SubtotalCents,
-			&i.TaxCents,
-			&i.TotalCents,
-			&i.Confidence,
-			&i.Status,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+	return result.RowsAffected(), nil
+}
+
+const failReceiptAttempt = `-- name: FailReceiptAttempt :exec
+UPDATE receipts
+SET
+  last_error      = $1::text,
+  locked_until    = NULL,
+  next_attempt_at = NOW() + make_interval(secs => $2::int),
+  status          = CASE WHEN attempts >= $3::int THEN 4 ELSE status END
+WHERE id = $4::bigint
+`
+
+type FailReceiptAttemptParams struct {
+	LastError      string `db:"last_error" json:"last_error"`
+	BackoffSeconds int32  `db:"backoff_seconds" json:"backoff_seconds"`
+	MaxAttempts    int32  `db:"max_attempts" json:"max_attempts"`
+	ID             int64  `db:"id" json:"id"`
+}
+
+func (q *Queries) FailReceiptAttempt(ctx context.Context, arg FailReceiptAttemptParams) error {
+	_, err := q.db.Exec(ctx, failReceiptAttempt,
+		arg.LastError,
+		arg.BackoffSeconds,
+		arg.MaxAttempts,
+		arg.ID,
+	)
+	return err
 }
 
 const getReceipt = `-- name: GetReceipt :one
-SELECT id, user_id, transaction_id, image_path, merchant, receipt_date, currency, subtotal_cents, tax_cents, total_cents, confidence, status, created_at, updated_at
+SELECT id, user_id, transaction_id, image_path, merchant, receipt_date, currency, subtotal_cents, tax_cents, total_cents, confidence, status, created_at, updated_at, attempts, next_attempt_at, last_error, locked_until, review_reasons
 FROM receipts
 WHERE id = $1::bigint
   AND user_id = $2::uuid
@@ -212,12 +394,116 @@ func (q *Queries) GetReceipt(ctx context.Context, arg GetReceiptParams) (Receipt
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.LockedUntil,
+		&i.ReviewReasons,
 	)
 	return i, err
 }
 
+const getReceiptPage = `-- name: GetReceiptPage :one
+SELECT id, receipt_id, page_no, image_path, content_type, created_at
+FROM receipt_pages
+WHERE receipt_id = $1::bigint
+  AND page_no = $2::int
+`
+
+type GetReceiptPageParams struct {
+	ReceiptID int64 `db:"receipt_id" json:"receipt_id"`
+	PageNo    int32 `db:"page_no" json:"page_no"`
+}
+
+func (q *Queries) GetReceiptPage(ctx context.Context, arg GetReceiptPageParams) (ReceiptPage, error) {
+	row := q.db.QueryRow(ctx, getReceiptPage, arg.ReceiptID, arg.PageNo)
+	var i ReceiptPage
+	err := row.Scan(
+		&i.ID,
+		&i.ReceiptID,
+		&i.PageNo,
+		&i.ImagePath,
+		&i.ContentType,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listItemPrices = `-- name: ListItemPrices :many
+SELECT
+  ri.id,
+  ri.receipt_id,
+  ri.raw_name,
+  ri.name,
+  ri.quantity,
+  ri.unit_price_cents,
+  ri.unit_currency,
+  coalesce(r.receipt_date, r.created_at::date)::date AS purchased_on,
+  coalesce(m.name, r.merchant, t.merchant) AS merchant
+FROM receipt_items ri
+JOIN receipts r ON ri.receipt_id = r.id
+LEFT JOIN transactions t ON r.transaction_id = t.id
+LEFT JOIN merchants m ON t.merchant_id = m.id
+WHERE r.user_id = $1::uuid
+  AND r.status IN (2, 3)
+  AND ri.unit_price_cents > 0
+  AND ($2::date IS NULL OR coalesce(r.receipt_date, r.created_at::date) >= $2::date)
+  AND ($3::date IS NULL OR coalesce(r.receipt_date, r.created_at::date) <= $3::date)
+ORDER BY purchased_on ASC, ri.receipt_id ASC, ri.sort_order ASC
+`
+
+type ListItemPricesParams struct {
+	UserID uuid.UUID  `db:"user_id" json:"user_id"`
+	Start  *time.Time `db:"start" json:"start"`
+	End    *time.Time `db:"end" json:"end"`
+}
+
+type ListItemPricesRow struct {
+	ID             int64     `db:"id" json:"id"`
+	ReceiptID      int64     `db:"receipt_id" json:"receipt_id"`
+	RawName        string    `db:"raw_name" json:"raw_name"`
+	Name           *string   `db:"name" json:"name"`
+	Quantity       float64   `db:"quantity" json:"quantity"`
+	UnitPriceCents int64     `db:"unit_price_cents" json:"unit_price_cents"`
+	UnitCurrency   string    `db:"unit_currency" json:"unit_currency"`
+	PurchasedOn    time.Time `db:"purchased_on" json:"purchased_on"`
+	Merchant       *string   `db:"merchant" json:"merchant"`
+}
+
+// priced line items on the user's parsed receipts, oldest first. names are
+// normalized in Go so the rules can improve without a backfill
+func (q *Queries) ListItemPrices(ctx context.Context, arg ListItemPricesParams) ([]ListItemPricesRow, error) {
+	rows, err := q.db.Query(ctx, listItemPrices, arg.UserID, arg.Start, arg.End)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListItemPricesRow
+	for rows.Next() {
+		var i ListItemPricesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReceiptID,
+			&i.RawName,
+			&i.Name,
+			&i.Quantity,
+			&i.UnitPriceCents,
+			&i.UnitCurrency,
+			&i.PurchasedOn,
+			&i.Merchant,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listReceiptItems = `-- name: ListReceiptItems :many
-SELECT id, receipt_id, raw_name, name, quantity, unit_price_cents, unit_currency, sort_order, created_at, updated_at
+SELECT id, receipt_id, raw_name, name, quantity, unit_price_cents, unit_currency, sort_order, created_at, updated_at, category_id
 FROM receipt_items
 WHERE receipt_id = $1::bigint
 ORDER BY sort_order ASC, id ASC
@@ -243,6 +529,134 @@ func (q *Queries) ListReceiptItems(ctx context.Context, receiptID int64) ([]Rece
 			&i.SortOrder,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CategoryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReceiptLinkCandidates = `-- name: ListReceiptLinkCandidates :many
+SELECT
+  t.id,
+  t.tx_date,
+  t.tx_amount_cents,
+  t.tx_currency,
+  t.tx_desc,
+  t.merchant,
+  t.foreign_amount_cents,
+  t.foreign_currency,
+  t.account_id,
+  a.name AS account_name
+FROM transactions t
+JOIN accounts a ON t.account_id = a.id
+LEFT JOIN account_users au ON a.id = au.account_id AND au.user_id = $1::uuid
+WHERE (a.owner_id = $1::uuid OR au.user_id IS NOT NULL)
+  AND t.tx_direction = 2
+  AND t.tx_date >= $2::timestamptz
+  AND t.tx_date < $3::timestamptz
+  AND (
+    t.tx_amount_cents BETWEEN $4::bigint AND $5::bigint
+    OR t.foreign_amount_cents BETWEEN $4::bigint AND $5::bigint
+  )
+  AND NOT EXISTS (
+    SELECT 1
+    FROM receipts r
+    WHERE r.transaction_id = t.id
+      AND r.id <> $6::bigint
+  )
+ORDER BY t.tx_date DESC, t.id DESC
+LIMIT 50
+`
+
+type ListReceiptLinkCandidatesParams struct {
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	StartDate time.Time `db:"start_date" json:"start_date"`
+	EndDate   time.Time `db:"end_date" json:"end_date"`
+	MinCents  int64     `db:"min_cents" json:"min_cents"`
+	MaxCents  int64     `db:"max_cents" json:"max_cents"`
+	ReceiptID int64     `db:"receipt_id" json:"receipt_id"`
+}
+
+type ListReceiptLinkCandidatesRow struct {
+	ID                 int64     `db:"id" json:"id"`
+	TxDate             time.Time `db:"tx_date" json:"tx_date"`
+	TxAmountCents      int64     `db:"tx_amount_cents" json:"tx_amount_cents"`
+	TxCurrency         string    `db:"tx_currency" json:"tx_currency"`
+	TxDesc             *string   `db:"tx_desc" json:"tx_desc"`
+	Merchant           *string   `db:"merchant" json:"merchant"`
+	ForeignAmountCents *int64    `db:"foreign_amount_cents" json:"foreign_amount_cents"`
+	ForeignCurrency    *string   `db:"foreign_currency" json:"foreign_currency"`
+	AccountID          int64     `db:"account_id" json:"account_id"`
+	AccountName        string    `db:"account_name" json:"account_name"`
+}
+
+func (q *Queries) ListReceiptLinkCandidates(ctx context.Context, arg ListReceiptLinkCandidatesParams) ([]ListReceiptLinkCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, listReceiptLinkCandidates,
+		arg.UserID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.MinCents,
+		arg.MaxCents,
+		arg.ReceiptID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListReceiptLinkCandidatesRow
+	for rows.Next() {
+		var i ListReceiptLinkCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TxDate,
+			&i.TxAmountCents,
+			&i.TxCurrency,
+			&i.TxDesc,
+			&i.Merchant,
+			&i.ForeignAmountCents,
+			&i.ForeignCurrency,
+			&i.AccountID,
+			&i.AccountName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReceiptPages = `-- name: ListReceiptPages :many
+SELECT id, receipt_id, page_no, image_path, content_type, created_at
+FROM receipt_pages
+WHERE receipt_id = $1::bigint
+ORDER BY page_no ASC
+`
+
+func (q *Queries) ListReceiptPages(ctx context.Context, receiptID int64) ([]ReceiptPage, error) {
+	rows, err := q.db.Query(ctx, listReceiptPages, receiptID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReceiptPage
+	for rows.Next() {
+		var i ReceiptPage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReceiptID,
+			&i.PageNo,
+			&i.ImagePath,
+			&i.ContentType,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -256,7 +670,7 @@ func (q *Queries) ListReceiptItems(ctx context.Context, receiptID int64) ([]Rece
 
 const listReceipts = `-- name: ListReceipts :many
 SELECT
-  r.id, r.user_id, r.transaction_id, r.image_path, r.merchant, r.receipt_date, r.currency, r.subtotal_cents, r.tax_cents, r.total_cents, r.confidence, r.status, r.created_at, r.updated_at,
+  r.id, r.user_id, r.transaction_id, r.image_path, r.merchant, r.receipt_date, r.currency, r.subtotal_cents, r.tax_cents, r.total_cents, r.confidence, r.status, r.created_at, r.updated_at, r.attempts, r.next_attempt_at, r.last_error, r.locked_until, r.review_reasons,
   count(*) OVER() AS total_count
 FROM receipts r
 WHERE r.user_id = $1::uuid
@@ -306,6 +720,11 @@ type ListReceiptsRow struct {
 	Status        null.ReceiptStatus `db:"status" json:"status"`
 	CreatedAt     time.Time          `db:"created_at" json:"created_at"`
 	UpdatedAt     time.Time          `db:"updated_at" json:"updated_at"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	NextAttemptAt time.Time          `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError     *string            `db:"last_error" json:"last_error"`
+	LockedUntil   *time.Time         `db:"locked_until" json:"locked_until"`
+	ReviewReasons []string           `db:"review_reasons" json:"review_reasons"`
 	TotalCount    int64              `db:"total_count" json:"total_count"`
 }
 
@@ -341,6 +760,11 @@ func (q *Queries) ListReceipts(ctx context.Context, arg ListReceiptsParams) ([]L
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.LockedUntil,
+			&i.ReviewReasons,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -353,6 +777,78 @@ func (q *Queries) ListReceipts(ctx context.Context, arg ListReceiptsParams) ([]L
 	return items, nil
 }
 
+const lockBlob = `-- name: LockBlob :exec
+SELECT pg_advisory_xact_lock(hashtextextended($1::text, 0))
+`
+
+// serializes uploads and deletes sharing a blob until the transaction ends
+func (q *Queries) LockBlob(ctx context.Context, imagePath string) error {
+	_, err := q.db.Exec(ctx, lockBlob, imagePath)
+	return err
+}
+
+const lockReceiptLease = `-- name: LockReceiptLease :one
+SELECT id
+FROM receipts
+WHERE id = $1::bigint
+  AND status = 1
+  AND locked_until > NOW()
+FOR UPDATE
+`
+
+// row-locks a job for its results only while this worker's lease is live
+func (q *Queries) LockReceiptLease(ctx context.Context, id int64) (int64, error) {
+	row := q.db.QueryRow(ctx, lockReceiptLease, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const retryReceipt = `-- name: RetryReceipt :one
+UPDATE receipts
+SET
+  status          = 1,
+  attempts        = 0,
+  next_attempt_at = NOW(),
+  locked_until    = NULL,
+  last_error      = NULL
+WHERE id = $1::bigint
+  AND user_id = $2::uuid
+  AND (status <> 1 OR locked_until IS NULL OR locked_until < NOW())
+RETURNING id, user_id, transaction_id, image_path, merchant, receipt_date, currency, subtotal_cents, tax_cents, total_cents, confidence, status, created_at, updated_at, attempts, next_attempt_at, last_error, locked_until, review_reasons
+`
+
+type RetryReceiptParams struct {
+	ID     int64     `db:"id" json:"id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) RetryReceipt(ctx context.Context, arg RetryReceiptParams) (Receipt, error) {
+	row := q.db.QueryRow(ctx, retryReceipt, arg.ID, arg.UserID)
+	var i Receipt
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TransactionID,
+		&i.ImagePath,
+		&i.Merchant,
+		&i.ReceiptDate,
+		&i.Currency,
+		&i.SubtotalCents,
+		&i.TaxCents,
+		&i.TotalCents,
+		&i.Confidence,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.LockedUntil,
+		&i.ReviewReasons,
+	)
+	return i, err
+}
+
 const updateReceipt = `-- name: UpdateReceipt :one
 UPDATE receipts
 SET
@@ -364,10 +860,11 @@ SET
   tax_cents      = coalesce($6::bigint, tax_cents),
   total_cents    = coalesce($7::bigint, total_cents),
   confidence     = coalesce($8::real, confidence),
-  status         = coalesce($9::smallint, status)
-WHERE id = $10::bigint
-  AND user_id = $11::uuid
-RETURNING id, user_id, transaction_id, image_path, merchant, receipt_date, currency, subtotal_cents, tax_cents, total_cents, confidence, status, created_at, updated_at
+  status         = coalesce($9::smallint, status),
+  review_reasons = coalesce($10::text[], review_reasons)
+WHERE id = $11::bigint
+  AND user_id = $12::uuid
+RETURNING id, user_id, transaction_id, image_path, merchant, receipt_date, currency, subtotal_cents, tax_cents, total_cents, confidence, status, created_at, updated_at, attempts, next_attempt_at, last_error, locked_until, review_reasons
 `
 
 type UpdateReceiptParams struct {
@@ -380,6 +877,7 @@ type UpdateReceiptParams struct {
 	TotalCents    *int64     `db:"total_cents" json:"total_cents"`
 	Confidence    *float32   `db:"confidence" json:"confidence"`
 	Status        *int16     `db:"status" json:"status"`
+	ReviewReasons []string   `db:"review_reasons" json:"review_reasons"`
 	ID            int64      `db:"id" json:"id"`
 	UserID        uuid.UUID  `db:"user_id" json:"user_id"`
 }
@@ -395,6 +893,7 @@ func (q *Queries) UpdateReceipt(ctx context.Context, arg UpdateReceiptParams) (R
 		arg.TotalCents,
 		arg.Confidence,
 		arg.Status,
+		arg.ReviewReasons,
 		arg.ID,
 		arg.UserID,
 	)
@@ -414,6 +913,27 @@ func (q *Queries) UpdateReceipt(ctx context.Context, arg UpdateReceiptParams) (R
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastError,
+		&i.LockedUntil,
+		&i.ReviewReasons,
 	)
 	return i, err
 }
+
+const updateReceiptItemsCurrency = `-- name: UpdateReceiptItemsCurrency :exec
+UPDATE receipt_items
+SET unit_currency = $1::char(3)
+WHERE receipt_id = $2::bigint
+`
+
+type UpdateReceiptItemsCurrencyParams struct {
+	UnitCurrency string `db:"unit_currency" json:"unit_currency"`
+	ReceiptID    int64  `db:"receipt_id" json:"receipt_id"`
+}
+
+func (q *Queries) UpdateReceiptItemsCurrency(ctx context.Context, arg UpdateReceiptItemsCurrencyParams) error {
+	_, err := q.db.Exec(ctx, updateReceiptItemsCurrency, arg.UnitCurrency, arg.ReceiptID)
+	return err
+}
@@ -19,35 +19,49 @@ set
     then $1::bigint
     else category_id
   end,
+  category_rule_id = case
+    when $1::bigint > 0 and category_manually_set = false
+    then $2::uuid
+    else category_rule_id
+  end,
   merchant = case
-    when $2::text != '' and merchant_manually_set = false
-    then $2::text
+    when $3::text != '' and merchant_manually_set = false
+    then $3::text
     else merchant
+  end,
+  merchant_rule_id = case
+    when $3::text != '' and merchant_manually_set = false
+    then $4::uuid
+    else merchant_rule_id
   end
-where id = ANY($3::bigint[])
+where id = ANY($5::bigint[])
   and account_id in (
     select a.id
     from accounts a
-    left join account_users au on a.id = au.account_id and au.user_id = $4::uuid
-    where a.owner_id = $4::uuid or au.user_id is not null
+    left join account_users au on a.id = au.account_id and au.user_id = $6::uuid
+    where a.owner_id = $6::uuid or au.user_id is not null
   )
   and (
     ($1::bigint > 0 and category_manually_set = false) or
-    ($2::text != '' and merchant_manually_set = false)
+    ($3::text != '' and merchant_manually_set = false)
   )
 `
 
 type BulkApplyRuleToTransactionsParams struct {
-	CategoryID     int64     `db:"category_id" json:"category_id"`
-	Merchant       string    `db:"merchant" json:"merchant"`
-	TransactionIds []int64   `db:"transaction_ids" json:"transaction_ids"`
-	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+	CategoryID     int64      `db:"category_id" json:"category_id"`
+	CategoryRuleID *uuid.UUID `db:"category_rule_id" json:"category_rule_id"`
+	Merchant       string     `db:"merchant" json:"merchant"`
+	MerchantRuleID *uuid.UUID `db:"merchant_rule_id" json:"merchant_rule_id"`
+	TransactionIds []int64    `db:"transaction_ids" json:"transaction_ids"`
+	UserID         uuid.UUID  `db:"user_id" json:"user_id"`
 }
 
 func (q *Queries) BulkApplyRuleToTransactions(ctx context.Context, arg BulkApplyRuleToTransactionsParams) (int64, error) {
 	result, err := q.db.Exec(ctx, bulkApplyRuleToTransactions,
 		arg.CategoryID,
+		arg.CategoryRuleID,
 		arg.Merchant,
+		arg.MerchantRuleID,
 		arg.TransactionIds,
 		arg.UserID,
 	)
@@ -58,8 +72,8 @@ func (q *Queries) BulkApplyRuleToTransactions(ctx context.Context, arg BulkApply
 }
 
 const createRule = `-- name: CreateRule :one
-insert into transaction_rules (user_id, rule_name, category_id, conditions, merchant)
-values ($1::uuid, $2::text, $3::bigint, $4::jsonb, $5::text)
+insert into transaction_rules (user_id, rule_name, category_id, conditions, merchant, rule_source)
+values ($1::uuid, $2::text, $3::bigint, $4::jsonb, $5::text, coalesce($6::text, 'user_created'))
 returning rule_id, user_id, rule_name, category_id, merchant, conditions, logic_operator, is_active, priority_order, rule_source, created_at, updated_at, last_applied_at, times_applied
 `
 
@@ -69,6 +83,7 @@ type CreateRuleParams struct {
 	CategoryID int64     `db:"category_id" json:"category_id"`
 	Conditions []byte    `db:"conditions" json:"conditions"`
 	Merchant   string    `db:"merchant" json:"merchant"`
+	RuleSource *string   `db:"rule_source" json:"rule_source"`
 }
 
 func (q *Queries) CreateRule(ctx context.Context, arg CreateRuleParams) (TransactionRule, error) {
@@ -78,6 +93,7 @@ func (q *Queries) CreateRule(ctx context.Context, arg CreateRuleParams) (Transac
 		arg.CategoryID,
 		arg.Conditions,
 		arg.Merchant,
+		arg.RuleSource,
 	)
 	var i TransactionRule
 	err := row.Scan(
@@ -197,7 +213,7 @@ func (q *Queries) GetRule(ctx context.Context, arg GetRuleParams) (TransactionRu
 
 const getTransactionsForRuleApplication = `-- name: GetTransactionsForRuleApplication :many
 select
-  t.id, t.account_id, t.email_id, t.tx_date, t.tx_amount_cents, t.tx_currency, t.tx_direction, t.tx_desc, t.balance_after_cents, t.balance_currency, t.merchant, t.category_id, t.category_manually_set, t.merchant_manually_set, t.suggestions, t.user_notes, t.foreign_amount_cents, t.foreign_currency, t.exchange_rate, t.created_at, t.updated_at
+  t.id, t.account_id, t.email_id, t.tx_date, t.tx_amount_cents, t.tx_currency, t.tx_direction, t.tx_desc, t.balance_after_cents, t.balance_currency, t.merchant, t.category_id, t.category_manually_set, t.merchant_manually_set, t.suggestions, t.user_notes, t.foreign_amount_cents, t.foreign_currency, t.exchange_rate, t.created_at, t.updated_at, t.category_rule_id, t.merchant_rule_id, t.merchant_id
 from transactions t
 join accounts a on t.account_id = a.id
 left join account_users au on a.id = au.account_id and au.user_id = $1::uuid
@@ -243,6 +259,9 @@ func (q *Queries) GetTransactionsForRuleApplication(ctx context.Context, arg Get
 			&i.ExchangeRate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CategoryRuleID,
+			&i.MerchantRuleID,
+			&i.MerchantID,
 		); err != nil {
 			return nil, err
 		}
@@ -254,6 +273,27 @@ func (q *Queries) GetTransactionsForRuleApplication(ctx context.Context, arg Get
 	return items, nil
 }
 
+const incrementRuleApplications = `-- name: IncrementRuleApplications :exec
+update transaction_rules r
+set
+  times_applied = coalesce(r.times_applied, 0) + u.applied,
+  last_applied_at = now()
+from unnest($1::uuid[], $2::int[]) as u(rule_id, applied)
+where r.rule_id = u.rule_id
+  and r.user_id = $3::uuid
+`
+
+type IncrementRuleApplicationsParams struct {
+	RuleIds []uuid.UUID `db:"rule_ids" json:"rule_ids"`
+	Counts  []int32     `db:"counts" json:"counts"`
+	UserID  uuid.UUID   `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) IncrementRuleApplications(ctx context.Context, arg IncrementRuleApplicationsParams) error {
+	_, err := q.db.Exec(ctx, incrementRuleApplications, arg.RuleIds, arg.Counts, arg.UserID)
+	return err
+}
+
 const listRules = `-- name: ListRules :many
 select rule_id, user_id, rule_name, category_id, merchant, conditions, logic_operator, is_active, priority_order, rule_source, created_at, updated_at, last_applied_at, times_applied
 from transaction_rules
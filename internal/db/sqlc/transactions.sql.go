@@ -18,7 +18,8 @@ update
   transactions
 set
   category_id = $1::bigint,
-  category_manually_set = true
+  category_manually_set = true,
+  category_rule_id = null
 where
   id = ANY($2::bigint [])
   and account_id in (
@@ -78,7 +79,7 @@ select
   unnest($11::char(3)[]),
   unnest($12::double precision[])
 returning
-  id, account_id, email_id, tx_date, tx_amount_cents, tx_currency, tx_direction, tx_desc, balance_after_cents, balance_currency, merchant, category_id, category_manually_set, merchant_manually_set, suggestions, user_notes, foreign_amount_cents, foreign_currency, exchange_rate, created_at, updated_at
+  id, account_id, email_id, tx_date, tx_amount_cents, tx_currency, tx_direction, tx_desc, balance_after_cents, balance_currency, merchant, category_id, category_manually_set, merchant_manually_set, suggestions, user_notes, foreign_amount_cents, foreign_currency, exchange_rate, created_at, updated_at, category_rule_id, merchant_rule_id, merchant_id
 `
 
 type BulkCreateTransactionsParams struct {
@@ -140,6 +141,9 @@ func (q *Queries) BulkCreateTransactions(ctx context.Context, arg BulkCreateTran
 			&i.ExchangeRate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CategoryRuleID,
+			&i.MerchantRuleID,
+			&i.MerchantID,
 		); err != nil {
 			return nil, err
 		}
@@ -188,6 +192,7 @@ update
 set
   category_id = $1::bigint,
   category_manually_set = $2::boolean,
+  category_rule_id = null,
   suggestions = $3::text []
 where
   id = $4::bigint
@@ -286,7 +291,7 @@ where
     or au.user_id is not null
   )
 returning
-  id, account_id, email_id, tx_date, tx_amount_cents, tx_currency, tx_direction, tx_desc, balance_after_cents, balance_currency, merchant, category_id, category_manually_set, merchant_manually_set, suggestions, user_notes, foreign_amount_cents, foreign_currency, exchange_rate, created_at, updated_at
+  id, account_id, email_id, tx_date, tx_amount_cents, tx_currency, tx_direction, tx_desc, balance_after_cents, balance_currency, merchant, category_id, category_manually_set, merchant_manually_set, suggestions, user_notes, foreign_amount_cents, foreign_currency, exchange_rate, created_at, updated_at, category_rule_id, merchant_rule_id, merchant_id
 `
 
 type CreateTransactionParams struct {
@@ -356,6 +361,9 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		&i.ExchangeRate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CategoryRuleID,
+		&i.MerchantRuleID,
+		&i.MerchantID,
 	)
 	return i, err
 }
@@ -393,7 +401,7 @@ func (q *Queries) DeleteTransaction(ctx context.Context, arg DeleteTransactionPa
 
 const findCandidateTransactions = `-- name: FindCandidateTransactions :many
 select
-  t.id, t.account_id, t.email_id, t.tx_date, t.tx_amount_cents, t.tx_currency, t.tx_direction, t.tx_desc, t.balance_after_cents, t.balance_currency, t.merchant, t.category_id, t.category_manually_set, t.merchant_manually_set, t.suggestions, t.user_notes, t.foreign_amount_cents, t.foreign_currency, t.exchange_rate, t.created_at, t.updated_at,
+  t.id, t.account_id, t.email_id, t.tx_date, t.tx_amount_cents, t.tx_currency, t.tx_direction, t.tx_desc, t.balance_after_cents, t.balance_currency, t.merchant, t.category_id, t.category_manually_set, t.merchant_manually_set, t.suggestions, t.user_notes, t.foreign_amount_cents, t.foreign_currency, t.exchange_rate, t.created_at, t.updated_at, t.category_rule_id, t.merchant_rule_id, t.merchant_id,
   similarity(t.tx_desc::text, $1::text) as merchant_score
 from
   transactions t
@@ -463,6 +471,9 @@ func (q *Queries) FindCandidateTransactions(ctx context.Context, arg FindCandida
 			&i.Transaction.ExchangeRate,
 			&i.Transaction.CreatedAt,
 			&i.Transaction.UpdatedAt,
+			&i.Transaction.CategoryRuleID,
+			&i.Transaction.MerchantRuleID,
+			&i.Transaction.MerchantID,
 			&i.MerchantScore,
 		); err != nil {
 			return nil, err
@@ -506,7 +517,7 @@ func (q *Queries) GetAccountIDsFromTransactionIDs(ctx context.Context, ids []int
 
 const getTransaction = `-- name: GetTransaction :one
 select
-  t.id, t.account_id, t.email_id, t.tx_date, t.tx_amount_cents, t.tx_currency, t.tx_direction, t.tx_desc, t.balance_after_cents, t.balance_currency, t.merchant, t.category_id, t.category_manually_set, t.merchant_manually_set, t.suggestions, t.user_notes, t.foreign_amount_cents, t.foreign_currency, t.exchange_rate, t.created_at, t.updated_at
+  t.id, t.account_id, t.email_id, t.tx_date, t.tx_amount_cents, t.tx_currency, t.tx_direction, t.tx_desc, t.balance_after_cents, t.balance_currency, t.merchant, t.category_id, t.category_manually_set, t.merchant_manually_set, t.suggestions, t.user_notes, t.foreign_amount_cents, t.foreign_currency, t.exchange_rate, t.created_at, t.updated_at, t.category_rule_id, t.merchant_rule_id, t.merchant_id
 from
   transactions t
   join accounts a on t.account_id = a.id
@@ -550,6 +561,9 @@ func (q *Queries) GetTransaction(ctx context.Context, arg GetTransactionParams)
 		&i.ExchangeRate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CategoryRuleID,
+		&i.MerchantRuleID,
+		&i.MerchantID,
 	)
 	return i, err
 }
@@ -602,7 +616,7 @@ func (q *Queries) GetTransactionCountByAccount(ctx context.Context, userID uuid.
 
 const listAllTransactions = `-- name: ListAllTransactions :many
 select
-  t.id, t.account_id, t.email_id, t.tx_date, t.tx_amount_cents, t.tx_currency, t.tx_direction, t.tx_desc, t.balance_after_cents, t.balance_currency, t.merchant, t.category_id, t.category_manually_set, t.merchant_manually_set, t.suggestions, t.user_notes, t.foreign_amount_cents, t.foreign_currency, t.exchange_rate, t.created_at, t.updated_at
+  t.id, t.account_id, t.email_id, t.tx_date, t.tx_amount_cents, t.tx_currency, t.tx_direction, t.tx_desc, t.balance_after_cents, t.balance_currency, t.merchant, t.category_id, t.category_manually_set, t.merchant_manually_set, t.suggestions, t.user_notes, t.foreign_amount_cents, t.foreign_currency, t.exchange_rate, t.created_at, t.updated_at, t.category_rule_id, t.merchant_rule_id, t.merchant_id
 from
   transactions t
   join accounts a on t.account_id = a.id
@@ -649,6 +663,9 @@ func (q *Queries) ListAllTransactions(ctx context.Context, userID uuid.UUID) ([]
 			&i.ExchangeRate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CategoryRuleID,
+			&i.MerchantRuleID,
+			&i.MerchantID,
 		); err != nil {
 			return nil, err
 		}
@@ -662,7 +679,7 @@ func (q *Queries) ListAllTransactions(ctx context.Context, userID uuid.UUID) ([]
 
 const listTransactions = `-- name: ListTransactions :many
 select
-  t.id, t.account_id, t.email_id, t.tx_date, t.tx_amount_cents, t.tx_currency, t.tx_direction, t.tx_desc, t.balance_after_cents, t.balance_currency, t.merchant, t.category_id, t.category_manually_set, t.merchant_manually_set, t.suggestions, t.user_notes, t.foreign_amount_cents, t.foreign_currency, t.exchange_rate, t.created_at, t.updated_at
+  t.id, t.account_id, t.email_id, t.tx_date, t.tx_amount_cents, t.tx_currency, t.tx_direction, t.tx_desc, t.balance_after_cents, t.balance_currency, t.merchant, t.category_id, t.category_manually_set, t.merchant_manually_set, t.suggestions, t.user_notes, t.foreign_amount_cents, t.foreign_currency, t.exchange_rate, t.created_at, t.updated_at, t.category_rule_id, t.merchant_rule_id, t.merchant_id
 from
   transactions t
   join accounts a on t.account_id = a.id
@@ -813,6 +830,9 @@ func (q *Queries) ListTransactions(ctx context.Context, arg ListTransactionsPara
 			&i.ExchangeRate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CategoryRuleID,
+			&i.MerchantRuleID,
+			&i.MerchantID,
 		); err != nil {
 			return nil, err
 		}
@@ -843,7 +863,15 @@ set
   exchange_rate = coalesce($13::double precision, exchange_rate),
   suggestions = coalesce($14::text[], suggestions),
   category_manually_set = coalesce($15::boolean, category_manually_set),
-  merchant_manually_set = coalesce($16::boolean, merchant_manually_set)
+  merchant_manually_set = coalesce($16::boolean, merchant_manually_set),
+  category_rule_id = case
+    when $15::boolean is not null then null
+    else category_rule_id
+  end,
+  merchant_rule_id = case
+    when $16::boolean is not null then null
+    else merchant_rule_id
+  end
 where
   id = $17::bigint
   and account_id in (
@@ -0,0 +1,19 @@
+package db
+
+import "testing"
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		sql, want string
+	}{
+		{"-- name: CreateTransaction :one\nINSERT INTO transactions ...", "CreateTransaction"},
+		{"-- name: SyncAccountBalances :exec\nUPDATE accounts ...", "SyncAccountBalances"},
+		{"UPDATE accounts SET anchor_date = $1 WHERE id = $2", "query"},
+	}
+
+	for _, tt := range tests {
+		if got := queryName(tt.sql); got != tt.want {
+			t.Errorf("queryName(%q): expected %q, got %q", tt.sql, tt.want, got)
+		}
+	}
+}
@@ -1,18 +1,29 @@
 package exchange
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
+
+	"null-core/internal/metrics"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/sync/singleflight"
 )
 
 type Client struct {
-	baseURL        string
-	httpClient     *http.Client
-	supportedCodes map[string]bool
-	codesLoaded    bool
+	baseURL    string
+	httpClient *http.Client
+	// guards supportedCodes; the client is shared by request handlers and
+	// the receipt workers. The map is swapped whole and never written after,
+	// so it's read without the lock once fetched
+	mu             sync.Mutex
+	supportedCodes map[string]bool // nil until the first successful load
+	loads          singleflight.Group
 }
 
 type RatesResponse struct {
@@ -23,61 +34,111 @@ type RatesResponse struct {
 
 type CurrenciesResponse map[string]string
 
-func NewClient(baseURL string) *Client {
+func NewClient(baseURL string, timeout time.Duration) *Client {
 	return &Client{
 		baseURL: strings.TrimSuffix(baseURL, "/"),
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: timeout,
+			Transport: otelhttp.NewTransport(http.DefaultTransport,
+				otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+					return "exchange " + r.Method
+				}),
+			),
 		},
-		supportedCodes: make(map[string]bool),
-		codesLoaded:    false,
 	}
 }
 
-// loadSupportedCurrencies fetches and caches supported currency codes
-func (c *Client) loadSupportedCurrencies() error {
-	if c.codesLoaded {
-		return nil
+// Ping checks the provider answers; health checks call it
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/currencies", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	countRequest("currencies", resp, err)
+	if err != nil {
+		return fmt.Errorf("failed to reach exchange API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// loadSupportedCurrencies returns the cached supported currency codes,
+// fetching them on first use. The fetch runs outside mu and concurrent
+// callers share it; a failed fetch isn't cached, so the next call retries
+func (c *Client) loadSupportedCurrencies(ctx context.Context) (map[string]bool, error) {
+	c.mu.Lock()
+	codes := c.supportedCodes
+	c.mu.Unlock()
+	if codes != nil {
+		return codes, nil
+	}
+
+	v, err, _ := c.loads.Do("currencies", func() (any, error) {
+		codes, err := c.fetchSupportedCurrencies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.supportedCodes = codes
+		c.mu.Unlock()
+		return codes, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return v.(map[string]bool), nil
+}
 
-	url := fmt.Sprintf("%s/currencies", c.baseURL)
+// fetchSupportedCurrencies asks the provider which currency codes it knows
+func (c *Client) fetchSupportedCurrencies(ctx context.Context) (map[string]bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/currencies", nil)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.httpClient.Do(req)
+	countRequest("currencies", resp, err)
 	if err != nil {
-		return fmt.Errorf("failed to fetch supported currencies: %w", err)
+		return nil, fmt.Errorf("failed to fetch supported currencies: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API returned status %d when fetching currencies", resp.StatusCode)
+		return nil, fmt.Errorf("API returned status %d when fetching currencies", resp.StatusCode)
 	}
 
 	var currencies CurrenciesResponse
 	err = json.NewDecoder(resp.Body).Decode(&currencies)
 	if err != nil {
-		return fmt.Errorf("failed to parse currencies response: %w", err)
+		return nil, fmt.Errorf("failed to parse currencies response: %w", err)
 	}
 
+	codes := make(map[string]bool, len(currencies))
 	for code := range currencies {
-		c.supportedCodes[code] = true
+		codes[code] = true
 	}
-	c.codesLoaded = true
 
-	return nil
+	return codes, nil
 }
 
 // validateCurrency checks if a currency code is valid and supported
-func (c *Client) validateCurrency(currencyCode string) error {
+func (c *Client) validateCurrency(ctx context.Context, currencyCode string) error {
 	if currencyCode == "" {
 		return fmt.Errorf("currency code cannot be empty")
 	}
 
-	err := c.loadSupportedCurrencies()
+	codes, err := c.loadSupportedCurrencies(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to load supported currencies: %w", err)
 	}
 
-	isSupported := c.supportedCodes[currencyCode]
+	isSupported := codes[currencyCode]
 	if !isSupported {
 		return fmt.Errorf("currency code '%s' is not supported", currencyCode)
 	}
@@ -86,8 +147,8 @@ func (c *Client) validateCurrency(currencyCode string) error {
 }
 
 // IsValidCurrency checks if a currency code is supported by the exchange API
-func (c *Client) IsValidCurrency(currencyCode string) (bool, error) {
-	err := c.validateCurrency(currencyCode)
+func (c *Client) IsValidCurrency(ctx context.Context, currencyCode string) (bool, error) {
+	err := c.validateCurrency(ctx, currencyCode)
 	if err != nil {
 		if strings.Contains(err.Error(), "is not supported") {
 			return false, nil
@@ -99,13 +160,13 @@ func (c *Client) IsValidCurrency(currencyCode string) (bool, error) {
 
 // GetExchangeRate fetches exchange rate from one currency to another
 // If date is nil, gets latest rate. Otherwise gets historical rate for the specified date.
-func (c *Client) GetExchangeRate(fromCurrency, toCurrency string, date *time.Time) (float64, error) {
-	err := c.validateCurrency(fromCurrency)
+func (c *Client) GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string, date *time.Time) (float64, error) {
+	err := c.validateCurrency(ctx, fromCurrency)
 	if err != nil {
 		return 0, fmt.Errorf("invalid from currency: %w", err)
 	}
 
-	err = c.validateCurrency(toCurrency)
+	err = c.validateCurrency(ctx, toCurrency)
 	if err != nil {
 		return 0, fmt.Errorf("invalid to currency: %w", err)
 	}
@@ -136,7 +197,13 @@ func (c *Client) GetExchangeRate(fromCurrency, toCurrency string, date *time.Tim
 		url = fmt.Sprintf("%s/latest?base=%s&symbols=%s", c.baseURL, fromCurrency, toCurrency)
 	}
 
-	resp, err := c.httpClient.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	countRequest("rates", resp, err)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch exchange rate: %w", err)
 	}
@@ -159,3 +226,12 @@ func (c *Client) GetExchangeRate(fromCurrency, toCurrency string, date *time.Tim
 
 	return rate, nil
 }
+
+// countRequest records a call to the API; anything but a 200 is a failure
+func countRequest(endpoint string, resp *http.Response, err error) {
+	result := "ok"
+	if err != nil || resp.StatusCode != http.StatusOK {
+		result = "error"
+	}
+	metrics.ExchangeRequests.WithLabelValues(endpoint, result).Inc()
+}
@@ -311,19 +311,108 @@ func (x *TopCategory) GetTotalAmount() *money.Money {
 	return nil
 }
 
+// spending on receipt line items, grouped by the item's own category
+type ItemCategorySpending struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    *int64                 `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	Slug          *string                `protobuf:"bytes,2,opt,name=slug,proto3,oneof" json:"slug,omitempty"`
+	Color         *string                `protobuf:"bytes,3,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	ItemCount     int64                  `protobuf:"varint,4,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	ReceiptCount  int64                  `protobuf:"varint,5,opt,name=receipt_count,json=receiptCount,proto3" json:"receipt_count,omitempty"`
+	TotalAmount   *money.Money           `protobuf:"bytes,6,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemCategorySpending) Reset() {
+	*x = ItemCategorySpending{}
+	mi := &file_null_v1_dashboard_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemCategorySpending) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemCategorySpending) ProtoMessage() {}
+
+func (x *ItemCategorySpending) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_dashboard_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemCategorySpending.ProtoReflect.Descriptor instead.
+func (*ItemCategorySpending) Descriptor() ([]byte, []int) {
+	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ItemCategorySpending) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *ItemCategorySpending) GetSlug() string {
+	if x != nil && x.Slug != nil {
+		return *x.Slug
+	}
+	return ""
+}
+
+func (x *ItemCategorySpending) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+func (x *ItemCategorySpending) GetItemCount() int64 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+func (x *ItemCategorySpending) GetReceiptCount() int64 {
+	if x != nil {
+		return x.ReceiptCount
+	}
+	return 0
+}
+
+func (x *ItemCategorySpending) GetTotalAmount() *money.Money {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return nil
+}
+
 type TopMerchant struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	Merchant         string                 `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
 	TransactionCount int64                  `protobuf:"varint,2,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
 	TotalAmount      *money.Money           `protobuf:"bytes,3,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
 	AvgAmount        *money.Money           `protobuf:"bytes,4,opt,name=avg_amount,json=avgAmount,proto3" json:"avg_amount,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// set when the transactions are linked to a canonical merchant
+	MerchantId    *int64  `protobuf:"varint,5,opt,name=merchant_id,json=merchantId,proto3,oneof" json:"merchant_id,omitempty"`
+	Color         *string `protobuf:"bytes,6,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	LogoUrl       *string `protobuf:"bytes,7,opt,name=logo_url,json=logoUrl,proto3,oneof" json:"logo_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TopMerchant) Reset() {
 	*x = TopMerchant{}
-	mi := &file_null_v1_dashboard_proto_msgTypes[4]
+	mi := &file_null_v1_dashboard_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +424,7 @@ func (x *TopMerchant) String() string {
 func (*TopMerchant) ProtoMessage() {}
 
 func (x *TopMerchant) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_dashboard_proto_msgTypes[4]
+	mi := &file_null_v1_dashboard_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +437,7 @@ func (x *TopMerchant) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopMerchant.ProtoReflect.Descriptor instead.
 func (*TopMerchant) Descriptor() ([]byte, []int) {
-	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{4}
+	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TopMerchant) GetMerchant() string {
@@ -379,6 +468,27 @@ func (x *TopMerchant) GetAvgAmount() *money.Money {
 	return nil
 }
 
+func (x *TopMerchant) GetMerchantId() int64 {
+	if x != nil && x.MerchantId != nil {
+		return *x.MerchantId
+	}
+	return 0
+}
+
+func (x *TopMerchant) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+func (x *TopMerchant) GetLogoUrl() string {
+	if x != nil && x.LogoUrl != nil {
+		return *x.LogoUrl
+	}
+	return ""
+}
+
 type PeriodInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StartDate     *date.Date             `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
@@ -390,7 +500,7 @@ type PeriodInfo struct {
 
 func (x *PeriodInfo) Reset() {
 	*x = PeriodInfo{}
-	mi := &file_null_v1_dashboard_proto_msgTypes[5]
+	mi := &file_null_v1_dashboard_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -402,7 +512,7 @@ func (x *PeriodInfo) String() string {
 func (*PeriodInfo) ProtoMessage() {}
 
 func (x *PeriodInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_dashboard_proto_msgTypes[5]
+	mi := &file_null_v1_dashboard_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +525,7 @@ func (x *PeriodInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeriodInfo.ProtoReflect.Descriptor instead.
 func (*PeriodInfo) Descriptor() ([]byte, []int) {
-	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{5}
+	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PeriodInfo) GetStartDate() *date.Date {
@@ -449,7 +559,7 @@ type PeriodSpending struct {
 
 func (x *PeriodSpending) Reset() {
 	*x = PeriodSpending{}
-	mi := &file_null_v1_dashboard_proto_msgTypes[6]
+	mi := &file_null_v1_dashboard_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -461,7 +571,7 @@ func (x *PeriodSpending) String() string {
 func (*PeriodSpending) ProtoMessage() {}
 
 func (x *PeriodSpending) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_dashboard_proto_msgTypes[6]
+	mi := &file_null_v1_dashboard_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -474,7 +584,7 @@ func (x *PeriodSpending) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeriodSpending.ProtoReflect.Descriptor instead.
 func (*PeriodSpending) Descriptor() ([]byte, []int) {
-	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{6}
+	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PeriodSpending) GetAmount() *money.Money {
@@ -502,7 +612,7 @@ type CategorySpendingComparison struct {
 
 func (x *CategorySpendingComparison) Reset() {
 	*x = CategorySpendingComparison{}
-	mi := &file_null_v1_dashboard_proto_msgTypes[7]
+	mi := &file_null_v1_dashboard_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -514,7 +624,7 @@ func (x *CategorySpendingComparison) String() string {
 func (*CategorySpendingComparison) ProtoMessage() {}
 
 func (x *CategorySpendingComparison) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_dashboard_proto_msgTypes[7]
+	mi := &file_null_v1_dashboard_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -527,7 +637,7 @@ func (x *CategorySpendingComparison) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CategorySpendingComparison.ProtoReflect.Descriptor instead.
 func (*CategorySpendingComparison) Descriptor() ([]byte, []int) {
-	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{7}
+	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CategorySpendingComparison) GetCategoryId() int64 {
@@ -561,7 +671,7 @@ type CategorySpendingTotals struct {
 
 func (x *CategorySpendingTotals) Reset() {
 	*x = CategorySpendingTotals{}
-	mi := &file_null_v1_dashboard_proto_msgTypes[8]
+	mi := &file_null_v1_dashboard_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -573,7 +683,7 @@ func (x *CategorySpendingTotals) String() string {
 func (*CategorySpendingTotals) ProtoMessage() {}
 
 func (x *CategorySpendingTotals) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_dashboard_proto_msgTypes[8]
+	mi := &file_null_v1_dashboard_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -586,7 +696,7 @@ func (x *CategorySpendingTotals) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CategorySpendingTotals.ProtoReflect.Descriptor instead.
 func (*CategorySpendingTotals) Descriptor() ([]byte, []int) {
-	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{8}
+	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CategorySpendingTotals) GetCurrentPeriodTotal() *money.Money {
@@ -613,7 +723,7 @@ type NetWorthPoint struct {
 
 func (x *NetWorthPoint) Reset() {
 	*x = NetWorthPoint{}
-	mi := &file_null_v1_dashboard_proto_msgTypes[9]
+	mi := &file_null_v1_dashboard_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -625,7 +735,7 @@ func (x *NetWorthPoint) String() string {
 func (*NetWorthPoint) ProtoMessage() {}
 
 func (x *NetWorthPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_dashboard_proto_msgTypes[9]
+	mi := &file_null_v1_dashboard_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -638,7 +748,7 @@ func (x *NetWorthPoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetWorthPoint.ProtoReflect.Descriptor instead.
 func (*NetWorthPoint) Descriptor() ([]byte, []int) {
-	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{9}
+	return file_null_v1_dashboard_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *NetWorthPoint) GetDate() *date.Date {
@@ -682,13 +792,32 @@ const file_null_v1_dashboard_proto_rawDesc = "" +
 	"\x05label\x18\x02 \x01(\tR\x05label\x12\x14\n" +
 	"\x05color\x18\x03 \x01(\tR\x05color\x12+\n" +
 	"\x11transaction_count\x18\x04 \x01(\x03R\x10transactionCount\x125\n" +
-	"\ftotal_amount\x18\x05 \x01(\v2\x12.google.type.MoneyR\vtotalAmount\"\xc0\x01\n" +
+	"\ftotal_amount\x18\x05 \x01(\v2\x12.google.type.MoneyR\vtotalAmount\"\x8e\x02\n" +
+	"\x14ItemCategorySpending\x12$\n" +
+	"\vcategory_id\x18\x01 \x01(\x03H\x00R\n" +
+	"categoryId\x88\x01\x01\x12\x17\n" +
+	"\x04slug\x18\x02 \x01(\tH\x01R\x04slug\x88\x01\x01\x12\x19\n" +
+	"\x05color\x18\x03 \x01(\tH\x02R\x05color\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x04 \x01(\x03R\titemCount\x12#\n" +
+	"\rreceipt_count\x18\x05 \x01(\x03R\freceiptCount\x125\n" +
+	"\ftotal_amount\x18\x06 \x01(\v2\x12.google.type.MoneyR\vtotalAmountB\x0e\n" +
+	"\f_category_idB\a\n" +
+	"\x05_slugB\b\n" +
+	"\x06_color\"\xc8\x02\n" +
 	"\vTopMerchant\x12\x1a\n" +
 	"\bmerchant\x18\x01 \x01(\tR\bmerchant\x12+\n" +
 	"\x11transaction_count\x18\x02 \x01(\x03R\x10transactionCount\x125\n" +
 	"\ftotal_amount\x18\x03 \x01(\v2\x12.google.type.MoneyR\vtotalAmount\x121\n" +
 	"\n" +
-	"avg_amount\x18\x04 \x01(\v2\x12.google.type.MoneyR\tavgAmount\"\x82\x01\n" +
+	"avg_amount\x18\x04 \x01(\v2\x12.google.type.MoneyR\tavgAmount\x12$\n" +
+	"\vmerchant_id\x18\x05 \x01(\x03H\x00R\n" +
+	"merchantId\x88\x01\x01\x12\x19\n" +
+	"\x05color\x18\x06 \x01(\tH\x01R\x05color\x88\x01\x01\x12\x1e\n" +
+	"\blogo_url\x18\a \x01(\tH\x02R\alogoUrl\x88\x01\x01B\x0e\n" +
+	"\f_merchant_idB\b\n" +
+	"\x06_colorB\v\n" +
+	"\t_logo_url\"\x82\x01\n" +
 	"\n" +
 	"PeriodInfo\x120\n" +
 	"\n" +
@@ -724,47 +853,49 @@ func file_null_v1_dashboard_proto_rawDescGZIP() []byte {
 	return file_null_v1_dashboard_proto_rawDescData
 }
 
-var file_null_v1_dashboard_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_null_v1_dashboard_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_null_v1_dashboard_proto_goTypes = []any{
 	(*TrendPoint)(nil),                 // 0: null.v1.TrendPoint
 	(*MonthlyComparison)(nil),          // 1: null.v1.MonthlyComparison
 	(*DashboardSummary)(nil),           // 2: null.v1.DashboardSummary
 	(*TopCategory)(nil),                // 3: null.v1.TopCategory
-	(*TopMerchant)(nil),                // 4: null.v1.TopMerchant
-	(*PeriodInfo)(nil),                 // 5: null.v1.PeriodInfo
-	(*PeriodSpending)(nil),             // 6: null.v1.PeriodSpending
-	(*CategorySpendingComparison)(nil), // 7: null.v1.CategorySpendingComparison
-	(*CategorySpendingTotals)(nil),     // 8: null.v1.CategorySpendingTotals
-	(*NetWorthPoint)(nil),              // 9: null.v1.NetWorthPoint
-	(*date.Date)(nil),                  // 10: google.type.Date
-	(*money.Money)(nil),                // 11: google.type.Money
+	(*ItemCategorySpending)(nil),       // 4: null.v1.ItemCategorySpending
+	(*TopMerchant)(nil),                // 5: null.v1.TopMerchant
+	(*PeriodInfo)(nil),                 // 6: null.v1.PeriodInfo
+	(*PeriodSpending)(nil),             // 7: null.v1.PeriodSpending
+	(*CategorySpendingComparison)(nil), // 8: null.v1.CategorySpendingComparison
+	(*CategorySpendingTotals)(nil),     // 9: null.v1.CategorySpendingTotals
+	(*NetWorthPoint)(nil),              // 10: null.v1.NetWorthPoint
+	(*date.Date)(nil),                  // 11: google.type.Date
+	(*money.Money)(nil),                // 12: google.type.Money
 }
 var file_null_v1_dashboard_proto_depIdxs = []int32{
-	10, // 0: null.v1.TrendPoint.date:type_name -> google.type.Date
-	11, // 1: null.v1.TrendPoint.income:type_name -> google.type.Money
-	11, // 2: null.v1.TrendPoint.expenses:type_name -> google.type.Money
-	11, // 3: null.v1.MonthlyComparison.income:type_name -> google.type.Money
-	11, // 4: null.v1.MonthlyComparison.expenses:type_name -> google.type.Money
-	11, // 5: null.v1.MonthlyComparison.net:type_name -> google.type.Money
-	11, // 6: null.v1.DashboardSummary.total_income:type_name -> google.type.Money
-	11, // 7: null.v1.DashboardSummary.total_expenses:type_name -> google.type.Money
-	11, // 8: null.v1.TopCategory.total_amount:type_name -> google.type.Money
-	11, // 9: null.v1.TopMerchant.total_amount:type_name -> google.type.Money
-	11, // 10: null.v1.TopMerchant.avg_amount:type_name -> google.type.Money
-	10, // 11: null.v1.PeriodInfo.start_date:type_name -> google.type.Date
-	10, // 12: null.v1.PeriodInfo.end_date:type_name -> google.type.Date
-	11, // 13: null.v1.PeriodSpending.amount:type_name -> google.type.Money
-	6,  // 14: null.v1.CategorySpendingComparison.current_period:type_name -> null.v1.PeriodSpending
-	6,  // 15: null.v1.CategorySpendingComparison.previous_period:type_name -> null.v1.PeriodSpending
-	11, // 16: null.v1.CategorySpendingTotals.current_period_total:type_name -> google.type.Money
-	11, // 17: null.v1.CategorySpendingTotals.previous_period_total:type_name -> google.type.Money
-	10, // 18: null.v1.NetWorthPoint.date:type_name -> google.type.Date
-	11, // 19: null.v1.NetWorthPoint.net_worth:type_name -> google.type.Money
-	20, // [20:20] is the sub-list for method output_type
-	20, // [20:20] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	11, // 0: null.v1.TrendPoint.date:type_name -> google.type.Date
+	12, // 1: null.v1.TrendPoint.income:type_name -> google.type.Money
+	12, // 2: null.v1.TrendPoint.expenses:type_name -> google.type.Money
+	12, // 3: null.v1.MonthlyComparison.income:type_name -> google.type.Money
+	12, // 4: null.v1.MonthlyComparison.expenses:type_name -> google.type.Money
+	12, // 5: null.v1.MonthlyComparison.net:type_name -> google.type.Money
+	12, // 6: null.v1.DashboardSummary.total_income:type_name -> google.type.Money
+	12, // 7: null.v1.DashboardSummary.total_expenses:type_name -> google.type.Money
+	12, // 8: null.v1.TopCategory.total_amount:type_name -> google.type.Money
+	12, // 9: null.v1.ItemCategorySpending.total_amount:type_name -> google.type.Money
+	12, // 10: null.v1.TopMerchant.total_amount:type_name -> google.type.Money
+	12, // 11: null.v1.TopMerchant.avg_amount:type_name -> google.type.Money
+	11, // 12: null.v1.PeriodInfo.start_date:type_name -> google.type.Date
+	11, // 13: null.v1.PeriodInfo.end_date:type_name -> google.type.Date
+	12, // 14: null.v1.PeriodSpending.amount:type_name -> google.type.Money
+	7,  // 15: null.v1.CategorySpendingComparison.current_period:type_name -> null.v1.PeriodSpending
+	7,  // 16: null.v1.CategorySpendingComparison.previous_period:type_name -> null.v1.PeriodSpending
+	12, // 17: null.v1.CategorySpendingTotals.current_period_total:type_name -> google.type.Money
+	12, // 18: null.v1.CategorySpendingTotals.previous_period_total:type_name -> google.type.Money
+	11, // 19: null.v1.NetWorthPoint.date:type_name -> google.type.Date
+	12, // 20: null.v1.NetWorthPoint.net_worth:type_name -> google.type.Money
+	21, // [21:21] is the sub-list for method output_type
+	21, // [21:21] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_null_v1_dashboard_proto_init() }
@@ -773,14 +904,16 @@ func file_null_v1_dashboard_proto_init() {
 		return
 	}
 	file_null_v1_category_proto_init()
-	file_null_v1_dashboard_proto_msgTypes[7].OneofWrappers = []any{}
+	file_null_v1_dashboard_proto_msgTypes[4].OneofWrappers = []any{}
+	file_null_v1_dashboard_proto_msgTypes[5].OneofWrappers = []any{}
+	file_null_v1_dashboard_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_dashboard_proto_rawDesc), len(file_null_v1_dashboard_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -987,6 +987,128 @@ func (x *GetNetWorthHistoryResponse) GetDataPoints() []*NetWorthPoint {
 	return nil
 }
 
+type GetItemCategorySpendingRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate *date.Date             `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	EndDate   *date.Date             `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	// only receipts linked to transactions in this category or its children,
+	// e.g. snacks inside grocery runs
+	TransactionCategoryId *int64 `protobuf:"varint,4,opt,name=transaction_category_id,json=transactionCategoryId,proto3,oneof" json:"transaction_category_id,omitempty"`
+	Limit                 *int32 `protobuf:"varint,5,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetItemCategorySpendingRequest) Reset() {
+	*x = GetItemCategorySpendingRequest{}
+	mi := &file_null_v1_dashboard_services_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemCategorySpendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemCategorySpendingRequest) ProtoMessage() {}
+
+func (x *GetItemCategorySpendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_dashboard_services_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemCategorySpendingRequest.ProtoReflect.Descriptor instead.
+func (*GetItemCategorySpendingRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_dashboard_services_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetItemCategorySpendingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetItemCategorySpendingRequest) GetStartDate() *date.Date {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetItemCategorySpendingRequest) GetEndDate() *date.Date {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *GetItemCategorySpendingRequest) GetTransactionCategoryId() int64 {
+	if x != nil && x.TransactionCategoryId != nil {
+		return *x.TransactionCategoryId
+	}
+	return 0
+}
+
+func (x *GetItemCategorySpendingRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type GetItemCategorySpendingResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Categories    []*ItemCategorySpending `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemCategorySpendingResponse) Reset() {
+	*x = GetItemCategorySpendingResponse{}
+	mi := &file_null_v1_dashboard_services_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemCategorySpendingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemCategorySpendingResponse) ProtoMessage() {}
+
+func (x *GetItemCategorySpendingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_dashboard_services_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemCategorySpendingResponse.ProtoReflect.Descriptor instead.
+func (*GetItemCategorySpendingResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_dashboard_services_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetItemCategorySpendingResponse) GetCategories() []*ItemCategorySpending {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
 var File_null_v1_dashboard_services_proto protoreflect.FileDescriptor
 
 const file_null_v1_dashboard_services_proto_rawDesc = "" +
@@ -1084,7 +1206,22 @@ const file_null_v1_dashboard_services_proto_rawDesc = "" +
 	"\vgranularity\x18\x04 \x01(\x0e2\x14.null.v1.GranularityR\vgranularity\"U\n" +
 	"\x1aGetNetWorthHistoryResponse\x127\n" +
 	"\vdata_points\x18\x01 \x03(\v2\x16.null.v1.NetWorthPointR\n" +
-	"dataPoints2\xa5\x06\n" +
+	"dataPoints\"\xbd\x02\n" +
+	"\x1eGetItemCategorySpendingRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x125\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x11.google.type.DateH\x00R\tstartDate\x88\x01\x01\x121\n" +
+	"\bend_date\x18\x03 \x01(\v2\x11.google.type.DateH\x01R\aendDate\x88\x01\x01\x12;\n" +
+	"\x17transaction_category_id\x18\x04 \x01(\x03H\x02R\x15transactionCategoryId\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x05 \x01(\x05H\x03R\x05limit\x88\x01\x01B\r\n" +
+	"\v_start_dateB\v\n" +
+	"\t_end_dateB\x1a\n" +
+	"\x18_transaction_category_idB\b\n" +
+	"\x06_limit\"`\n" +
+	"\x1fGetItemCategorySpendingResponse\x12=\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x1d.null.v1.ItemCategorySpendingR\n" +
+	"categories2\x93\a\n" +
 	"\x10DashboardService\x12`\n" +
 	"\x13GetDashboardSummary\x12#.null.v1.GetDashboardSummaryRequest\x1a$.null.v1.GetDashboardSummaryResponse\x12c\n" +
 	"\x14GetMonthlyComparison\x12$.null.v1.GetMonthlyComparisonRequest\x1a%.null.v1.GetMonthlyComparisonResponse\x12W\n" +
@@ -1093,7 +1230,8 @@ const file_null_v1_dashboard_services_proto_rawDesc = "" +
 	"\x11GetSpendingTrends\x12!.null.v1.GetSpendingTrendsRequest\x1a\".null.v1.GetSpendingTrendsResponse\x12`\n" +
 	"\x13GetFinancialSummary\x12#.null.v1.GetFinancialSummaryRequest\x1a$.null.v1.GetFinancialSummaryResponse\x12~\n" +
 	"\x1dGetCategorySpendingComparison\x12-.null.v1.GetCategorySpendingComparisonRequest\x1a..null.v1.GetCategorySpendingComparisonResponse\x12]\n" +
-	"\x12GetNetWorthHistory\x12\".null.v1.GetNetWorthHistoryRequest\x1a#.null.v1.GetNetWorthHistoryResponseB\x8b\x01\n" +
+	"\x12GetNetWorthHistory\x12\".null.v1.GetNetWorthHistoryRequest\x1a#.null.v1.GetNetWorthHistoryResponse\x12l\n" +
+	"\x17GetItemCategorySpending\x12'.null.v1.GetItemCategorySpendingRequest\x1a(.null.v1.GetItemCategorySpendingResponseB\x8b\x01\n" +
 	"\vcom.null.v1B\x16DashboardServicesProtoP\x01Z%null-core/internal/gen/null/v1;nullv1\xa2\x02\x03NXX\xaa\x02\aNull.V1\xca\x02\bNull_\\V1\xe2\x02\x14Null_\\V1\\GPBMetadata\xea\x02\bNull::V1b\x06proto3"
 
 var (
@@ -1108,7 +1246,7 @@ func file_null_v1_dashboard_services_proto_rawDescGZIP() []byte {
 	return file_null_v1_dashboard_services_proto_rawDescData
 }
 
-var file_null_v1_dashboard_services_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_null_v1_dashboard_services_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_null_v1_dashboard_services_proto_goTypes = []any{
 	(*GetDashboardSummaryRequest)(nil),            // 0: null.v1.GetDashboardSummaryRequest
 	(*GetDashboardSummaryResponse)(nil),           // 1: null.v1.GetDashboardSummaryResponse
@@ -1127,73 +1265,81 @@ var file_null_v1_dashboard_services_proto_goTypes = []any{
 	(*GetCategorySpendingComparisonResponse)(nil), // 14: null.v1.GetCategorySpendingComparisonResponse
 	(*GetNetWorthHistoryRequest)(nil),             // 15: null.v1.GetNetWorthHistoryRequest
 	(*GetNetWorthHistoryResponse)(nil),            // 16: null.v1.GetNetWorthHistoryResponse
-	(*date.Date)(nil),                             // 17: google.type.Date
-	(*DashboardSummary)(nil),                      // 18: null.v1.DashboardSummary
-	(*MonthlyComparison)(nil),                     // 19: null.v1.MonthlyComparison
-	(*TopCategory)(nil),                           // 20: null.v1.TopCategory
-	(*TopMerchant)(nil),                           // 21: null.v1.TopMerchant
-	(*TrendPoint)(nil),                            // 22: null.v1.TrendPoint
-	(*money.Money)(nil),                           // 23: google.type.Money
-	(PeriodType)(0),                               // 24: null.v1.PeriodType
-	(*Category)(nil),                              // 25: null.v1.Category
-	(*CategorySpendingComparison)(nil),            // 26: null.v1.CategorySpendingComparison
-	(*PeriodInfo)(nil),                            // 27: null.v1.PeriodInfo
-	(*CategorySpendingTotals)(nil),                // 28: null.v1.CategorySpendingTotals
-	(Granularity)(0),                              // 29: null.v1.Granularity
-	(*NetWorthPoint)(nil),                         // 30: null.v1.NetWorthPoint
+	(*GetItemCategorySpendingRequest)(nil),        // 17: null.v1.GetItemCategorySpendingRequest
+	(*GetItemCategorySpendingResponse)(nil),       // 18: null.v1.GetItemCategorySpendingResponse
+	(*date.Date)(nil),                             // 19: google.type.Date
+	(*DashboardSummary)(nil),                      // 20: null.v1.DashboardSummary
+	(*MonthlyComparison)(nil),                     // 21: null.v1.MonthlyComparison
+	(*TopCategory)(nil),                           // 22: null.v1.TopCategory
+	(*TopMerchant)(nil),                           // 23: null.v1.TopMerchant
+	(*TrendPoint)(nil),                            // 24: null.v1.TrendPoint
+	(*money.Money)(nil),                           // 25: google.type.Money
+	(PeriodType)(0),                               // 26: null.v1.PeriodType
+	(*Category)(nil),                              // 27: null.v1.Category
+	(*CategorySpendingComparison)(nil),            // 28: null.v1.CategorySpendingComparison
+	(*PeriodInfo)(nil),                            // 29: null.v1.PeriodInfo
+	(*CategorySpendingTotals)(nil),                // 30: null.v1.CategorySpendingTotals
+	(Granularity)(0),                              // 31: null.v1.Granularity
+	(*NetWorthPoint)(nil),                         // 32: null.v1.NetWorthPoint
+	(*ItemCategorySpending)(nil),                  // 33: null.v1.ItemCategorySpending
 }
 var file_null_v1_dashboard_services_proto_depIdxs = []int32{
-	17, // 0: null.v1.GetDashboardSummaryRequest.start_date:type_name -> google.type.Date
-	17, // 1: null.v1.GetDashboardSummaryRequest.end_date:type_name -> google.type.Date
-	18, // 2: null.v1.GetDashboardSummaryResponse.summary:type_name -> null.v1.DashboardSummary
-	19, // 3: null.v1.GetMonthlyComparisonResponse.comparisons:type_name -> null.v1.MonthlyComparison
-	17, // 4: null.v1.GetTopCategoriesRequest.start_date:type_name -> google.type.Date
-	17, // 5: null.v1.GetTopCategoriesRequest.end_date:type_name -> google.type.Date
-	20, // 6: null.v1.GetTopCategoriesResponse.categories:type_name -> null.v1.TopCategory
-	17, // 7: null.v1.GetTopMerchantsRequest.start_date:type_name -> google.type.Date
-	17, // 8: null.v1.GetTopMerchantsRequest.end_date:type_name -> google.type.Date
-	21, // 9: null.v1.GetTopMerchantsResponse.merchants:type_name -> null.v1.TopMerchant
-	17, // 10: null.v1.GetSpendingTrendsRequest.start_date:type_name -> google.type.Date
-	17, // 11: null.v1.GetSpendingTrendsRequest.end_date:type_name -> google.type.Date
-	22, // 12: null.v1.GetSpendingTrendsResponse.trends:type_name -> null.v1.TrendPoint
-	23, // 13: null.v1.GetFinancialSummaryResponse.total_balance:type_name -> google.type.Money
-	23, // 14: null.v1.GetFinancialSummaryResponse.total_debt:type_name -> google.type.Money
-	23, // 15: null.v1.GetFinancialSummaryResponse.net_balance:type_name -> google.type.Money
-	24, // 16: null.v1.GetCategorySpendingComparisonRequest.period_type:type_name -> null.v1.PeriodType
-	17, // 17: null.v1.GetCategorySpendingComparisonRequest.custom_start_date:type_name -> google.type.Date
-	17, // 18: null.v1.GetCategorySpendingComparisonRequest.custom_end_date:type_name -> google.type.Date
-	25, // 19: null.v1.CategorySpendingItem.category:type_name -> null.v1.Category
-	26, // 20: null.v1.CategorySpendingItem.spending:type_name -> null.v1.CategorySpendingComparison
-	27, // 21: null.v1.GetCategorySpendingComparisonResponse.current_period:type_name -> null.v1.PeriodInfo
-	27, // 22: null.v1.GetCategorySpendingComparisonResponse.previous_period:type_name -> null.v1.PeriodInfo
+	19, // 0: null.v1.GetDashboardSummaryRequest.start_date:type_name -> google.type.Date
+	19, // 1: null.v1.GetDashboardSummaryRequest.end_date:type_name -> google.type.Date
+	20, // 2: null.v1.GetDashboardSummaryResponse.summary:type_name -> null.v1.DashboardSummary
+	21, // 3: null.v1.GetMonthlyComparisonResponse.comparisons:type_name -> null.v1.MonthlyComparison
+	19, // 4: null.v1.GetTopCategoriesRequest.start_date:type_name -> google.type.Date
+	19, // 5: null.v1.GetTopCategoriesRequest.end_date:type_name -> google.type.Date
+	22, // 6: null.v1.GetTopCategoriesResponse.categories:type_name -> null.v1.TopCategory
+	19, // 7: null.v1.GetTopMerchantsRequest.start_date:type_name -> google.type.Date
+	19, // 8: null.v1.GetTopMerchantsRequest.end_date:type_name -> google.type.Date
+	23, // 9: null.v1.GetTopMerchantsResponse.merchants:type_name -> null.v1.TopMerchant
+	19, // 10: null.v1.GetSpendingTrendsRequest.start_date:type_name -> google.type.Date
+	19, // 11: null.v1.GetSpendingTrendsRequest.end_date:type_name -> google.type.Date
+	24, // 12: null.v1.GetSpendingTrendsResponse.trends:type_name -> null.v1.TrendPoint
+	25, // 13: null.v1.GetFinancialSummaryResponse.total_balance:type_name -> google.type.Money
+	25, // 14: null.v1.GetFinancialSummaryResponse.total_debt:type_name -> google.type.Money
+	25, // 15: null.v1.GetFinancialSummaryResponse.net_balance:type_name -> google.type.Money
+	26, // 16: null.v1.GetCategorySpendingComparisonRequest.period_type:type_name -> null.v1.PeriodType
+	19, // 17: null.v1.GetCategorySpendingComparisonRequest.custom_start_date:type_name -> google.type.Date
+	19, // 18: null.v1.GetCategorySpendingComparisonRequest.custom_end_date:type_name -> google.type.Date
+	27, // 19: null.v1.CategorySpendingItem.category:type_name -> null.v1.Category
+	28, // 20: null.v1.CategorySpendingItem.spending:type_name -> null.v1.CategorySpendingComparison
+	29, // 21: null.v1.GetCategorySpendingComparisonResponse.current_period:type_name -> null.v1.PeriodInfo
+	29, // 22: null.v1.GetCategorySpendingComparisonResponse.previous_period:type_name -> null.v1.PeriodInfo
 	13, // 23: null.v1.GetCategorySpendingComparisonResponse.categories:type_name -> null.v1.CategorySpendingItem
-	26, // 24: null.v1.GetCategorySpendingComparisonResponse.uncategorized:type_name -> null.v1.CategorySpendingComparison
-	28, // 25: null.v1.GetCategorySpendingComparisonResponse.totals:type_name -> null.v1.CategorySpendingTotals
-	17, // 26: null.v1.GetNetWorthHistoryRequest.start_date:type_name -> google.type.Date
-	17, // 27: null.v1.GetNetWorthHistoryRequest.end_date:type_name -> google.type.Date
-	29, // 28: null.v1.GetNetWorthHistoryRequest.granularity:type_name -> null.v1.Granularity
-	30, // 29: null.v1.GetNetWorthHistoryResponse.data_points:type_name -> null.v1.NetWorthPoint
-	0,  // 30: null.v1.DashboardService.GetDashboardSummary:input_type -> null.v1.GetDashboardSummaryRequest
-	2,  // 31: null.v1.DashboardService.GetMonthlyComparison:input_type -> null.v1.GetMonthlyComparisonRequest
-	4,  // 32: null.v1.DashboardService.GetTopCategories:input_type -> null.v1.GetTopCategoriesRequest
-	6,  // 33: null.v1.DashboardService.GetTopMerchants:input_type -> null.v1.GetTopMerchantsRequest
-	8,  // 34: null.v1.DashboardService.GetSpendingTrends:input_type -> null.v1.GetSpendingTrendsRequest
-	10, // 35: null.v1.DashboardService.GetFinancialSummary:input_type -> null.v1.GetFinancialSummaryRequest
-	12, // 36: null.v1.DashboardService.GetCategorySpendingComparison:input_type -> null.v1.GetCategorySpendingComparisonRequest
-	15, // 37: null.v1.DashboardService.GetNetWorthHistory:input_type -> null.v1.GetNetWorthHistoryRequest
-	1,  // 38: null.v1.DashboardService.GetDashboardSummary:output_type -> null.v1.GetDashboardSummaryResponse
-	3,  // 39: null.v1.DashboardService.GetMonthlyComparison:output_type -> null.v1.GetMonthlyComparisonResponse
-	5,  // 40: null.v1.DashboardService.GetTopCategories:output_type -> null.v1.GetTopCategoriesResponse
-	7,  // 41: null.v1.DashboardService.GetTopMerchants:output_type -> null.v1.GetTopMerchantsResponse
-	9,  // 42: null.v1.DashboardService.GetSpendingTrends:output_type -> null.v1.GetSpendingTrendsResponse
-	11, // 43: null.v1.DashboardService.GetFinancialSummary:output_type -> null.v1.GetFinancialSummaryResponse
-	14, // 44: null.v1.DashboardService.GetCategorySpendingComparison:output_type -> null.v1.GetCategorySpendingComparisonResponse
-	16, // 45: null.v1.DashboardService.GetNetWorthHistory:output_type -> null.v1.GetNetWorthHistoryResponse
-	38, // [38:46] is the sub-list for method output_type
-	30, // [30:38] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	28, // 24: null.v1.GetCategorySpendingComparisonResponse.uncategorized:type_name -> null.v1.CategorySpendingComparison
+	30, // 25: null.v1.GetCategorySpendingComparisonResponse.totals:type_name -> null.v1.CategorySpendingTotals
+	19, // 26: null.v1.GetNetWorthHistoryRequest.start_date:type_name -> google.type.Date
+	19, // 27: null.v1.GetNetWorthHistoryRequest.end_date:type_name -> google.type.Date
+	31, // 28: null.v1.GetNetWorthHistoryRequest.granularity:type_name -> null.v1.Granularity
+	32, // 29: null.v1.GetNetWorthHistoryResponse.data_points:type_name -> null.v1.NetWorthPoint
+	19, // 30: null.v1.GetItemCategorySpendingRequest.start_date:type_name -> google.type.Date
+	19, // 31: null.v1.GetItemCategorySpendingRequest.end_date:type_name -> google.type.Date
+	33, // 32: null.v1.GetItemCategorySpendingResponse.categories:type_name -> null.v1.ItemCategorySpending
+	0,  // 33: null.v1.DashboardService.GetDashboardSummary:input_type -> null.v1.GetDashboardSummaryRequest
+	2,  // 34: null.v1.DashboardService.GetMonthlyComparison:input_type -> null.v1.GetMonthlyComparisonRequest
+	4,  // 35: null.v1.DashboardService.GetTopCategories:input_type -> null.v1.GetTopCategoriesRequest
+	6,  // 36: null.v1.DashboardService.GetTopMerchants:input_type -> null.v1.GetTopMerchantsRequest
+	8,  // 37: null.v1.DashboardService.GetSpendingTrends:input_type -> null.v1.GetSpendingTrendsRequest
+	10, // 38: null.v1.DashboardService.GetFinancialSummary:input_type -> null.v1.GetFinancialSummaryRequest
+	12, // 39: null.v1.DashboardService.GetCategorySpendingComparison:input_type -> null.v1.GetCategorySpendingComparisonRequest
+	15, // 40: null.v1.DashboardService.GetNetWorthHistory:input_type -> null.v1.GetNetWorthHistoryRequest
+	17, // 41: null.v1.DashboardService.GetItemCategorySpending:input_type -> null.v1.GetItemCategorySpendingRequest
+	1,  // 42: null.v1.DashboardService.GetDashboardSummary:output_type -> null.v1.GetDashboardSummaryResponse
+	3,  // 43: null.v1.DashboardService.GetMonthlyComparison:output_type -> null.v1.GetMonthlyComparisonResponse
+	5,  // 44: null.v1.DashboardService.GetTopCategories:output_type -> null.v1.GetTopCategoriesResponse
+	7,  // 45: null.v1.DashboardService.GetTopMerchants:output_type -> null.v1.GetTopMerchantsResponse
+	9,  // 46: null.v1.DashboardService.GetSpendingTrends:output_type -> null.v1.GetSpendingTrendsResponse
+	11, // 47: null.v1.DashboardService.GetFinancialSummary:output_type -> null.v1.GetFinancialSummaryResponse
+	14, // 48: null.v1.DashboardService.GetCategorySpendingComparison:output_type -> null.v1.GetCategorySpendingComparisonResponse
+	16, // 49: null.v1.DashboardService.GetNetWorthHistory:output_type -> null.v1.GetNetWorthHistoryResponse
+	18, // 50: null.v1.DashboardService.GetItemCategorySpending:output_type -> null.v1.GetItemCategorySpendingResponse
+	42, // [42:51] is the sub-list for method output_type
+	33, // [33:42] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_null_v1_dashboard_services_proto_init() }
@@ -1213,13 +1359,14 @@ func file_null_v1_dashboard_services_proto_init() {
 	file_null_v1_dashboard_services_proto_msgTypes[12].OneofWrappers = []any{}
 	file_null_v1_dashboard_services_proto_msgTypes[13].OneofWrappers = []any{}
 	file_null_v1_dashboard_services_proto_msgTypes[14].OneofWrappers = []any{}
+	file_null_v1_dashboard_services_proto_msgTypes[17].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_dashboard_services_proto_rawDesc), len(file_null_v1_dashboard_services_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
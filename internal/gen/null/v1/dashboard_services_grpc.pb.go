@@ -27,6 +27,7 @@ const (
 	DashboardService_GetFinancialSummary_FullMethodName           = "/null.v1.DashboardService/GetFinancialSummary"
 	DashboardService_GetCategorySpendingComparison_FullMethodName = "/null.v1.DashboardService/GetCategorySpendingComparison"
 	DashboardService_GetNetWorthHistory_FullMethodName            = "/null.v1.DashboardService/GetNetWorthHistory"
+	DashboardService_GetItemCategorySpending_FullMethodName       = "/null.v1.DashboardService/GetItemCategorySpending"
 )
 
 // DashboardServiceClient is the client API for DashboardService service.
@@ -45,6 +46,8 @@ type DashboardServiceClient interface {
 	// compares category spending between current and previous period
 	GetCategorySpendingComparison(ctx context.Context, in *GetCategorySpendingComparisonRequest, opts ...grpc.CallOption) (*GetCategorySpendingComparisonResponse, error)
 	GetNetWorthHistory(ctx context.Context, in *GetNetWorthHistoryRequest, opts ...grpc.CallOption) (*GetNetWorthHistoryResponse, error)
+	// receipt item spending by item category, optionally within one transaction category
+	GetItemCategorySpending(ctx context.Context, in *GetItemCategorySpendingRequest, opts ...grpc.CallOption) (*GetItemCategorySpendingResponse, error)
 }
 
 type dashboardServiceClient struct {
@@ -135,6 +138,16 @@ func (c *dashboardServiceClient) GetNetWorthHistory(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *dashboardServiceClient) GetItemCategorySpending(ctx context.Context, in *GetItemCategorySpendingRequest, opts ...grpc.CallOption) (*GetItemCategorySpendingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetItemCategorySpendingResponse)
+	err := c.cc.Invoke(ctx, DashboardService_GetItemCategorySpending_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DashboardServiceServer is the server API for DashboardService service.
 // All implementations must embed UnimplementedDashboardServiceServer
 // for forward compatibility.
@@ -151,6 +164,8 @@ type DashboardServiceServer interface {
 	// compares category spending between current and previous period
 	GetCategorySpendingComparison(context.Context, *GetCategorySpendingComparisonRequest) (*GetCategorySpendingComparisonResponse, error)
 	GetNetWorthHistory(context.Context, *GetNetWorthHistoryRequest) (*GetNetWorthHistoryResponse, error)
+	// receipt item spending by item category, optionally within one transaction category
+	GetItemCategorySpending(context.Context, *GetItemCategorySpendingRequest) (*GetItemCategorySpendingResponse, error)
 	mustEmbedUnimplementedDashboardServiceServer()
 }
 
@@ -185,6 +200,9 @@ func (UnimplementedDashboardServiceServer) GetCategorySpendingComparison(context
 func (UnimplementedDashboardServiceServer) GetNetWorthHistory(context.Context, *GetNetWorthHistoryRequest) (*GetNetWorthHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNetWorthHistory not implemented")
 }
+func (UnimplementedDashboardServiceServer) GetItemCategorySpending(context.Context, *GetItemCategorySpendingRequest) (*GetItemCategorySpendingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItemCategorySpending not implemented")
+}
 func (UnimplementedDashboardServiceServer) mustEmbedUnimplementedDashboardServiceServer() {}
 func (UnimplementedDashboardServiceServer) testEmbeddedByValue()                          {}
 
@@ -350,6 +368,24 @@ func _DashboardService_GetNetWorthHistory_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DashboardService_GetItemCategorySpending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemCategorySpendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DashboardServiceServer).GetItemCategorySpending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DashboardService_GetItemCategorySpending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DashboardServiceServer).GetItemCategorySpending(ctx, req.(*GetItemCategorySpendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DashboardService_ServiceDesc is the grpc.ServiceDesc for DashboardService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -389,6 +425,10 @@ var DashboardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNetWorthHistory",
 			Handler:    _DashboardService_GetNetWorthHistory_Handler,
 		},
+		{
+			MethodName: "GetItemCategorySpending",
+			Handler:    _DashboardService_GetItemCategorySpending_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "null/v1/dashboard_services.proto",
@@ -0,0 +1,210 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: null/v1/merchant.proto
+
+package nullv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Merchant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// alternative spellings matched against normalized descriptors, e.g. "blue bottle"
+	Aliases []string `protobuf:"bytes,3,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// case-insensitive regexes matched against the raw descriptor
+	Patterns []string `protobuf:"bytes,4,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	// applied to uncategorized transactions resolved to this merchant
+	DefaultCategoryId *int64                 `protobuf:"varint,5,opt,name=default_category_id,json=defaultCategoryId,proto3,oneof" json:"default_category_id,omitempty"`
+	LogoUrl           *string                `protobuf:"bytes,6,opt,name=logo_url,json=logoUrl,proto3,oneof" json:"logo_url,omitempty"`
+	Color             *string                `protobuf:"bytes,7,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Merchant) Reset() {
+	*x = Merchant{}
+	mi := &file_null_v1_merchant_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Merchant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Merchant) ProtoMessage() {}
+
+func (x *Merchant) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Merchant.ProtoReflect.Descriptor instead.
+func (*Merchant) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Merchant) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Merchant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Merchant) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Merchant) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+func (x *Merchant) GetDefaultCategoryId() int64 {
+	if x != nil && x.DefaultCategoryId != nil {
+		return *x.DefaultCategoryId
+	}
+	return 0
+}
+
+func (x *Merchant) GetLogoUrl() string {
+	if x != nil && x.LogoUrl != nil {
+		return *x.LogoUrl
+	}
+	return ""
+}
+
+func (x *Merchant) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+func (x *Merchant) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Merchant) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_null_v1_merchant_proto protoreflect.FileDescriptor
+
+const file_null_v1_merchant_proto_rawDesc = "" +
+	"\n" +
+	"\x16null/v1/merchant.proto\x12\anull.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\xa9\x03\n" +
+	"\bMerchant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1e\n" +
+	"\x04name\x18\x02 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x04name\x12\x18\n" +
+	"\aaliases\x18\x03 \x03(\tR\aaliases\x12\x1a\n" +
+	"\bpatterns\x18\x04 \x03(\tR\bpatterns\x123\n" +
+	"\x13default_category_id\x18\x05 \x01(\x03H\x00R\x11defaultCategoryId\x88\x01\x01\x12(\n" +
+	"\blogo_url\x18\x06 \x01(\tB\b\xbaH\x05r\x03\x88\x01\x01H\x01R\alogoUrl\x88\x01\x01\x123\n" +
+	"\x05color\x18\a \x01(\tB\x18\xbaH\x15r\x132\x11^#[0-9A-Fa-f]{6}$H\x02R\x05color\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x16\n" +
+	"\x14_default_category_idB\v\n" +
+	"\t_logo_urlB\b\n" +
+	"\x06_colorB\x82\x01\n" +
+	"\vcom.null.v1B\rMerchantProtoP\x01Z%null-core/internal/gen/null/v1;nullv1\xa2\x02\x03NXX\xaa\x02\aNull.V1\xca\x02\bNull_\\V1\xe2\x02\x14Null_\\V1\\GPBMetadata\xea\x02\bNull::V1b\x06proto3"
+
+var (
+	file_null_v1_merchant_proto_rawDescOnce sync.Once
+	file_null_v1_merchant_proto_rawDescData []byte
+)
+
+func file_null_v1_merchant_proto_rawDescGZIP() []byte {
+	file_null_v1_merchant_proto_rawDescOnce.Do(func() {
+		file_null_v1_merchant_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_null_v1_merchant_proto_rawDesc), len(file_null_v1_merchant_proto_rawDesc)))
+	})
+	return file_null_v1_merchant_proto_rawDescData
+}
+
+var file_null_v1_merchant_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_null_v1_merchant_proto_goTypes = []any{
+	(*Merchant)(nil),              // 0: null.v1.Merchant
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_null_v1_merchant_proto_depIdxs = []int32{
+	1, // 0: null.v1.Merchant.created_at:type_name -> google.protobuf.Timestamp
+	1, // 1: null.v1.Merchant.updated_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_null_v1_merchant_proto_init() }
+func file_null_v1_merchant_proto_init() {
+	if File_null_v1_merchant_proto != nil {
+		return
+	}
+	file_null_v1_merchant_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_merchant_proto_rawDesc), len(file_null_v1_merchant_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_null_v1_merchant_proto_goTypes,
+		DependencyIndexes: file_null_v1_merchant_proto_depIdxs,
+		MessageInfos:      file_null_v1_merchant_proto_msgTypes,
+	}.Build()
+	File_null_v1_merchant_proto = out.File
+	file_null_v1_merchant_proto_goTypes = nil
+	file_null_v1_merchant_proto_depIdxs = nil
+}
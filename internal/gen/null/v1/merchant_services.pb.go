@@ -0,0 +1,842 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: null/v1/merchant_services.proto
+
+package nullv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListMerchantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMerchantsRequest) Reset() {
+	*x = ListMerchantsRequest{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMerchantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMerchantsRequest) ProtoMessage() {}
+
+func (x *ListMerchantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMerchantsRequest.ProtoReflect.Descriptor instead.
+func (*ListMerchantsRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListMerchantsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListMerchantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchants     []*Merchant            `protobuf:"bytes,1,rep,name=merchants,proto3" json:"merchants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMerchantsResponse) Reset() {
+	*x = ListMerchantsResponse{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMerchantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMerchantsResponse) ProtoMessage() {}
+
+func (x *ListMerchantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMerchantsResponse.ProtoReflect.Descriptor instead.
+func (*ListMerchantsResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListMerchantsResponse) GetMerchants() []*Merchant {
+	if x != nil {
+		return x.Merchants
+	}
+	return nil
+}
+
+type GetMerchantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMerchantRequest) Reset() {
+	*x = GetMerchantRequest{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMerchantRequest) ProtoMessage() {}
+
+func (x *GetMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMerchantRequest.ProtoReflect.Descriptor instead.
+func (*GetMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetMerchantRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetMerchantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchant      *Merchant              `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMerchantResponse) Reset() {
+	*x = GetMerchantResponse{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMerchantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMerchantResponse) ProtoMessage() {}
+
+func (x *GetMerchantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMerchantResponse.ProtoReflect.Descriptor instead.
+func (*GetMerchantResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetMerchantResponse) GetMerchant() *Merchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+type CreateMerchantRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Aliases           []string               `protobuf:"bytes,2,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Patterns          []string               `protobuf:"bytes,3,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	DefaultCategoryId *int64                 `protobuf:"varint,4,opt,name=default_category_id,json=defaultCategoryId,proto3,oneof" json:"default_category_id,omitempty"`
+	LogoUrl           *string                `protobuf:"bytes,5,opt,name=logo_url,json=logoUrl,proto3,oneof" json:"logo_url,omitempty"`
+	Color             *string                `protobuf:"bytes,6,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	// link existing transactions whose descriptor resolves to this merchant
+	ApplyToExisting *bool `protobuf:"varint,7,opt,name=apply_to_existing,json=applyToExisting,proto3,oneof" json:"apply_to_existing,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateMerchantRequest) Reset() {
+	*x = CreateMerchantRequest{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMerchantRequest) ProtoMessage() {}
+
+func (x *CreateMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMerchantRequest.ProtoReflect.Descriptor instead.
+func (*CreateMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateMerchantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateMerchantRequest) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *CreateMerchantRequest) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+func (x *CreateMerchantRequest) GetDefaultCategoryId() int64 {
+	if x != nil && x.DefaultCategoryId != nil {
+		return *x.DefaultCategoryId
+	}
+	return 0
+}
+
+func (x *CreateMerchantRequest) GetLogoUrl() string {
+	if x != nil && x.LogoUrl != nil {
+		return *x.LogoUrl
+	}
+	return ""
+}
+
+func (x *CreateMerchantRequest) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+func (x *CreateMerchantRequest) GetApplyToExisting() bool {
+	if x != nil && x.ApplyToExisting != nil {
+		return *x.ApplyToExisting
+	}
+	return false
+}
+
+type CreateMerchantResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Merchant           *Merchant              `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	TransactionsLinked int64                  `protobuf:"varint,2,opt,name=transactions_linked,json=transactionsLinked,proto3" json:"transactions_linked,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CreateMerchantResponse) Reset() {
+	*x = CreateMerchantResponse{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMerchantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMerchantResponse) ProtoMessage() {}
+
+func (x *CreateMerchantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMerchantResponse.ProtoReflect.Descriptor instead.
+func (*CreateMerchantResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateMerchantResponse) GetMerchant() *Merchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+func (x *CreateMerchantResponse) GetTransactionsLinked() int64 {
+	if x != nil {
+		return x.TransactionsLinked
+	}
+	return 0
+}
+
+type UpdateMerchantRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// aliases and patterns are only replaced when listed here, so they can be cleared
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Name       *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Aliases    []string               `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Patterns   []string               `protobuf:"bytes,5,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	// 0 clears the default category
+	DefaultCategoryId *int64 `protobuf:"varint,6,opt,name=default_category_id,json=defaultCategoryId,proto3,oneof" json:"default_category_id,omitempty"`
+	// empty string clears
+	LogoUrl         *string `protobuf:"bytes,7,opt,name=logo_url,json=logoUrl,proto3,oneof" json:"logo_url,omitempty"`
+	Color           *string `protobuf:"bytes,8,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	ApplyToExisting *bool   `protobuf:"varint,9,opt,name=apply_to_existing,json=applyToExisting,proto3,oneof" json:"apply_to_existing,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateMerchantRequest) Reset() {
+	*x = UpdateMerchantRequest{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMerchantRequest) ProtoMessage() {}
+
+func (x *UpdateMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMerchantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateMerchantRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateMerchantRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateMerchantRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateMerchantRequest) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *UpdateMerchantRequest) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+func (x *UpdateMerchantRequest) GetDefaultCategoryId() int64 {
+	if x != nil && x.DefaultCategoryId != nil {
+		return *x.DefaultCategoryId
+	}
+	return 0
+}
+
+func (x *UpdateMerchantRequest) GetLogoUrl() string {
+	if x != nil && x.LogoUrl != nil {
+		return *x.LogoUrl
+	}
+	return ""
+}
+
+func (x *UpdateMerchantRequest) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+func (x *UpdateMerchantRequest) GetApplyToExisting() bool {
+	if x != nil && x.ApplyToExisting != nil {
+		return *x.ApplyToExisting
+	}
+	return false
+}
+
+type UpdateMerchantResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TransactionsLinked int64                  `protobuf:"varint,1,opt,name=transactions_linked,json=transactionsLinked,proto3" json:"transactions_linked,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UpdateMerchantResponse) Reset() {
+	*x = UpdateMerchantResponse{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMerchantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMerchantResponse) ProtoMessage() {}
+
+func (x *UpdateMerchantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMerchantResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMerchantResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateMerchantResponse) GetTransactionsLinked() int64 {
+	if x != nil {
+		return x.TransactionsLinked
+	}
+	return 0
+}
+
+type DeleteMerchantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMerchantRequest) Reset() {
+	*x = DeleteMerchantRequest{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMerchantRequest) ProtoMessage() {}
+
+func (x *DeleteMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMerchantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteMerchantRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteMerchantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AffectedRows  int64                  `protobuf:"varint,1,opt,name=affected_rows,json=affectedRows,proto3" json:"affected_rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMerchantResponse) Reset() {
+	*x = DeleteMerchantResponse{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMerchantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMerchantResponse) ProtoMessage() {}
+
+func (x *DeleteMerchantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMerchantResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMerchantResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteMerchantResponse) GetAffectedRows() int64 {
+	if x != nil {
+		return x.AffectedRows
+	}
+	return 0
+}
+
+type MergeMerchantsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// merchant that survives the merge
+	TargetId int64 `protobuf:"varint,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	// merchants folded into the target and deleted
+	SourceIds     []int64 `protobuf:"varint,2,rep,packed,name=source_ids,json=sourceIds,proto3" json:"source_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeMerchantsRequest) Reset() {
+	*x = MergeMerchantsRequest{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeMerchantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeMerchantsRequest) ProtoMessage() {}
+
+func (x *MergeMerchantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeMerchantsRequest.ProtoReflect.Descriptor instead.
+func (*MergeMerchantsRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MergeMerchantsRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *MergeMerchantsRequest) GetSourceIds() []int64 {
+	if x != nil {
+		return x.SourceIds
+	}
+	return nil
+}
+
+type MergeMerchantsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Merchant          *Merchant              `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	TransactionsMoved int64                  `protobuf:"varint,2,opt,name=transactions_moved,json=transactionsMoved,proto3" json:"transactions_moved,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MergeMerchantsResponse) Reset() {
+	*x = MergeMerchantsResponse{}
+	mi := &file_null_v1_merchant_services_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeMerchantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeMerchantsResponse) ProtoMessage() {}
+
+func (x *MergeMerchantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_merchant_services_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeMerchantsResponse.ProtoReflect.Descriptor instead.
+func (*MergeMerchantsResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_merchant_services_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MergeMerchantsResponse) GetMerchant() *Merchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+func (x *MergeMerchantsResponse) GetTransactionsMoved() int64 {
+	if x != nil {
+		return x.TransactionsMoved
+	}
+	return 0
+}
+
+var File_null_v1_merchant_services_proto protoreflect.FileDescriptor
+
+const file_null_v1_merchant_services_proto_rawDesc = "" +
+	"\n" +
+	"\x1fnull/v1/merchant_services.proto\x12\anull.v1\x1a\x16null/v1/merchant.proto\x1a\x1bbuf/validate/validate.proto\x1a google/protobuf/field_mask.proto\"9\n" +
+	"\x14ListMerchantsRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\"H\n" +
+	"\x15ListMerchantsResponse\x12/\n" +
+	"\tmerchants\x18\x01 \x03(\v2\x11.null.v1.MerchantR\tmerchants\"-\n" +
+	"\x12GetMerchantRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\"D\n" +
+	"\x13GetMerchantResponse\x12-\n" +
+	"\bmerchant\x18\x01 \x01(\v2\x11.null.v1.MerchantR\bmerchant\"\xed\x02\n" +
+	"\x15CreateMerchantRequest\x12\x1e\n" +
+	"\x04name\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x04name\x12\x18\n" +
+	"\aaliases\x18\x02 \x03(\tR\aaliases\x12\x1a\n" +
+	"\bpatterns\x18\x03 \x03(\tR\bpatterns\x123\n" +
+	"\x13default_category_id\x18\x04 \x01(\x03H\x00R\x11defaultCategoryId\x88\x01\x01\x12\x1e\n" +
+	"\blogo_url\x18\x05 \x01(\tH\x01R\alogoUrl\x88\x01\x01\x123\n" +
+	"\x05color\x18\x06 \x01(\tB\x18\xbaH\x15r\x132\x11^#[0-9A-Fa-f]{6}$H\x02R\x05color\x88\x01\x01\x12/\n" +
+	"\x11apply_to_existing\x18\a \x01(\bH\x03R\x0fapplyToExisting\x88\x01\x01B\x16\n" +
+	"\x14_default_category_idB\v\n" +
+	"\t_logo_urlB\b\n" +
+	"\x06_colorB\x14\n" +
+	"\x12_apply_to_existing\"x\n" +
+	"\x16CreateMerchantResponse\x12-\n" +
+	"\bmerchant\x18\x01 \x01(\v2\x11.null.v1.MerchantR\bmerchant\x12/\n" +
+	"\x13transactions_linked\x18\x02 \x01(\x03R\x12transactionsLinked\"\xab\x03\n" +
+	"\x15UpdateMerchantRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x18\n" +
+	"\aaliases\x18\x04 \x03(\tR\aaliases\x12\x1a\n" +
+	"\bpatterns\x18\x05 \x03(\tR\bpatterns\x123\n" +
+	"\x13default_category_id\x18\x06 \x01(\x03H\x01R\x11defaultCategoryId\x88\x01\x01\x12\x1e\n" +
+	"\blogo_url\x18\a \x01(\tH\x02R\alogoUrl\x88\x01\x01\x12\x19\n" +
+	"\x05color\x18\b \x01(\tH\x03R\x05color\x88\x01\x01\x12/\n" +
+	"\x11apply_to_existing\x18\t \x01(\bH\x04R\x0fapplyToExisting\x88\x01\x01B\a\n" +
+	"\x05_nameB\x16\n" +
+	"\x14_default_category_idB\v\n" +
+	"\t_logo_urlB\b\n" +
+	"\x06_colorB\x14\n" +
+	"\x12_apply_to_existing\"I\n" +
+	"\x16UpdateMerchantResponse\x12/\n" +
+	"\x13transactions_linked\x18\x01 \x01(\x03R\x12transactionsLinked\"0\n" +
+	"\x15DeleteMerchantRequest\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\"=\n" +
+	"\x16DeleteMerchantResponse\x12#\n" +
+	"\raffected_rows\x18\x01 \x01(\x03R\faffectedRows\"l\n" +
+	"\x15MergeMerchantsRequest\x12$\n" +
+	"\ttarget_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\btargetId\x12-\n" +
+	"\n" +
+	"source_ids\x18\x02 \x03(\x03B\x0e\xbaH\v\x92\x01\b\b\x01\"\x04\"\x02 \x00R\tsourceIds\"v\n" +
+	"\x16MergeMerchantsResponse\x12-\n" +
+	"\bmerchant\x18\x01 \x01(\v2\x11.null.v1.MerchantR\bmerchant\x12-\n" +
+	"\x12transactions_moved\x18\x02 \x01(\x03R\x11transactionsMoved2\xf7\x03\n" +
+	"\x0fMerchantService\x12N\n" +
+	"\rListMerchants\x12\x1d.null.v1.ListMerchantsRequest\x1a\x1e.null.v1.ListMerchantsResponse\x12H\n" +
+	"\vGetMerchant\x12\x1b.null.v1.GetMerchantRequest\x1a\x1c.null.v1.GetMerchantResponse\x12Q\n" +
+	"\x0eCreateMerchant\x12\x1e.null.v1.CreateMerchantRequest\x1a\x1f.null.v1.CreateMerchantResponse\x12Q\n" +
+	"\x0eUpdateMerchant\x12\x1e.null.v1.UpdateMerchantRequest\x1a\x1f.null.v1.UpdateMerchantResponse\x12Q\n" +
+	"\x0eDeleteMerchant\x12\x1e.null.v1.DeleteMerchantRequest\x1a\x1f.null.v1.DeleteMerchantResponse\x12Q\n" +
+	"\x0eMergeMerchants\x12\x1e.null.v1.MergeMerchantsRequest\x1a\x1f.null.v1.MergeMerchantsResponseB\x8a\x01\n" +
+	"\vcom.null.v1B\x15MerchantServicesProtoP\x01Z%null-core/internal/gen/null/v1;nullv1\xa2\x02\x03NXX\xaa\x02\aNull.V1\xca\x02\bNull_\\V1\xe2\x02\x14Null_\\V1\\GPBMetadata\xea\x02\bNull::V1b\x06proto3"
+
+var (
+	file_null_v1_merchant_services_proto_rawDescOnce sync.Once
+	file_null_v1_merchant_services_proto_rawDescData []byte
+)
+
+func file_null_v1_merchant_services_proto_rawDescGZIP() []byte {
+	file_null_v1_merchant_services_proto_rawDescOnce.Do(func() {
+		file_null_v1_merchant_services_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_null_v1_merchant_services_proto_rawDesc), len(file_null_v1_merchant_services_proto_rawDesc)))
+	})
+	return file_null_v1_merchant_services_proto_rawDescData
+}
+
+var file_null_v1_merchant_services_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_null_v1_merchant_services_proto_goTypes = []any{
+	(*ListMerchantsRequest)(nil),   // 0: null.v1.ListMerchantsRequest
+	(*ListMerchantsResponse)(nil),  // 1: null.v1.ListMerchantsResponse
+	(*GetMerchantRequest)(nil),     // 2: null.v1.GetMerchantRequest
+	(*GetMerchantResponse)(nil),    // 3: null.v1.GetMerchantResponse
+	(*CreateMerchantRequest)(nil),  // 4: null.v1.CreateMerchantRequest
+	(*CreateMerchantResponse)(nil), // 5: null.v1.CreateMerchantResponse
+	(*UpdateMerchantRequest)(nil),  // 6: null.v1.UpdateMerchantRequest
+	(*UpdateMerchantResponse)(nil), // 7: null.v1.UpdateMerchantResponse
+	(*DeleteMerchantRequest)(nil),  // 8: null.v1.DeleteMerchantRequest
+	(*DeleteMerchantResponse)(nil), // 9: null.v1.DeleteMerchantResponse
+	(*MergeMerchantsRequest)(nil),  // 10: null.v1.MergeMerchantsRequest
+	(*MergeMerchantsResponse)(nil), // 11: null.v1.MergeMerchantsResponse
+	(*Merchant)(nil),               // 12: null.v1.Merchant
+	(*fieldmaskpb.FieldMask)(nil),  // 13: google.protobuf.FieldMask
+}
+var file_null_v1_merchant_services_proto_depIdxs = []int32{
+	12, // 0: null.v1.ListMerchantsResponse.merchants:type_name -> null.v1.Merchant
+	12, // 1: null.v1.GetMerchantResponse.merchant:type_name -> null.v1.Merchant
+	12, // 2: null.v1.CreateMerchantResponse.merchant:type_name -> null.v1.Merchant
+	13, // 3: null.v1.UpdateMerchantRequest.update_mask:type_name -> google.protobuf.FieldMask
+	12, // 4: null.v1.MergeMerchantsResponse.merchant:type_name -> null.v1.Merchant
+	0,  // 5: null.v1.MerchantService.ListMerchants:input_type -> null.v1.ListMerchantsRequest
+	2,  // 6: null.v1.MerchantService.GetMerchant:input_type -> null.v1.GetMerchantRequest
+	4,  // 7: null.v1.MerchantService.CreateMerchant:input_type -> null.v1.CreateMerchantRequest
+	6,  // 8: null.v1.MerchantService.UpdateMerchant:input_type -> null.v1.UpdateMerchantRequest
+	8,  // 9: null.v1.MerchantService.DeleteMerchant:input_type -> null.v1.DeleteMerchantRequest
+	10, // 10: null.v1.MerchantService.MergeMerchants:input_type -> null.v1.MergeMerchantsRequest
+	1,  // 11: null.v1.MerchantService.ListMerchants:output_type -> null.v1.ListMerchantsResponse
+	3,  // 12: null.v1.MerchantService.GetMerchant:output_type -> null.v1.GetMerchantResponse
+	5,  // 13: null.v1.MerchantService.CreateMerchant:output_type -> null.v1.CreateMerchantResponse
+	7,  // 14: null.v1.MerchantService.UpdateMerchant:output_type -> null.v1.UpdateMerchantResponse
+	9,  // 15: null.v1.MerchantService.DeleteMerchant:output_type -> null.v1.DeleteMerchantResponse
+	11, // 16: null.v1.MerchantService.MergeMerchants:output_type -> null.v1.MergeMerchantsResponse
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_null_v1_merchant_services_proto_init() }
+func file_null_v1_merchant_services_proto_init() {
+	if File_null_v1_merchant_services_proto != nil {
+		return
+	}
+	file_null_v1_merchant_proto_init()
+	file_null_v1_merchant_services_proto_msgTypes[4].OneofWrappers = []any{}
+	file_null_v1_merchant_services_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_merchant_services_proto_rawDesc), len(file_null_v1_merchant_services_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_null_v1_merchant_services_proto_goTypes,
+		DependencyIndexes: file_null_v1_merchant_services_proto_depIdxs,
+		MessageInfos:      file_null_v1_merchant_services_proto_msgTypes,
+	}.Build()
+	File_null_v1_merchant_services_proto = out.File
+	file_null_v1_merchant_services_proto_goTypes = nil
+	file_null_v1_merchant_services_proto_depIdxs = nil
+}
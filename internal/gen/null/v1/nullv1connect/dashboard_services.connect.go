@@ -57,6 +57,9 @@ const (
 	// DashboardServiceGetNetWorthHistoryProcedure is the fully-qualified name of the DashboardService's
 	// GetNetWorthHistory RPC.
 	DashboardServiceGetNetWorthHistoryProcedure = "/null.v1.DashboardService/GetNetWorthHistory"
+	// DashboardServiceGetItemCategorySpendingProcedure is the fully-qualified name of the
+	// DashboardService's GetItemCategorySpending RPC.
+	DashboardServiceGetItemCategorySpendingProcedure = "/null.v1.DashboardService/GetItemCategorySpending"
 )
 
 // DashboardServiceClient is a client for the null.v1.DashboardService service.
@@ -71,6 +74,8 @@ type DashboardServiceClient interface {
 	// compares category spending between current and previous period
 	GetCategorySpendingComparison(context.Context, *connect.Request[v1.GetCategorySpendingComparisonRequest]) (*connect.Response[v1.GetCategorySpendingComparisonResponse], error)
 	GetNetWorthHistory(context.Context, *connect.Request[v1.GetNetWorthHistoryRequest]) (*connect.Response[v1.GetNetWorthHistoryResponse], error)
+	// receipt item spending by item category, optionally within one transaction category
+	GetItemCategorySpending(context.Context, *connect.Request[v1.GetItemCategorySpendingRequest]) (*connect.Response[v1.GetItemCategorySpendingResponse], error)
 }
 
 // NewDashboardServiceClient constructs a client for the null.v1.DashboardService service. By
@@ -132,6 +137,12 @@ func NewDashboardServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(dashboardServiceMethods.ByName("GetNetWorthHistory")),
 			connect.WithClientOptions(opts...),
 		),
+		getItemCategorySpending: connect.NewClient[v1.GetItemCategorySpendingRequest, v1.GetItemCategorySpendingResponse](
+			httpClient,
+			baseURL+DashboardServiceGetItemCategorySpendingProcedure,
+			connect.WithSchema(dashboardServiceMethods.ByName("GetItemCategorySpending")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -145,6 +156,7 @@ type dashboardServiceClient struct {
 	getFinancialSummary           *connect.Client[v1.GetFinancialSummaryRequest, v1.GetFinancialSummaryResponse]
 	getCategorySpendingComparison *connect.Client[v1.GetCategorySpendingComparisonRequest, v1.GetCategorySpendingComparisonResponse]
 	getNetWorthHistory            *connect.Client[v1.GetNetWorthHistoryRequest, v1.GetNetWorthHistoryResponse]
+	getItemCategorySpending       *connect.Client[v1.GetItemCategorySpendingRequest, v1.GetItemCategorySpendingResponse]
 }
 
 // GetDashboardSummary calls null.v1.DashboardService.GetDashboardSummary.
@@ -187,6 +199,11 @@ func (c *dashboardServiceClient) GetNetWorthHistory(ctx context.Context, req *co
 	return c.getNetWorthHistory.CallUnary(ctx, req)
 }
 
+// GetItemCategorySpending calls null.v1.DashboardService.GetItemCategorySpending.
+func (c *dashboardServiceClient) GetItemCategorySpending(ctx context.Context, req *connect.Request[v1.GetItemCategorySpendingRequest]) (*connect.Response[v1.GetItemCategorySpendingResponse], error) {
+	return c.getItemCategorySpending.CallUnary(ctx, req)
+}
+
 // DashboardServiceHandler is an implementation of the null.v1.DashboardService service.
 type DashboardServiceHandler interface {
 	GetDashboardSummary(context.Context, *connect.Request[v1.GetDashboardSummaryRequest]) (*connect.Response[v1.GetDashboardSummaryResponse], error)
@@ -199,6 +216,8 @@ type DashboardServiceHandler interface {
 	// compares category spending between current and previous period
 	GetCategorySpendingComparison(context.Context, *connect.Request[v1.GetCategorySpendingComparisonRequest]) (*connect.Response[v1.GetCategorySpendingComparisonResponse], error)
 	GetNetWorthHistory(context.Context, *connect.Request[v1.GetNetWorthHistoryRequest]) (*connect.Response[v1.GetNetWorthHistoryResponse], error)
+	// receipt item spending by item category, optionally within one transaction category
+	GetItemCategorySpending(context.Context, *connect.Request[v1.GetItemCategorySpendingRequest]) (*connect.Response[v1.GetItemCategorySpendingResponse], error)
 }
 
 // NewDashboardServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -256,6 +275,12 @@ func NewDashboardServiceHandler(svc DashboardServiceHandler, opts ...connect.Han
 		connect.WithSchema(dashboardServiceMethods.ByName("GetNetWorthHistory")),
 		connect.WithHandlerOptions(opts...),
 	)
+	dashboardServiceGetItemCategorySpendingHandler := connect.NewUnaryHandler(
+		DashboardServiceGetItemCategorySpendingProcedure,
+		svc.GetItemCategorySpending,
+		connect.WithSchema(dashboardServiceMethods.ByName("GetItemCategorySpending")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/null.v1.DashboardService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case DashboardServiceGetDashboardSummaryProcedure:
@@ -274,6 +299,8 @@ func NewDashboardServiceHandler(svc DashboardServiceHandler, opts ...connect.Han
 			dashboardServiceGetCategorySpendingComparisonHandler.ServeHTTP(w, r)
 		case DashboardServiceGetNetWorthHistoryProcedure:
 			dashboardServiceGetNetWorthHistoryHandler.ServeHTTP(w, r)
+		case DashboardServiceGetItemCategorySpendingProcedure:
+			dashboardServiceGetItemCategorySpendingHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -314,3 +341,7 @@ func (UnimplementedDashboardServiceHandler) GetCategorySpendingComparison(contex
 func (UnimplementedDashboardServiceHandler) GetNetWorthHistory(context.Context, *connect.Request[v1.GetNetWorthHistoryRequest]) (*connect.Response[v1.GetNetWorthHistoryResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.DashboardService.GetNetWorthHistory is not implemented"))
 }
+
+func (UnimplementedDashboardServiceHandler) GetItemCategorySpending(context.Context, *connect.Request[v1.GetItemCategorySpendingRequest]) (*connect.Response[v1.GetItemCategorySpendingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.DashboardService.GetItemCategorySpending is not implemented"))
+}
@@ -48,6 +48,15 @@ const (
 	// ReceiptServiceDeleteReceiptProcedure is the fully-qualified name of the ReceiptService's
 	// DeleteReceipt RPC.
 	ReceiptServiceDeleteReceiptProcedure = "/null.v1.ReceiptService/DeleteReceipt"
+	// ReceiptServiceRetryReceiptProcedure is the fully-qualified name of the ReceiptService's
+	// RetryReceipt RPC.
+	ReceiptServiceRetryReceiptProcedure = "/null.v1.ReceiptService/RetryReceipt"
+	// ReceiptServiceGetReceiptImageProcedure is the fully-qualified name of the ReceiptService's
+	// GetReceiptImage RPC.
+	ReceiptServiceGetReceiptImageProcedure = "/null.v1.ReceiptService/GetReceiptImage"
+	// ReceiptServiceGetPriceHistoryProcedure is the fully-qualified name of the ReceiptService's
+	// GetPriceHistory RPC.
+	ReceiptServiceGetPriceHistoryProcedure = "/null.v1.ReceiptService/GetPriceHistory"
 )
 
 // ReceiptServiceClient is a client for the null.v1.ReceiptService service.
@@ -57,6 +66,12 @@ type ReceiptServiceClient interface {
 	GetReceipt(context.Context, *connect.Request[v1.GetReceiptRequest]) (*connect.Response[v1.GetReceiptResponse], error)
 	UpdateReceipt(context.Context, *connect.Request[v1.UpdateReceiptRequest]) (*connect.Response[v1.UpdateReceiptResponse], error)
 	DeleteReceipt(context.Context, *connect.Request[v1.DeleteReceiptRequest]) (*connect.Response[v1.DeleteReceiptResponse], error)
+	// requeue a failed receipt for OCR with a fresh attempt budget
+	RetryReceipt(context.Context, *connect.Request[v1.RetryReceiptRequest]) (*connect.Response[v1.RetryReceiptResponse], error)
+	// original receipt image or a thumbnail, inline or as a signed url
+	GetReceiptImage(context.Context, *connect.Request[v1.GetReceiptImageRequest]) (*connect.Response[v1.GetReceiptImageResponse], error)
+	// item prices over time, per merchant, grouped by normalized item name
+	GetPriceHistory(context.Context, *connect.Request[v1.GetPriceHistoryRequest]) (*connect.Response[v1.GetPriceHistoryResponse], error)
 }
 
 // NewReceiptServiceClient constructs a client for the null.v1.ReceiptService service. By default,
@@ -100,16 +115,37 @@ func NewReceiptServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(receiptServiceMethods.ByName("DeleteReceipt")),
 			connect.WithClientOptions(opts...),
 		),
+		retryReceipt: connect.NewClient[v1.RetryReceiptRequest, v1.RetryReceiptResponse](
+			httpClient,
+			baseURL+ReceiptServiceRetryReceiptProcedure,
+			connect.WithSchema(receiptServiceMethods.ByName("RetryReceipt")),
+			connect.WithClientOptions(opts...),
+		),
+		getReceiptImage: connect.NewClient[v1.GetReceiptImageRequest, v1.GetReceiptImageResponse](
+			httpClient,
+			baseURL+ReceiptServiceGetReceiptImageProcedure,
+			connect.WithSchema(receiptServiceMethods.ByName("GetReceiptImage")),
+			connect.WithClientOptions(opts...),
+		),
+		getPriceHistory: connect.NewClient[v1.GetPriceHistoryRequest, v1.GetPriceHistoryResponse](
+			httpClient,
+			baseURL+ReceiptServiceGetPriceHistoryProcedure,
+			connect.WithSchema(receiptServiceMethods.ByName("GetPriceHistory")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // receiptServiceClient implements ReceiptServiceClient.
 type receiptServiceClient struct {
-	uploadReceipt *connect.Client[v1.UploadReceiptRequest, v1.UploadReceiptResponse]
-	listReceipts  *connect.Client[v1.ListReceiptsRequest, v1.ListReceiptsResponse]
-	getReceipt    *connect.Client[v1.GetReceiptRequest, v1.GetReceiptResponse]
-	updateReceipt *connect.Client[v1.UpdateReceiptRequest, v1.UpdateReceiptResponse]
-	deleteReceipt *connect.Client[v1.DeleteReceiptRequest, v1.DeleteReceiptResponse]
+	uploadReceipt   *connect.Client[v1.UploadReceiptRequest, v1.UploadReceiptResponse]
+	listReceipts    *connect.Client[v1.ListReceiptsRequest, v1.ListReceiptsResponse]
+	getReceipt      *connect.Client[v1.GetReceiptRequest, v1.GetReceiptResponse]
+	updateReceipt   *connect.Client[v1.UpdateReceiptRequest, v1.UpdateReceiptResponse]
+	deleteReceipt   *connect.Client[v1.DeleteReceiptRequest, v1.DeleteReceiptResponse]
+	retryReceipt    *connect.Client[v1.RetryReceiptRequest, v1.RetryReceiptResponse]
+	getReceiptImage *connect.Client[v1.GetReceiptImageRequest, v1.GetReceiptImageResponse]
+	getPriceHistory *connect.Client[v1.GetPriceHistoryRequest, v1.GetPriceHistoryResponse]
 }
 
 // UploadReceipt calls null.v1.ReceiptService.UploadReceipt.
@@ -137,6 +173,21 @@ func (c *receiptServiceClient) DeleteReceipt(ctx context.Context, req *connect.R
 	return c.deleteReceipt.CallUnary(ctx, req)
 }
 
+// RetryReceipt calls null.v1.ReceiptService.RetryReceipt.
+func (c *receiptServiceClient) RetryReceipt(ctx context.Context, req *connect.Request[v1.RetryReceiptRequest]) (*connect.Response[v1.RetryReceiptResponse], error) {
+	return c.retryReceipt.CallUnary(ctx, req)
+}
+
+// GetReceiptImage calls null.v1.ReceiptService.GetReceiptImage.
+func (c *receiptServiceClient) GetReceiptImage(ctx context.Context, req *connect.Request[v1.GetReceiptImageRequest]) (*connect.Response[v1.GetReceiptImageResponse], error) {
+	return c.getReceiptImage.CallUnary(ctx, req)
+}
+
+// GetPriceHistory calls null.v1.ReceiptService.GetPriceHistory.
+func (c *receiptServiceClient) GetPriceHistory(ctx context.Context, req *connect.Request[v1.GetPriceHistoryRequest]) (*connect.Response[v1.GetPriceHistoryResponse], error) {
+	return c.getPriceHistory.CallUnary(ctx, req)
+}
+
 // ReceiptServiceHandler is an implementation of the null.v1.ReceiptService service.
 type ReceiptServiceHandler interface {
 	UploadReceipt(context.Context, *connect.Request[v1.UploadReceiptRequest]) (*connect.Response[v1.UploadReceiptResponse], error)
@@ -144,6 +195,12 @@ type ReceiptServiceHandler interface {
 	GetReceipt(context.Context, *connect.Request[v1.GetReceiptRequest]) (*connect.Response[v1.GetReceiptResponse], error)
 	UpdateReceipt(context.Context, *connect.Request[v1.UpdateReceiptRequest]) (*connect.Response[v1.UpdateReceiptResponse], error)
 	DeleteReceipt(context.Context, *connect.Request[v1.DeleteReceiptRequest]) (*connect.Response[v1.DeleteReceiptResponse], error)
+	// requeue a failed receipt for OCR with a fresh attempt budget
+	RetryReceipt(context.Context, *connect.Request[v1.RetryReceiptRequest]) (*connect.Response[v1.RetryReceiptResponse], error)
+	// original receipt image or a thumbnail, inline or as a signed url
+	GetReceiptImage(context.Context, *connect.Request[v1.GetReceiptImageRequest]) (*connect.Response[v1.GetReceiptImageResponse], error)
+	// item prices over time, per merchant, grouped by normalized item name
+	GetPriceHistory(context.Context, *connect.Request[v1.GetPriceHistoryRequest]) (*connect.Response[v1.GetPriceHistoryResponse], error)
 }
 
 // NewReceiptServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -183,6 +240,24 @@ func NewReceiptServiceHandler(svc ReceiptServiceHandler, opts ...connect.Handler
 		connect.WithSchema(receiptServiceMethods.ByName("DeleteReceipt")),
 		connect.WithHandlerOptions(opts...),
 	)
+	receiptServiceRetryReceiptHandler := connect.NewUnaryHandler(
+		ReceiptServiceRetryReceiptProcedure,
+		svc.RetryReceipt,
+		connect.WithSchema(receiptServiceMethods.ByName("RetryReceipt")),
+		connect.WithHandlerOptions(opts...),
+	)
+	receiptServiceGetReceiptImageHandler := connect.NewUnaryHandler(
+		ReceiptServiceGetReceiptImageProcedure,
+		svc.GetReceiptImage,
+		connect.WithSchema(receiptServiceMethods.ByName("GetReceiptImage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	receiptServiceGetPriceHistoryHandler := connect.NewUnaryHandler(
+		ReceiptServiceGetPriceHistoryProcedure,
+		svc.GetPriceHistory,
+		connect.WithSchema(receiptServiceMethods.ByName("GetPriceHistory")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/null.v1.ReceiptService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ReceiptServiceUploadReceiptProcedure:
@@ -195,6 +270,12 @@ func NewReceiptServiceHandler(svc ReceiptServiceHandler, opts ...connect.Handler
 			receiptServiceUpdateReceiptHandler.ServeHTTP(w, r)
 		case ReceiptServiceDeleteReceiptProcedure:
 			receiptServiceDeleteReceiptHandler.ServeHTTP(w, r)
+		case ReceiptServiceRetryReceiptProcedure:
+			receiptServiceRetryReceiptHandler.ServeHTTP(w, r)
+		case ReceiptServiceGetReceiptImageProcedure:
+			receiptServiceGetReceiptImageHandler.ServeHTTP(w, r)
+		case ReceiptServiceGetPriceHistoryProcedure:
+			receiptServiceGetPriceHistoryHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -223,3 +304,15 @@ func (UnimplementedReceiptServiceHandler) UpdateReceipt(context.Context, *connec
 func (UnimplementedReceiptServiceHandler) DeleteReceipt(context.Context, *connect.Request[v1.DeleteReceiptRequest]) (*connect.Response[v1.DeleteReceiptResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.ReceiptService.DeleteReceipt is not implemented"))
 }
+
+func (UnimplementedReceiptServiceHandler) RetryReceipt(context.Context, *connect.Request[v1.RetryReceiptRequest]) (*connect.Response[v1.RetryReceiptResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.ReceiptService.RetryReceipt is not implemented"))
+}
+
+func (UnimplementedReceiptServiceHandler) GetReceiptImage(context.Context, *connect.Request[v1.GetReceiptImageRequest]) (*connect.Response[v1.GetReceiptImageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.ReceiptService.GetReceiptImage is not implemented"))
+}
+
+func (UnimplementedReceiptServiceHandler) GetPriceHistory(context.Context, *connect.Request[v1.GetPriceHistoryRequest]) (*connect.Response[v1.GetPriceHistoryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.ReceiptService.GetPriceHistory is not implemented"))
+}
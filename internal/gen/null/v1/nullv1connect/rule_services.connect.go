@@ -46,6 +46,9 @@ const (
 	// RuleServiceValidateRuleProcedure is the fully-qualified name of the RuleService's ValidateRule
 	// RPC.
 	RuleServiceValidateRuleProcedure = "/null.v1.RuleService/ValidateRule"
+	// RuleServiceSuggestRulesProcedure is the fully-qualified name of the RuleService's SuggestRules
+	// RPC.
+	RuleServiceSuggestRulesProcedure = "/null.v1.RuleService/SuggestRules"
 )
 
 // RuleServiceClient is a client for the null.v1.RuleService service.
@@ -56,6 +59,8 @@ type RuleServiceClient interface {
 	UpdateRule(context.Context, *connect.Request[v1.UpdateRuleRequest]) (*connect.Response[v1.UpdateRuleResponse], error)
 	DeleteRule(context.Context, *connect.Request[v1.DeleteRuleRequest]) (*connect.Response[v1.DeleteRuleResponse], error)
 	ValidateRule(context.Context, *connect.Request[v1.ValidateRuleRequest]) (*connect.Response[v1.ValidateRuleResponse], error)
+	// mine manual categorizations for candidate rules
+	SuggestRules(context.Context, *connect.Request[v1.SuggestRulesRequest]) (*connect.Response[v1.SuggestRulesResponse], error)
 }
 
 // NewRuleServiceClient constructs a client for the null.v1.RuleService service. By default, it uses
@@ -105,6 +110,12 @@ func NewRuleServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(ruleServiceMethods.ByName("ValidateRule")),
 			connect.WithClientOptions(opts...),
 		),
+		suggestRules: connect.NewClient[v1.SuggestRulesRequest, v1.SuggestRulesResponse](
+			httpClient,
+			baseURL+RuleServiceSuggestRulesProcedure,
+			connect.WithSchema(ruleServiceMethods.ByName("SuggestRules")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -116,6 +127,7 @@ type ruleServiceClient struct {
 	updateRule   *connect.Client[v1.UpdateRuleRequest, v1.UpdateRuleResponse]
 	deleteRule   *connect.Client[v1.DeleteRuleRequest, v1.DeleteRuleResponse]
 	validateRule *connect.Client[v1.ValidateRuleRequest, v1.ValidateRuleResponse]
+	suggestRules *connect.Client[v1.SuggestRulesRequest, v1.SuggestRulesResponse]
 }
 
 // ListRules calls null.v1.RuleService.ListRules.
@@ -148,6 +160,11 @@ func (c *ruleServiceClient) ValidateRule(ctx context.Context, req *connect.Reque
 	return c.validateRule.CallUnary(ctx, req)
 }
 
+// SuggestRules calls null.v1.RuleService.SuggestRules.
+func (c *ruleServiceClient) SuggestRules(ctx context.Context, req *connect.Request[v1.SuggestRulesRequest]) (*connect.Response[v1.SuggestRulesResponse], error) {
+	return c.suggestRules.CallUnary(ctx, req)
+}
+
 // RuleServiceHandler is an implementation of the null.v1.RuleService service.
 type RuleServiceHandler interface {
 	ListRules(context.Context, *connect.Request[v1.ListRulesRequest]) (*connect.Response[v1.ListRulesResponse], error)
@@ -156,6 +173,8 @@ type RuleServiceHandler interface {
 	UpdateRule(context.Context, *connect.Request[v1.UpdateRuleRequest]) (*connect.Response[v1.UpdateRuleResponse], error)
 	DeleteRule(context.Context, *connect.Request[v1.DeleteRuleRequest]) (*connect.Response[v1.DeleteRuleResponse], error)
 	ValidateRule(context.Context, *connect.Request[v1.ValidateRuleRequest]) (*connect.Response[v1.ValidateRuleResponse], error)
+	// mine manual categorizations for candidate rules
+	SuggestRules(context.Context, *connect.Request[v1.SuggestRulesRequest]) (*connect.Response[v1.SuggestRulesResponse], error)
 }
 
 // NewRuleServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -201,6 +220,12 @@ func NewRuleServiceHandler(svc RuleServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(ruleServiceMethods.ByName("ValidateRule")),
 		connect.WithHandlerOptions(opts...),
 	)
+	ruleServiceSuggestRulesHandler := connect.NewUnaryHandler(
+		RuleServiceSuggestRulesProcedure,
+		svc.SuggestRules,
+		connect.WithSchema(ruleServiceMethods.ByName("SuggestRules")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/null.v1.RuleService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RuleServiceListRulesProcedure:
@@ -215,6 +240,8 @@ func NewRuleServiceHandler(svc RuleServiceHandler, opts ...connect.HandlerOption
 			ruleServiceDeleteRuleHandler.ServeHTTP(w, r)
 		case RuleServiceValidateRuleProcedure:
 			ruleServiceValidateRuleHandler.ServeHTTP(w, r)
+		case RuleServiceSuggestRulesProcedure:
+			ruleServiceSuggestRulesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -247,3 +274,7 @@ func (UnimplementedRuleServiceHandler) DeleteRule(context.Context, *connect.Requ
 func (UnimplementedRuleServiceHandler) ValidateRule(context.Context, *connect.Request[v1.ValidateRuleRequest]) (*connect.Response[v1.ValidateRuleResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.RuleService.ValidateRule is not implemented"))
 }
+
+func (UnimplementedRuleServiceHandler) SuggestRules(context.Context, *connect.Request[v1.SuggestRulesRequest]) (*connect.Response[v1.SuggestRulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.RuleService.SuggestRules is not implemented"))
+}
@@ -51,6 +51,9 @@ const (
 	// TransactionServiceCategorizeTransactionsProcedure is the fully-qualified name of the
 	// TransactionService's CategorizeTransactions RPC.
 	TransactionServiceCategorizeTransactionsProcedure = "/null.v1.TransactionService/CategorizeTransactions"
+	// TransactionServiceListUncategorizedTransactionsProcedure is the fully-qualified name of the
+	// TransactionService's ListUncategorizedTransactions RPC.
+	TransactionServiceListUncategorizedTransactionsProcedure = "/null.v1.TransactionService/ListUncategorizedTransactions"
 )
 
 // TransactionServiceClient is a client for the null.v1.TransactionService service.
@@ -61,6 +64,8 @@ type TransactionServiceClient interface {
 	UpdateTransaction(context.Context, *connect.Request[v1.UpdateTransactionRequest]) (*connect.Response[v1.UpdateTransactionResponse], error)
 	DeleteTransaction(context.Context, *connect.Request[v1.DeleteTransactionRequest]) (*connect.Response[v1.DeleteTransactionResponse], error)
 	CategorizeTransactions(context.Context, *connect.Request[v1.CategorizeTransactionsRequest]) (*connect.Response[v1.CategorizeTransactionsResponse], error)
+	// uncategorized inbox: uncategorized transactions with category suggestions
+	ListUncategorizedTransactions(context.Context, *connect.Request[v1.ListUncategorizedTransactionsRequest]) (*connect.Response[v1.ListUncategorizedTransactionsResponse], error)
 }
 
 // NewTransactionServiceClient constructs a client for the null.v1.TransactionService service. By
@@ -110,17 +115,24 @@ func NewTransactionServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(transactionServiceMethods.ByName("CategorizeTransactions")),
 			connect.WithClientOptions(opts...),
 		),
+		listUncategorizedTransactions: connect.NewClient[v1.ListUncategorizedTransactionsRequest, v1.ListUncategorizedTransactionsResponse](
+			httpClient,
+			baseURL+TransactionServiceListUncategorizedTransactionsProcedure,
+			connect.WithSchema(transactionServiceMethods.ByName("ListUncategorizedTransactions")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // transactionServiceClient implements TransactionServiceClient.
 type transactionServiceClient struct {
-	listTransactions       *connect.Client[v1.ListTransactionsRequest, v1.ListTransactionsResponse]
-	getTransaction         *connect.Client[v1.GetTransactionRequest, v1.GetTransactionResponse]
-	createTransaction      *connect.Client[v1.CreateTransactionRequest, v1.CreateTransactionResponse]
-	updateTransaction      *connect.Client[v1.UpdateTransactionRequest, v1.UpdateTransactionResponse]
-	deleteTransaction      *connect.Client[v1.DeleteTransactionRequest, v1.DeleteTransactionResponse]
-	categorizeTransactions *connect.Client[v1.CategorizeTransactionsRequest, v1.CategorizeTransactionsResponse]
+	listTransactions              *connect.Client[v1.ListTransactionsRequest, v1.ListTransactionsResponse]
+	getTransaction                *connect.Client[v1.GetTransactionRequest, v1.GetTransactionResponse]
+	createTransaction             *connect.Client[v1.CreateTransactionRequest, v1.CreateTransactionResponse]
+	updateTransaction             *connect.Client[v1.UpdateTransactionRequest, v1.UpdateTransactionResponse]
+	deleteTransaction             *connect.Client[v1.DeleteTransactionRequest, v1.DeleteTransactionResponse]
+	categorizeTransactions        *connect.Client[v1.CategorizeTransactionsRequest, v1.CategorizeTransactionsResponse]
+	listUncategorizedTransactions *connect.Client[v1.ListUncategorizedTransactionsRequest, v1.ListUncategorizedTransactionsResponse]
 }
 
 // ListTransactions calls null.v1.TransactionService.ListTransactions.
@@ -153,6 +165,11 @@ func (c *transactionServiceClient) CategorizeTransactions(ctx context.Context, r
 	return c.categorizeTransactions.CallUnary(ctx, req)
 }
 
+// ListUncategorizedTransactions calls null.v1.TransactionService.ListUncategorizedTransactions.
+func (c *transactionServiceClient) ListUncategorizedTransactions(ctx context.Context, req *connect.Request[v1.ListUncategorizedTransactionsRequest]) (*connect.Response[v1.ListUncategorizedTransactionsResponse], error) {
+	return c.listUncategorizedTransactions.CallUnary(ctx, req)
+}
+
 // TransactionServiceHandler is an implementation of the null.v1.TransactionService service.
 type TransactionServiceHandler interface {
 	ListTransactions(context.Context, *connect.Request[v1.ListTransactionsRequest]) (*connect.Response[v1.ListTransactionsResponse], error)
@@ -161,6 +178,8 @@ type TransactionServiceHandler interface {
 	UpdateTransaction(context.Context, *connect.Request[v1.UpdateTransactionRequest]) (*connect.Response[v1.UpdateTransactionResponse], error)
 	DeleteTransaction(context.Context, *connect.Request[v1.DeleteTransactionRequest]) (*connect.Response[v1.DeleteTransactionResponse], error)
 	CategorizeTransactions(context.Context, *connect.Request[v1.CategorizeTransactionsRequest]) (*connect.Response[v1.CategorizeTransactionsResponse], error)
+	// uncategorized inbox: uncategorized transactions with category suggestions
+	ListUncategorizedTransactions(context.Context, *connect.Request[v1.ListUncategorizedTransactionsRequest]) (*connect.Response[v1.ListUncategorizedTransactionsResponse], error)
 }
 
 // NewTransactionServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -206,6 +225,12 @@ func NewTransactionServiceHandler(svc TransactionServiceHandler, opts ...connect
 		connect.WithSchema(transactionServiceMethods.ByName("CategorizeTransactions")),
 		connect.WithHandlerOptions(opts...),
 	)
+	transactionServiceListUncategorizedTransactionsHandler := connect.NewUnaryHandler(
+		TransactionServiceListUncategorizedTransactionsProcedure,
+		svc.ListUncategorizedTransactions,
+		connect.WithSchema(transactionServiceMethods.ByName("ListUncategorizedTransactions")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/null.v1.TransactionService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case TransactionServiceListTransactionsProcedure:
@@ -220,6 +245,8 @@ func NewTransactionServiceHandler(svc TransactionServiceHandler, opts ...connect
 			transactionServiceDeleteTransactionHandler.ServeHTTP(w, r)
 		case TransactionServiceCategorizeTransactionsProcedure:
 			transactionServiceCategorizeTransactionsHandler.ServeHTTP(w, r)
+		case TransactionServiceListUncategorizedTransactionsProcedure:
+			transactionServiceListUncategorizedTransactionsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -252,3 +279,7 @@ func (UnimplementedTransactionServiceHandler) DeleteTransaction(context.Context,
 func (UnimplementedTransactionServiceHandler) CategorizeTransactions(context.Context, *connect.Request[v1.CategorizeTransactionsRequest]) (*connect.Response[v1.CategorizeTransactionsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.TransactionService.CategorizeTransactions is not implemented"))
 }
+
+func (UnimplementedTransactionServiceHandler) ListUncategorizedTransactions(context.Context, *connect.Request[v1.ListUncategorizedTransactionsRequest]) (*connect.Response[v1.ListUncategorizedTransactionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.TransactionService.ListUncategorizedTransactions is not implemented"))
+}
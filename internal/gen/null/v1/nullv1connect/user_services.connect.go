@@ -41,6 +41,15 @@ const (
 	UserServiceUpdateUserProcedure = "/null.v1.UserService/UpdateUser"
 	// UserServiceDeleteUserProcedure is the fully-qualified name of the UserService's DeleteUser RPC.
 	UserServiceDeleteUserProcedure = "/null.v1.UserService/DeleteUser"
+	// UserServiceCreateApiTokenProcedure is the fully-qualified name of the UserService's
+	// CreateApiToken RPC.
+	UserServiceCreateApiTokenProcedure = "/null.v1.UserService/CreateApiToken"
+	// UserServiceListApiTokensProcedure is the fully-qualified name of the UserService's ListApiTokens
+	// RPC.
+	UserServiceListApiTokensProcedure = "/null.v1.UserService/ListApiTokens"
+	// UserServiceDeleteApiTokenProcedure is the fully-qualified name of the UserService's
+	// DeleteApiToken RPC.
+	UserServiceDeleteApiTokenProcedure = "/null.v1.UserService/DeleteApiToken"
 )
 
 // UserServiceClient is a client for the null.v1.UserService service.
@@ -49,6 +58,11 @@ type UserServiceClient interface {
 	CreateUser(context.Context, *connect.Request[v1.CreateUserRequest]) (*connect.Response[v1.CreateUserResponse], error)
 	UpdateUser(context.Context, *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.UpdateUserResponse], error)
 	DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[v1.DeleteUserResponse], error)
+	// personal API tokens for scripts and integrations; tokens can't manage
+	// other tokens
+	CreateApiToken(context.Context, *connect.Request[v1.CreateApiTokenRequest]) (*connect.Response[v1.CreateApiTokenResponse], error)
+	ListApiTokens(context.Context, *connect.Request[v1.ListApiTokensRequest]) (*connect.Response[v1.ListApiTokensResponse], error)
+	DeleteApiToken(context.Context, *connect.Request[v1.DeleteApiTokenRequest]) (*connect.Response[v1.DeleteApiTokenResponse], error)
 }
 
 // NewUserServiceClient constructs a client for the null.v1.UserService service. By default, it uses
@@ -86,15 +100,36 @@ func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(userServiceMethods.ByName("DeleteUser")),
 			connect.WithClientOptions(opts...),
 		),
+		createApiToken: connect.NewClient[v1.CreateApiTokenRequest, v1.CreateApiTokenResponse](
+			httpClient,
+			baseURL+UserServiceCreateApiTokenProcedure,
+			connect.WithSchema(userServiceMethods.ByName("CreateApiToken")),
+			connect.WithClientOptions(opts...),
+		),
+		listApiTokens: connect.NewClient[v1.ListApiTokensRequest, v1.ListApiTokensResponse](
+			httpClient,
+			baseURL+UserServiceListApiTokensProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListApiTokens")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteApiToken: connect.NewClient[v1.DeleteApiTokenRequest, v1.DeleteApiTokenResponse](
+			httpClient,
+			baseURL+UserServiceDeleteApiTokenProcedure,
+			connect.WithSchema(userServiceMethods.ByName("DeleteApiToken")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // userServiceClient implements UserServiceClient.
 type userServiceClient struct {
-	getUser    *connect.Client[v1.GetUserRequest, v1.GetUserResponse]
-	createUser *connect.Client[v1.CreateUserRequest, v1.CreateUserResponse]
-	updateUser *connect.Client[v1.UpdateUserRequest, v1.UpdateUserResponse]
-	deleteUser *connect.Client[v1.DeleteUserRequest, v1.DeleteUserResponse]
+	getUser        *connect.Client[v1.GetUserRequest, v1.GetUserResponse]
+	createUser     *connect.Client[v1.CreateUserRequest, v1.CreateUserResponse]
+	updateUser     *connect.Client[v1.UpdateUserRequest, v1.UpdateUserResponse]
+	deleteUser     *connect.Client[v1.DeleteUserRequest, v1.DeleteUserResponse]
+	createApiToken *connect.Client[v1.CreateApiTokenRequest, v1.CreateApiTokenResponse]
+	listApiTokens  *connect.Client[v1.ListApiTokensRequest, v1.ListApiTokensResponse]
+	deleteApiToken *connect.Client[v1.DeleteApiTokenRequest, v1.DeleteApiTokenResponse]
 }
 
 // GetUser calls null.v1.UserService.GetUser.
@@ -117,12 +152,32 @@ func (c *userServiceClient) DeleteUser(ctx context.Context, req *connect.Request
 	return c.deleteUser.CallUnary(ctx, req)
 }
 
+// CreateApiToken calls null.v1.UserService.CreateApiToken.
+func (c *userServiceClient) CreateApiToken(ctx context.Context, req *connect.Request[v1.CreateApiTokenRequest]) (*connect.Response[v1.CreateApiTokenResponse], error) {
+	return c.createApiToken.CallUnary(ctx, req)
+}
+
+// ListApiTokens calls null.v1.UserService.ListApiTokens.
+func (c *userServiceClient) ListApiTokens(ctx context.Context, req *connect.Request[v1.ListApiTokensRequest]) (*connect.Response[v1.ListApiTokensResponse], error) {
+	return c.listApiTokens.CallUnary(ctx, req)
+}
+
+// DeleteApiToken calls null.v1.UserService.DeleteApiToken.
+func (c *userServiceClient) DeleteApiToken(ctx context.Context, req *connect.Request[v1.DeleteApiTokenRequest]) (*connect.Response[v1.DeleteApiTokenResponse], error) {
+	return c.deleteApiToken.CallUnary(ctx, req)
+}
+
 // UserServiceHandler is an implementation of the null.v1.UserService service.
 type UserServiceHandler interface {
 	GetUser(context.Context, *connect.Request[v1.GetUserRequest]) (*connect.Response[v1.GetUserResponse], error)
 	CreateUser(context.Context, *connect.Request[v1.CreateUserRequest]) (*connect.Response[v1.CreateUserResponse], error)
 	UpdateUser(context.Context, *connect.Request[v1.UpdateUserRequest]) (*connect.Response[v1.UpdateUserResponse], error)
 	DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[v1.DeleteUserResponse], error)
+	// personal API tokens for scripts and integrations; tokens can't manage
+	// other tokens
+	CreateApiToken(context.Context, *connect.Request[v1.CreateApiTokenRequest]) (*connect.Response[v1.CreateApiTokenResponse], error)
+	ListApiTokens(context.Context, *connect.Request[v1.ListApiTokensRequest]) (*connect.Response[v1.ListApiTokensResponse], error)
+	DeleteApiToken(context.Context, *connect.Request[v1.DeleteApiTokenRequest]) (*connect.Response[v1.DeleteApiTokenResponse], error)
 }
 
 // NewUserServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -156,6 +211,24 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(userServiceMethods.ByName("DeleteUser")),
 		connect.WithHandlerOptions(opts...),
 	)
+	userServiceCreateApiTokenHandler := connect.NewUnaryHandler(
+		UserServiceCreateApiTokenProcedure,
+		svc.CreateApiToken,
+		connect.WithSchema(userServiceMethods.ByName("CreateApiToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListApiTokensHandler := connect.NewUnaryHandler(
+		UserServiceListApiTokensProcedure,
+		svc.ListApiTokens,
+		connect.WithSchema(userServiceMethods.ByName("ListApiTokens")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceDeleteApiTokenHandler := connect.NewUnaryHandler(
+		UserServiceDeleteApiTokenProcedure,
+		svc.DeleteApiToken,
+		connect.WithSchema(userServiceMethods.ByName("DeleteApiToken")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/null.v1.UserService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case UserServiceGetUserProcedure:
@@ -166,6 +239,12 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 			userServiceUpdateUserHandler.ServeHTTP(w, r)
 		case UserServiceDeleteUserProcedure:
 			userServiceDeleteUserHandler.ServeHTTP(w, r)
+		case UserServiceCreateApiTokenProcedure:
+			userServiceCreateApiTokenHandler.ServeHTTP(w, r)
+		case UserServiceListApiTokensProcedure:
+			userServiceListApiTokensHandler.ServeHTTP(w, r)
+		case UserServiceDeleteApiTokenProcedure:
+			userServiceDeleteApiTokenHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -190,3 +269,15 @@ func (UnimplementedUserServiceHandler) UpdateUser(context.Context, *connect.Requ
 func (UnimplementedUserServiceHandler) DeleteUser(context.Context, *connect.Request[v1.DeleteUserRequest]) (*connect.Response[v1.DeleteUserResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.UserService.DeleteUser is not implemented"))
 }
+
+func (UnimplementedUserServiceHandler) CreateApiToken(context.Context, *connect.Request[v1.CreateApiTokenRequest]) (*connect.Response[v1.CreateApiTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.UserService.CreateApiToken is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListApiTokens(context.Context, *connect.Request[v1.ListApiTokensRequest]) (*connect.Response[v1.ListApiTokensResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.UserService.ListApiTokens is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) DeleteApiToken(context.Context, *connect.Request[v1.DeleteApiTokenRequest]) (*connect.Response[v1.DeleteApiTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("null.v1.UserService.DeleteApiToken is not implemented"))
+}
@@ -32,6 +32,8 @@ const (
 	ReceiptStatus_RECEIPT_STATUS_PARSED      ReceiptStatus = 2
 	ReceiptStatus_RECEIPT_STATUS_LINKED      ReceiptStatus = 3
 	ReceiptStatus_RECEIPT_STATUS_FAILED      ReceiptStatus = 4
+	// parsed, but failed the consistency checks or OCR confidence threshold
+	ReceiptStatus_RECEIPT_STATUS_NEEDS_REVIEW ReceiptStatus = 5
 )
 
 // Enum value maps for ReceiptStatus.
@@ -42,13 +44,15 @@ var (
 		2: "RECEIPT_STATUS_PARSED",
 		3: "RECEIPT_STATUS_LINKED",
 		4: "RECEIPT_STATUS_FAILED",
+		5: "RECEIPT_STATUS_NEEDS_REVIEW",
 	}
 	ReceiptStatus_value = map[string]int32{
-		"RECEIPT_STATUS_UNSPECIFIED": 0,
-		"RECEIPT_STATUS_PENDING":     1,
-		"RECEIPT_STATUS_PARSED":      2,
-		"RECEIPT_STATUS_LINKED":      3,
-		"RECEIPT_STATUS_FAILED":      4,
+		"RECEIPT_STATUS_UNSPECIFIED":  0,
+		"RECEIPT_STATUS_PENDING":      1,
+		"RECEIPT_STATUS_PARSED":       2,
+		"RECEIPT_STATUS_LINKED":       3,
+		"RECEIPT_STATUS_FAILED":       4,
+		"RECEIPT_STATUS_NEEDS_REVIEW": 5,
 	}
 )
 
@@ -79,6 +83,59 @@ func (ReceiptStatus) EnumDescriptor() ([]byte, []int) {
 	return file_null_v1_receipt_proto_rawDescGZIP(), []int{0}
 }
 
+// thumbnails are JPEGs scaled so the longest side fits the given size
+type ReceiptImageSize int32
+
+const (
+	ReceiptImageSize_RECEIPT_IMAGE_SIZE_UNSPECIFIED ReceiptImageSize = 0 // original
+	ReceiptImageSize_RECEIPT_IMAGE_SIZE_SMALL       ReceiptImageSize = 1 // 160px
+	ReceiptImageSize_RECEIPT_IMAGE_SIZE_MEDIUM      ReceiptImageSize = 2 // 480px
+	ReceiptImageSize_RECEIPT_IMAGE_SIZE_LARGE       ReceiptImageSize = 3 // 1024px
+)
+
+// Enum value maps for ReceiptImageSize.
+var (
+	ReceiptImageSize_name = map[int32]string{
+		0: "RECEIPT_IMAGE_SIZE_UNSPECIFIED",
+		1: "RECEIPT_IMAGE_SIZE_SMALL",
+		2: "RECEIPT_IMAGE_SIZE_MEDIUM",
+		3: "RECEIPT_IMAGE_SIZE_LARGE",
+	}
+	ReceiptImageSize_value = map[string]int32{
+		"RECEIPT_IMAGE_SIZE_UNSPECIFIED": 0,
+		"RECEIPT_IMAGE_SIZE_SMALL":       1,
+		"RECEIPT_IMAGE_SIZE_MEDIUM":      2,
+		"RECEIPT_IMAGE_SIZE_LARGE":       3,
+	}
+)
+
+func (x ReceiptImageSize) Enum() *ReceiptImageSize {
+	p := new(ReceiptImageSize)
+	*p = x
+	return p
+}
+
+func (x ReceiptImageSize) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReceiptImageSize) Descriptor() protoreflect.EnumDescriptor {
+	return file_null_v1_receipt_proto_enumTypes[1].Descriptor()
+}
+
+func (ReceiptImageSize) Type() protoreflect.EnumType {
+	return &file_null_v1_receipt_proto_enumTypes[1]
+}
+
+func (x ReceiptImageSize) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReceiptImageSize.Descriptor instead.
+func (ReceiptImageSize) EnumDescriptor() ([]byte, []int) {
+	return file_null_v1_receipt_proto_rawDescGZIP(), []int{1}
+}
+
 type ReceiptItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -88,6 +145,7 @@ type ReceiptItem struct {
 	Quantity      float64                `protobuf:"fixed64,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	UnitPrice     *money.Money           `protobuf:"bytes,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
 	SortOrder     int32                  `protobuf:"varint,7,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	CategoryId    *int64                 `protobuf:"varint,8,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -171,11 +229,21 @@ func (x *ReceiptItem) GetSortOrder() int32 {
 	return 0
 }
 
+func (x *ReceiptItem) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
 type Receipt struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	Id                  int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId              string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TransactionId       *int64                 `protobuf:"varint,3,opt,name=transaction_id,json=transactionId,proto3,oneof" json:"transaction_id,omitempty"`
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TransactionId *int64                 `protobuf:"varint,3,opt,name=transaction_id,json=transactionId,proto3,oneof" json:"transaction_id,omitempty"`
+	// storage key of the original image; fetch it with GetReceiptImage
+	//
+	// Deprecated: Marked as deprecated in null/v1/receipt.proto.
 	ImagePath           string                 `protobuf:"bytes,4,opt,name=image_path,json=imagePath,proto3" json:"image_path,omitempty"`
 	Merchant            *string                `protobuf:"bytes,5,opt,name=merchant,proto3,oneof" json:"merchant,omitempty"`
 	ReceiptDate         *date.Date             `protobuf:"bytes,6,opt,name=receipt_date,json=receiptDate,proto3,oneof" json:"receipt_date,omitempty"`
@@ -190,8 +258,15 @@ type Receipt struct {
 	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	TransactionMerchant *string                `protobuf:"bytes,16,opt,name=transaction_merchant,json=transactionMerchant,proto3,oneof" json:"transaction_merchant,omitempty"`
 	TransactionAmount   *money.Money           `protobuf:"bytes,17,opt,name=transaction_amount,json=transactionAmount,proto3,oneof" json:"transaction_amount,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// OCR job state
+	Attempts  int32   `protobuf:"varint,18,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError *string `protobuf:"bytes,19,opt,name=last_error,json=lastError,proto3,oneof" json:"last_error,omitempty"`
+	// the upload's images/PDFs in order; fetch each with GetReceiptImage
+	Pages []*ReceiptPage `protobuf:"bytes,20,rep,name=pages,proto3" json:"pages,omitempty"`
+	// why a parse landed in NEEDS_REVIEW; empty otherwise
+	ReviewReasons []string `protobuf:"bytes,21,rep,name=review_reasons,json=reviewReasons,proto3" json:"review_reasons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Receipt) Reset() {
@@ -245,6 +320,7 @@ func (x *Receipt) GetTransactionId() int64 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in null/v1/receipt.proto.
 func (x *Receipt) GetImagePath() string {
 	if x != nil {
 		return x.ImagePath
@@ -343,6 +419,86 @@ func (x *Receipt) GetTransactionAmount() *money.Money {
 	return nil
 }
 
+func (x *Receipt) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Receipt) GetLastError() string {
+	if x != nil && x.LastError != nil {
+		return *x.LastError
+	}
+	return ""
+}
+
+func (x *Receipt) GetPages() []*ReceiptPage {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+func (x *Receipt) GetReviewReasons() []string {
+	if x != nil {
+		return x.ReviewReasons
+	}
+	return nil
+}
+
+type ReceiptPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageNo        int32                  `protobuf:"varint,1,opt,name=page_no,json=pageNo,proto3" json:"page_no,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiptPage) Reset() {
+	*x = ReceiptPage{}
+	mi := &file_null_v1_receipt_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiptPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptPage) ProtoMessage() {}
+
+func (x *ReceiptPage) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptPage.ProtoReflect.Descriptor instead.
+func (*ReceiptPage) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReceiptPage) GetPageNo() int32 {
+	if x != nil {
+		return x.PageNo
+	}
+	return 0
+}
+
+func (x *ReceiptPage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
 type ReceiptLinkCandidate struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	TransactionId   int64                  `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
@@ -353,13 +509,15 @@ type ReceiptLinkCandidate struct {
 	AccountName     string                 `protobuf:"bytes,6,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
 	DateDiffDays    int32                  `protobuf:"varint,7,opt,name=date_diff_days,json=dateDiffDays,proto3" json:"date_diff_days,omitempty"`
 	AmountDiffCents int64                  `protobuf:"varint,8,opt,name=amount_diff_cents,json=amountDiffCents,proto3" json:"amount_diff_cents,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// 0-1, from amount, date and merchant similarity
+	Score         float64 `protobuf:"fixed64,9,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ReceiptLinkCandidate) Reset() {
 	*x = ReceiptLinkCandidate{}
-	mi := &file_null_v1_receipt_proto_msgTypes[2]
+	mi := &file_null_v1_receipt_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -371,7 +529,7 @@ func (x *ReceiptLinkCandidate) String() string {
 func (*ReceiptLinkCandidate) ProtoMessage() {}
 
 func (x *ReceiptLinkCandidate) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_proto_msgTypes[2]
+	mi := &file_null_v1_receipt_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,7 +542,7 @@ func (x *ReceiptLinkCandidate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReceiptLinkCandidate.ProtoReflect.Descriptor instead.
 func (*ReceiptLinkCandidate) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_proto_rawDescGZIP(), []int{2}
+	return file_null_v1_receipt_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ReceiptLinkCandidate) GetTransactionId() int64 {
@@ -443,11 +601,268 @@ func (x *ReceiptLinkCandidate) GetAmountDiffCents() int64 {
 	return 0
 }
 
+func (x *ReceiptLinkCandidate) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// one normalized product in one currency across all receipts
+type ItemPriceHistory struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// normalized key items are grouped by, e.g. "organic banana"
+	NormalizedName string `protobuf:"bytes,1,opt,name=normalized_name,json=normalizedName,proto3" json:"normalized_name,omitempty"`
+	// the most common name as printed
+	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Currency    string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	// oldest first
+	Points []*ItemPricePoint `protobuf:"bytes,4,rep,name=points,proto3" json:"points,omitempty"`
+	// "where is this cheapest": one entry per merchant, cheapest latest price first
+	Merchants []*MerchantItemPrice `protobuf:"bytes,5,rep,name=merchants,proto3" json:"merchants,omitempty"`
+	// latest price against the first, in percent
+	ChangePercent *float64 `protobuf:"fixed64,6,opt,name=change_percent,json=changePercent,proto3,oneof" json:"change_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemPriceHistory) Reset() {
+	*x = ItemPriceHistory{}
+	mi := &file_null_v1_receipt_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemPriceHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemPriceHistory) ProtoMessage() {}
+
+func (x *ItemPriceHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemPriceHistory.ProtoReflect.Descriptor instead.
+func (*ItemPriceHistory) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ItemPriceHistory) GetNormalizedName() string {
+	if x != nil {
+		return x.NormalizedName
+	}
+	return ""
+}
+
+func (x *ItemPriceHistory) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *ItemPriceHistory) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ItemPriceHistory) GetPoints() []*ItemPricePoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *ItemPriceHistory) GetMerchants() []*MerchantItemPrice {
+	if x != nil {
+		return x.Merchants
+	}
+	return nil
+}
+
+func (x *ItemPriceHistory) GetChangePercent() float64 {
+	if x != nil && x.ChangePercent != nil {
+		return *x.ChangePercent
+	}
+	return 0
+}
+
+type ItemPricePoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *date.Date             `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	UnitPrice     *money.Money           `protobuf:"bytes,2,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Merchant      string                 `protobuf:"bytes,3,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	ReceiptId     int64                  `protobuf:"varint,4,opt,name=receipt_id,json=receiptId,proto3" json:"receipt_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemPricePoint) Reset() {
+	*x = ItemPricePoint{}
+	mi := &file_null_v1_receipt_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemPricePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemPricePoint) ProtoMessage() {}
+
+func (x *ItemPricePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemPricePoint.ProtoReflect.Descriptor instead.
+func (*ItemPricePoint) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ItemPricePoint) GetDate() *date.Date {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *ItemPricePoint) GetUnitPrice() *money.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *ItemPricePoint) GetMerchant() string {
+	if x != nil {
+		return x.Merchant
+	}
+	return ""
+}
+
+func (x *ItemPricePoint) GetReceiptId() int64 {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return 0
+}
+
+type MerchantItemPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchant      string                 `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	LatestPrice   *money.Money           `protobuf:"bytes,2,opt,name=latest_price,json=latestPrice,proto3" json:"latest_price,omitempty"`
+	LatestDate    *date.Date             `protobuf:"bytes,3,opt,name=latest_date,json=latestDate,proto3" json:"latest_date,omitempty"`
+	MinPrice      *money.Money           `protobuf:"bytes,4,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	AvgPrice      *money.Money           `protobuf:"bytes,5,opt,name=avg_price,json=avgPrice,proto3" json:"avg_price,omitempty"`
+	PurchaseCount int32                  `protobuf:"varint,6,opt,name=purchase_count,json=purchaseCount,proto3" json:"purchase_count,omitempty"`
+	ChangePercent *float64               `protobuf:"fixed64,7,opt,name=change_percent,json=changePercent,proto3,oneof" json:"change_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MerchantItemPrice) Reset() {
+	*x = MerchantItemPrice{}
+	mi := &file_null_v1_receipt_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MerchantItemPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerchantItemPrice) ProtoMessage() {}
+
+func (x *MerchantItemPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerchantItemPrice.ProtoReflect.Descriptor instead.
+func (*MerchantItemPrice) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MerchantItemPrice) GetMerchant() string {
+	if x != nil {
+		return x.Merchant
+	}
+	return ""
+}
+
+func (x *MerchantItemPrice) GetLatestPrice() *money.Money {
+	if x != nil {
+		return x.LatestPrice
+	}
+	return nil
+}
+
+func (x *MerchantItemPrice) GetLatestDate() *date.Date {
+	if x != nil {
+		return x.LatestDate
+	}
+	return nil
+}
+
+func (x *MerchantItemPrice) GetMinPrice() *money.Money {
+	if x != nil {
+		return x.MinPrice
+	}
+	return nil
+}
+
+func (x *MerchantItemPrice) GetAvgPrice() *money.Money {
+	if x != nil {
+		return x.AvgPrice
+	}
+	return nil
+}
+
+func (x *MerchantItemPrice) GetPurchaseCount() int32 {
+	if x != nil {
+		return x.PurchaseCount
+	}
+	return 0
+}
+
+func (x *MerchantItemPrice) GetChangePercent() float64 {
+	if x != nil && x.ChangePercent != nil {
+		return *x.ChangePercent
+	}
+	return 0
+}
+
 var File_null_v1_receipt_proto protoreflect.FileDescriptor
 
 const file_null_v1_receipt_proto_rawDesc = "" +
 	"\n" +
-	"\x15null/v1/receipt.proto\x12\anull.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16google/type/date.proto\x1a\x17google/type/money.proto\"\xe7\x01\n" +
+	"\x15null/v1/receipt.proto\x12\anull.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16google/type/date.proto\x1a\x17google/type/money.proto\"\x9d\x02\n" +
 	"\vReceiptItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
 	"\n" +
@@ -458,14 +873,17 @@ const file_null_v1_receipt_proto_rawDesc = "" +
 	"\n" +
 	"unit_price\x18\x06 \x01(\v2\x12.google.type.MoneyR\tunitPrice\x12\x1d\n" +
 	"\n" +
-	"sort_order\x18\a \x01(\x05R\tsortOrderB\a\n" +
-	"\x05_name\"\x9c\a\n" +
+	"sort_order\x18\a \x01(\x05R\tsortOrder\x12$\n" +
+	"\vcategory_id\x18\b \x01(\x03H\x01R\n" +
+	"categoryId\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_category_id\"\xc2\b\n" +
 	"\aReceipt\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12*\n" +
-	"\x0etransaction_id\x18\x03 \x01(\x03H\x00R\rtransactionId\x88\x01\x01\x12\x1d\n" +
+	"\x0etransaction_id\x18\x03 \x01(\x03H\x00R\rtransactionId\x88\x01\x01\x12!\n" +
 	"\n" +
-	"image_path\x18\x04 \x01(\tR\timagePath\x12\x1f\n" +
+	"image_path\x18\x04 \x01(\tB\x02\x18\x01R\timagePath\x12\x1f\n" +
 	"\bmerchant\x18\x05 \x01(\tH\x01R\bmerchant\x88\x01\x01\x129\n" +
 	"\freceipt_date\x18\x06 \x01(\v2\x11.google.type.DateH\x02R\vreceiptDate\x88\x01\x01\x12\x1f\n" +
 	"\bcurrency\x18\a \x01(\tH\x03R\bcurrency\x88\x01\x01\x123\n" +
@@ -483,7 +901,13 @@ const file_null_v1_receipt_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x126\n" +
 	"\x14transaction_merchant\x18\x10 \x01(\tH\bR\x13transactionMerchant\x88\x01\x01\x12F\n" +
-	"\x12transaction_amount\x18\x11 \x01(\v2\x12.google.type.MoneyH\tR\x11transactionAmount\x88\x01\x01B\x11\n" +
+	"\x12transaction_amount\x18\x11 \x01(\v2\x12.google.type.MoneyH\tR\x11transactionAmount\x88\x01\x01\x12\x1a\n" +
+	"\battempts\x18\x12 \x01(\x05R\battempts\x12\"\n" +
+	"\n" +
+	"last_error\x18\x13 \x01(\tH\n" +
+	"R\tlastError\x88\x01\x01\x12*\n" +
+	"\x05pages\x18\x14 \x03(\v2\x14.null.v1.ReceiptPageR\x05pages\x12%\n" +
+	"\x0ereview_reasons\x18\x15 \x03(\tR\rreviewReasonsB\x11\n" +
 	"\x0f_transaction_idB\v\n" +
 	"\t_merchantB\x0f\n" +
 	"\r_receipt_dateB\v\n" +
@@ -493,7 +917,11 @@ const file_null_v1_receipt_proto_rawDesc = "" +
 	"\x06_totalB\r\n" +
 	"\v_confidenceB\x17\n" +
 	"\x15_transaction_merchantB\x15\n" +
-	"\x13_transaction_amount\"\xce\x02\n" +
+	"\x13_transaction_amountB\r\n" +
+	"\v_last_error\"I\n" +
+	"\vReceiptPage\x12\x17\n" +
+	"\apage_no\x18\x01 \x01(\x05R\x06pageNo\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"\xe4\x02\n" +
 	"\x14ReceiptLinkCandidate\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\x03R\rtransactionId\x12\x1a\n" +
 	"\bmerchant\x18\x02 \x01(\tR\bmerchant\x12*\n" +
@@ -503,13 +931,45 @@ const file_null_v1_receipt_proto_rawDesc = "" +
 	"account_id\x18\x05 \x01(\x03R\taccountId\x12!\n" +
 	"\faccount_name\x18\x06 \x01(\tR\vaccountName\x12$\n" +
 	"\x0edate_diff_days\x18\a \x01(\x05R\fdateDiffDays\x12*\n" +
-	"\x11amount_diff_cents\x18\b \x01(\x03R\x0famountDiffCents*\x9c\x01\n" +
+	"\x11amount_diff_cents\x18\b \x01(\x03R\x0famountDiffCents\x12\x14\n" +
+	"\x05score\x18\t \x01(\x01R\x05score\"\xa4\x02\n" +
+	"\x10ItemPriceHistory\x12'\n" +
+	"\x0fnormalized_name\x18\x01 \x01(\tR\x0enormalizedName\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12/\n" +
+	"\x06points\x18\x04 \x03(\v2\x17.null.v1.ItemPricePointR\x06points\x128\n" +
+	"\tmerchants\x18\x05 \x03(\v2\x1a.null.v1.MerchantItemPriceR\tmerchants\x12*\n" +
+	"\x0echange_percent\x18\x06 \x01(\x01H\x00R\rchangePercent\x88\x01\x01B\x11\n" +
+	"\x0f_change_percent\"\xa5\x01\n" +
+	"\x0eItemPricePoint\x12%\n" +
+	"\x04date\x18\x01 \x01(\v2\x11.google.type.DateR\x04date\x121\n" +
+	"\n" +
+	"unit_price\x18\x02 \x01(\v2\x12.google.type.MoneyR\tunitPrice\x12\x1a\n" +
+	"\bmerchant\x18\x03 \x01(\tR\bmerchant\x12\x1d\n" +
+	"\n" +
+	"receipt_id\x18\x04 \x01(\x03R\treceiptId\"\xe2\x02\n" +
+	"\x11MerchantItemPrice\x12\x1a\n" +
+	"\bmerchant\x18\x01 \x01(\tR\bmerchant\x125\n" +
+	"\flatest_price\x18\x02 \x01(\v2\x12.google.type.MoneyR\vlatestPrice\x122\n" +
+	"\vlatest_date\x18\x03 \x01(\v2\x11.google.type.DateR\n" +
+	"latestDate\x12/\n" +
+	"\tmin_price\x18\x04 \x01(\v2\x12.google.type.MoneyR\bminPrice\x12/\n" +
+	"\tavg_price\x18\x05 \x01(\v2\x12.google.type.MoneyR\bavgPrice\x12%\n" +
+	"\x0epurchase_count\x18\x06 \x01(\x05R\rpurchaseCount\x12*\n" +
+	"\x0echange_percent\x18\a \x01(\x01H\x00R\rchangePercent\x88\x01\x01B\x11\n" +
+	"\x0f_change_percent*\xbd\x01\n" +
 	"\rReceiptStatus\x12\x1e\n" +
 	"\x1aRECEIPT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16RECEIPT_STATUS_PENDING\x10\x01\x12\x19\n" +
 	"\x15RECEIPT_STATUS_PARSED\x10\x02\x12\x19\n" +
 	"\x15RECEIPT_STATUS_LINKED\x10\x03\x12\x19\n" +
-	"\x15RECEIPT_STATUS_FAILED\x10\x04B\x81\x01\n" +
+	"\x15RECEIPT_STATUS_FAILED\x10\x04\x12\x1f\n" +
+	"\x1bRECEIPT_STATUS_NEEDS_REVIEW\x10\x05*\x91\x01\n" +
+	"\x10ReceiptImageSize\x12\"\n" +
+	"\x1eRECEIPT_IMAGE_SIZE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18RECEIPT_IMAGE_SIZE_SMALL\x10\x01\x12\x1d\n" +
+	"\x19RECEIPT_IMAGE_SIZE_MEDIUM\x10\x02\x12\x1c\n" +
+	"\x18RECEIPT_IMAGE_SIZE_LARGE\x10\x03B\x81\x01\n" +
 	"\vcom.null.v1B\fReceiptProtoP\x01Z%null-core/internal/gen/null/v1;nullv1\xa2\x02\x03NXX\xaa\x02\aNull.V1\xca\x02\bNull_\\V1\xe2\x02\x14Null_\\V1\\GPBMetadata\xea\x02\bNull::V1b\x06proto3"
 
 var (
@@ -524,35 +984,49 @@ func file_null_v1_receipt_proto_rawDescGZIP() []byte {
 	return file_null_v1_receipt_proto_rawDescData
 }
 
-var file_null_v1_receipt_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_null_v1_receipt_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_null_v1_receipt_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_null_v1_receipt_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_null_v1_receipt_proto_goTypes = []any{
 	(ReceiptStatus)(0),            // 0: null.v1.ReceiptStatus
-	(*ReceiptItem)(nil),           // 1: null.v1.ReceiptItem
-	(*Receipt)(nil),               // 2: null.v1.Receipt
-	(*ReceiptLinkCandidate)(nil),  // 3: null.v1.ReceiptLinkCandidate
-	(*money.Money)(nil),           // 4: google.type.Money
-	(*date.Date)(nil),             // 5: google.type.Date
-	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(ReceiptImageSize)(0),         // 1: null.v1.ReceiptImageSize
+	(*ReceiptItem)(nil),           // 2: null.v1.ReceiptItem
+	(*Receipt)(nil),               // 3: null.v1.Receipt
+	(*ReceiptPage)(nil),           // 4: null.v1.ReceiptPage
+	(*ReceiptLinkCandidate)(nil),  // 5: null.v1.ReceiptLinkCandidate
+	(*ItemPriceHistory)(nil),      // 6: null.v1.ItemPriceHistory
+	(*ItemPricePoint)(nil),        // 7: null.v1.ItemPricePoint
+	(*MerchantItemPrice)(nil),     // 8: null.v1.MerchantItemPrice
+	(*money.Money)(nil),           // 9: google.type.Money
+	(*date.Date)(nil),             // 10: google.type.Date
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
 }
 var file_null_v1_receipt_proto_depIdxs = []int32{
-	4,  // 0: null.v1.ReceiptItem.unit_price:type_name -> google.type.Money
-	5,  // 1: null.v1.Receipt.receipt_date:type_name -> google.type.Date
-	4,  // 2: null.v1.Receipt.subtotal:type_name -> google.type.Money
-	4,  // 3: null.v1.Receipt.tax:type_name -> google.type.Money
-	4,  // 4: null.v1.Receipt.total:type_name -> google.type.Money
+	9,  // 0: null.v1.ReceiptItem.unit_price:type_name -> google.type.Money
+	10, // 1: null.v1.Receipt.receipt_date:type_name -> google.type.Date
+	9,  // 2: null.v1.Receipt.subtotal:type_name -> google.type.Money
+	9,  // 3: null.v1.Receipt.tax:type_name -> google.type.Money
+	9,  // 4: null.v1.Receipt.total:type_name -> google.type.Money
 	0,  // 5: null.v1.Receipt.status:type_name -> null.v1.ReceiptStatus
-	1,  // 6: null.v1.Receipt.items:type_name -> null.v1.ReceiptItem
-	6,  // 7: null.v1.Receipt.created_at:type_name -> google.protobuf.Timestamp
-	6,  // 8: null.v1.Receipt.updated_at:type_name -> google.protobuf.Timestamp
-	4,  // 9: null.v1.Receipt.transaction_amount:type_name -> google.type.Money
-	4,  // 10: null.v1.ReceiptLinkCandidate.amount:type_name -> google.type.Money
-	6,  // 11: null.v1.ReceiptLinkCandidate.tx_date:type_name -> google.protobuf.Timestamp
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	2,  // 6: null.v1.Receipt.items:type_name -> null.v1.ReceiptItem
+	11, // 7: null.v1.Receipt.created_at:type_name -> google.protobuf.Timestamp
+	11, // 8: null.v1.Receipt.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 9: null.v1.Receipt.transaction_amount:type_name -> google.type.Money
+	4,  // 10: null.v1.Receipt.pages:type_name -> null.v1.ReceiptPage
+	9,  // 11: null.v1.ReceiptLinkCandidate.amount:type_name -> google.type.Money
+	11, // 12: null.v1.ReceiptLinkCandidate.tx_date:type_name -> google.protobuf.Timestamp
+	7,  // 13: null.v1.ItemPriceHistory.points:type_name -> null.v1.ItemPricePoint
+	8,  // 14: null.v1.ItemPriceHistory.merchants:type_name -> null.v1.MerchantItemPrice
+	10, // 15: null.v1.ItemPricePoint.date:type_name -> google.type.Date
+	9,  // 16: null.v1.ItemPricePoint.unit_price:type_name -> google.type.Money
+	9,  // 17: null.v1.MerchantItemPrice.latest_price:type_name -> google.type.Money
+	10, // 18: null.v1.MerchantItemPrice.latest_date:type_name -> google.type.Date
+	9,  // 19: null.v1.MerchantItemPrice.min_price:type_name -> google.type.Money
+	9,  // 20: null.v1.MerchantItemPrice.avg_price:type_name -> google.type.Money
+	21, // [21:21] is the sub-list for method output_type
+	21, // [21:21] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_null_v1_receipt_proto_init() }
@@ -562,13 +1036,15 @@ func file_null_v1_receipt_proto_init() {
 	}
 	file_null_v1_receipt_proto_msgTypes[0].OneofWrappers = []any{}
 	file_null_v1_receipt_proto_msgTypes[1].OneofWrappers = []any{}
+	file_null_v1_receipt_proto_msgTypes[4].OneofWrappers = []any{}
+	file_null_v1_receipt_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_receipt_proto_rawDesc), len(file_null_v1_receipt_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
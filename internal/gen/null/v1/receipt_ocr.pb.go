@@ -77,9 +77,14 @@ func (OCRErrorCode) EnumDescriptor() ([]byte, []int) {
 }
 
 type ParseReceiptRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ImageData     []byte                 `protobuf:"bytes,1,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
-	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// first page; kept for parsers that only read a single image
+	ImageData   []byte `protobuf:"bytes,1,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// every page in order, including the first, when there is more than one
+	Pages []*ParseReceiptPage `protobuf:"bytes,3,rep,name=pages,proto3" json:"pages,omitempty"`
+	// embedded text of text-based PDFs; parse this instead of running OCR
+	Text          *string `protobuf:"bytes,4,opt,name=text,proto3,oneof" json:"text,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -128,6 +133,72 @@ func (x *ParseReceiptRequest) GetContentType() string {
 	return ""
 }
 
+func (x *ParseReceiptRequest) GetPages() []*ParseReceiptPage {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+func (x *ParseReceiptRequest) GetText() string {
+	if x != nil && x.Text != nil {
+		return *x.Text
+	}
+	return ""
+}
+
+type ParseReceiptPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseReceiptPage) Reset() {
+	*x = ParseReceiptPage{}
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseReceiptPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseReceiptPage) ProtoMessage() {}
+
+func (x *ParseReceiptPage) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseReceiptPage.ProtoReflect.Descriptor instead.
+func (*ParseReceiptPage) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ParseReceiptPage) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ParseReceiptPage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
 type ParseReceiptResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -139,7 +210,7 @@ type ParseReceiptResponse struct {
 
 func (x *ParseReceiptResponse) Reset() {
 	*x = ParseReceiptResponse{}
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[1]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -151,7 +222,7 @@ func (x *ParseReceiptResponse) String() string {
 func (*ParseReceiptResponse) ProtoMessage() {}
 
 func (x *ParseReceiptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[1]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -164,7 +235,7 @@ func (x *ParseReceiptResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ParseReceiptResponse.ProtoReflect.Descriptor instead.
 func (*ParseReceiptResponse) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{1}
+	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ParseReceiptResponse) GetSuccess() bool {
@@ -204,7 +275,7 @@ type ParsedReceipt struct {
 
 func (x *ParsedReceipt) Reset() {
 	*x = ParsedReceipt{}
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[2]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -216,7 +287,7 @@ func (x *ParsedReceipt) String() string {
 func (*ParsedReceipt) ProtoMessage() {}
 
 func (x *ParsedReceipt) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[2]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +300,7 @@ func (x *ParsedReceipt) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ParsedReceipt.ProtoReflect.Descriptor instead.
 func (*ParsedReceipt) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{2}
+	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ParsedReceipt) GetMerchant() string {
@@ -300,7 +371,7 @@ type ParsedItem struct {
 
 func (x *ParsedItem) Reset() {
 	*x = ParsedItem{}
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[3]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -312,7 +383,7 @@ func (x *ParsedItem) String() string {
 func (*ParsedItem) ProtoMessage() {}
 
 func (x *ParsedItem) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[3]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -325,7 +396,7 @@ func (x *ParsedItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ParsedItem.ProtoReflect.Descriptor instead.
 func (*ParsedItem) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{3}
+	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ParsedItem) GetRaw() string {
@@ -366,7 +437,7 @@ type OCRError struct {
 
 func (x *OCRError) Reset() {
 	*x = OCRError{}
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[4]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -378,7 +449,7 @@ func (x *OCRError) String() string {
 func (*OCRError) ProtoMessage() {}
 
 func (x *OCRError) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[4]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +462,7 @@ func (x *OCRError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OCRError.ProtoReflect.Descriptor instead.
 func (*OCRError) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{4}
+	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *OCRError) GetCode() OCRErrorCode {
@@ -416,7 +487,7 @@ type HealthRequest struct {
 
 func (x *HealthRequest) Reset() {
 	*x = HealthRequest{}
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[5]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -428,7 +499,7 @@ func (x *HealthRequest) String() string {
 func (*HealthRequest) ProtoMessage() {}
 
 func (x *HealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[5]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,7 +512,7 @@ func (x *HealthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
 func (*HealthRequest) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{5}
+	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{6}
 }
 
 type HealthResponse struct {
@@ -455,7 +526,7 @@ type HealthResponse struct {
 
 func (x *HealthResponse) Reset() {
 	*x = HealthResponse{}
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[6]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -467,7 +538,7 @@ func (x *HealthResponse) String() string {
 func (*HealthResponse) ProtoMessage() {}
 
 func (x *HealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_ocr_proto_msgTypes[6]
+	mi := &file_null_v1_receipt_ocr_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -480,7 +551,7 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
 func (*HealthResponse) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{6}
+	return file_null_v1_receipt_ocr_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *HealthResponse) GetStatus() string {
@@ -508,10 +579,16 @@ var File_null_v1_receipt_ocr_proto protoreflect.FileDescriptor
 
 const file_null_v1_receipt_ocr_proto_rawDesc = "" +
 	"\n" +
-	"\x19null/v1/receipt_ocr.proto\x12\anull.v1\"W\n" +
+	"\x19null/v1/receipt_ocr.proto\x12\anull.v1\"\xaa\x01\n" +
 	"\x13ParseReceiptRequest\x12\x1d\n" +
 	"\n" +
 	"image_data\x18\x01 \x01(\fR\timageData\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12/\n" +
+	"\x05pages\x18\x03 \x03(\v2\x19.null.v1.ParseReceiptPageR\x05pages\x12\x17\n" +
+	"\x04text\x18\x04 \x01(\tH\x00R\x04text\x88\x01\x01B\a\n" +
+	"\x05_text\"I\n" +
+	"\x10ParseReceiptPage\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12!\n" +
 	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"\xa2\x01\n" +
 	"\x14ParseReceiptResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12/\n" +
@@ -576,31 +653,33 @@ func file_null_v1_receipt_ocr_proto_rawDescGZIP() []byte {
 }
 
 var file_null_v1_receipt_ocr_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_null_v1_receipt_ocr_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_null_v1_receipt_ocr_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_null_v1_receipt_ocr_proto_goTypes = []any{
 	(OCRErrorCode)(0),            // 0: null.v1.OCRErrorCode
 	(*ParseReceiptRequest)(nil),  // 1: null.v1.ParseReceiptRequest
-	(*ParseReceiptResponse)(nil), // 2: null.v1.ParseReceiptResponse
-	(*ParsedReceipt)(nil),        // 3: null.v1.ParsedReceipt
-	(*ParsedItem)(nil),           // 4: null.v1.ParsedItem
-	(*OCRError)(nil),             // 5: null.v1.OCRError
-	(*HealthRequest)(nil),        // 6: null.v1.HealthRequest
-	(*HealthResponse)(nil),       // 7: null.v1.HealthResponse
+	(*ParseReceiptPage)(nil),     // 2: null.v1.ParseReceiptPage
+	(*ParseReceiptResponse)(nil), // 3: null.v1.ParseReceiptResponse
+	(*ParsedReceipt)(nil),        // 4: null.v1.ParsedReceipt
+	(*ParsedItem)(nil),           // 5: null.v1.ParsedItem
+	(*OCRError)(nil),             // 6: null.v1.OCRError
+	(*HealthRequest)(nil),        // 7: null.v1.HealthRequest
+	(*HealthResponse)(nil),       // 8: null.v1.HealthResponse
 }
 var file_null_v1_receipt_ocr_proto_depIdxs = []int32{
-	3, // 0: null.v1.ParseReceiptResponse.data:type_name -> null.v1.ParsedReceipt
-	5, // 1: null.v1.ParseReceiptResponse.error:type_name -> null.v1.OCRError
-	4, // 2: null.v1.ParsedReceipt.items:type_name -> null.v1.ParsedItem
-	0, // 3: null.v1.OCRError.code:type_name -> null.v1.OCRErrorCode
-	1, // 4: null.v1.ReceiptOCRService.ParseReceipt:input_type -> null.v1.ParseReceiptRequest
-	6, // 5: null.v1.ReceiptOCRService.Health:input_type -> null.v1.HealthRequest
-	2, // 6: null.v1.ReceiptOCRService.ParseReceipt:output_type -> null.v1.ParseReceiptResponse
-	7, // 7: null.v1.ReceiptOCRService.Health:output_type -> null.v1.HealthResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	2, // 0: null.v1.ParseReceiptRequest.pages:type_name -> null.v1.ParseReceiptPage
+	4, // 1: null.v1.ParseReceiptResponse.data:type_name -> null.v1.ParsedReceipt
+	6, // 2: null.v1.ParseReceiptResponse.error:type_name -> null.v1.OCRError
+	5, // 3: null.v1.ParsedReceipt.items:type_name -> null.v1.ParsedItem
+	0, // 4: null.v1.OCRError.code:type_name -> null.v1.OCRErrorCode
+	1, // 5: null.v1.ReceiptOCRService.ParseReceipt:input_type -> null.v1.ParseReceiptRequest
+	7, // 6: null.v1.ReceiptOCRService.Health:input_type -> null.v1.HealthRequest
+	3, // 7: null.v1.ReceiptOCRService.ParseReceipt:output_type -> null.v1.ParseReceiptResponse
+	8, // 8: null.v1.ReceiptOCRService.Health:output_type -> null.v1.HealthResponse
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_null_v1_receipt_ocr_proto_init() }
@@ -608,16 +687,17 @@ func file_null_v1_receipt_ocr_proto_init() {
 	if File_null_v1_receipt_ocr_proto != nil {
 		return
 	}
-	file_null_v1_receipt_ocr_proto_msgTypes[1].OneofWrappers = []any{}
+	file_null_v1_receipt_ocr_proto_msgTypes[0].OneofWrappers = []any{}
 	file_null_v1_receipt_ocr_proto_msgTypes[2].OneofWrappers = []any{}
 	file_null_v1_receipt_ocr_proto_msgTypes[3].OneofWrappers = []any{}
+	file_null_v1_receipt_ocr_proto_msgTypes[4].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_receipt_ocr_proto_rawDesc), len(file_null_v1_receipt_ocr_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -9,8 +9,10 @@ package nullv1
 import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	date "google.golang.org/genproto/googleapis/type/date"
+	money "google.golang.org/genproto/googleapis/type/money"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,10 +26,13 @@ const (
 )
 
 type UploadReceiptRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	ImageData     []byte                 `protobuf:"bytes,2,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
-	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// single-file upload; use pages for several images
+	ImageData   []byte `protobuf:"bytes,2,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// stored as one receipt, after image_data if both are set
+	Pages         []*ReceiptUploadPage `protobuf:"bytes,4,rep,name=pages,proto3" json:"pages,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,6 +88,65 @@ func (x *UploadReceiptRequest) GetContentType() string {
 	return ""
 }
 
+func (x *UploadReceiptRequest) GetPages() []*ReceiptUploadPage {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+type ReceiptUploadPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiptUploadPage) Reset() {
+	*x = ReceiptUploadPage{}
+	mi := &file_null_v1_receipt_services_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiptUploadPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptUploadPage) ProtoMessage() {}
+
+func (x *ReceiptUploadPage) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_services_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptUploadPage.ProtoReflect.Descriptor instead.
+func (*ReceiptUploadPage) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReceiptUploadPage) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ReceiptUploadPage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
 type UploadReceiptResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
@@ -92,7 +156,7 @@ type UploadReceiptResponse struct {
 
 func (x *UploadReceiptResponse) Reset() {
 	*x = UploadReceiptResponse{}
-	mi := &file_null_v1_receipt_services_proto_msgTypes[1]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -104,7 +168,7 @@ func (x *UploadReceiptResponse) String() string {
 func (*UploadReceiptResponse) ProtoMessage() {}
 
 func (x *UploadReceiptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_services_proto_msgTypes[1]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -117,7 +181,7 @@ func (x *UploadReceiptResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadReceiptResponse.ProtoReflect.Descriptor instead.
 func (*UploadReceiptResponse) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{1}
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *UploadReceiptResponse) GetReceipt() *Receipt {
@@ -128,21 +192,22 @@ func (x *UploadReceiptResponse) GetReceipt() *Receipt {
 }
 
 type ListReceiptsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
-	Offset        *int32                 `protobuf:"varint,3,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
-	Status        *ReceiptStatus         `protobuf:"varint,4,opt,name=status,proto3,enum=null.v1.ReceiptStatus,oneof" json:"status,omitempty"`
-	UnlinkedOnly  *bool                  `protobuf:"varint,5,opt,name=unlinked_only,json=unlinkedOnly,proto3,oneof" json:"unlinked_only,omitempty"`
-	StartDate     *date.Date             `protobuf:"bytes,6,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
-	EndDate       *date.Date             `protobuf:"bytes,7,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit  *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset *int32                 `protobuf:"varint,3,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	// RECEIPT_STATUS_NEEDS_REVIEW lists the review queue
+	Status        *ReceiptStatus `protobuf:"varint,4,opt,name=status,proto3,enum=null.v1.ReceiptStatus,oneof" json:"status,omitempty"`
+	UnlinkedOnly  *bool          `protobuf:"varint,5,opt,name=unlinked_only,json=unlinkedOnly,proto3,oneof" json:"unlinked_only,omitempty"`
+	StartDate     *date.Date     `protobuf:"bytes,6,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	EndDate       *date.Date     `protobuf:"bytes,7,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListReceiptsRequest) Reset() {
 	*x = ListReceiptsRequest{}
-	mi := &file_null_v1_receipt_services_proto_msgTypes[2]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -154,7 +219,7 @@ func (x *ListReceiptsRequest) String() string {
 func (*ListReceiptsRequest) ProtoMessage() {}
 
 func (x *ListReceiptsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_services_proto_msgTypes[2]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -167,7 +232,7 @@ func (x *ListReceiptsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListReceiptsRequest.ProtoReflect.Descriptor instead.
 func (*ListReceiptsRequest) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{2}
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ListReceiptsRequest) GetUserId() string {
@@ -229,7 +294,7 @@ type ListReceiptsResponse struct {
 
 func (x *ListReceiptsResponse) Reset() {
 	*x = ListReceiptsResponse{}
-	mi := &file_null_v1_receipt_services_proto_msgTypes[3]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -241,7 +306,7 @@ func (x *ListReceiptsResponse) String() string {
 func (*ListReceiptsResponse) ProtoMessage() {}
 
 func (x *ListReceiptsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_services_proto_msgTypes[3]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,7 +319,7 @@ func (x *ListReceiptsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListReceiptsResponse.ProtoReflect.Descriptor instead.
 func (*ListReceiptsResponse) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{3}
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ListReceiptsResponse) GetReceipts() []*Receipt {
@@ -281,7 +346,7 @@ type GetReceiptRequest struct {
 
 func (x *GetReceiptRequest) Reset() {
 	*x = GetReceiptRequest{}
-	mi := &file_null_v1_receipt_services_proto_msgTypes[4]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -293,7 +358,7 @@ func (x *GetReceiptRequest) String() string {
 func (*GetReceiptRequest) ProtoMessage() {}
 
 func (x *GetReceiptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_services_proto_msgTypes[4]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -306,7 +371,7 @@ func (x *GetReceiptRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetReceiptRequest.ProtoReflect.Descriptor instead.
 func (*GetReceiptRequest) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{4}
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetReceiptRequest) GetUserId() string {
@@ -333,7 +398,7 @@ type GetReceiptResponse struct {
 
 func (x *GetReceiptResponse) Reset() {
 	*x = GetReceiptResponse{}
-	mi := &file_null_v1_receipt_services_proto_msgTypes[5]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -345,7 +410,7 @@ func (x *GetReceiptResponse) String() string {
 func (*GetReceiptResponse) ProtoMessage() {}
 
 func (x *GetReceiptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_services_proto_msgTypes[5]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,7 +423,7 @@ func (x *GetReceiptResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetReceiptResponse.ProtoReflect.Descriptor instead.
 func (*GetReceiptResponse) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{5}
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetReceiptResponse) GetReceipt() *Receipt {
@@ -381,13 +446,20 @@ type UpdateReceiptRequest struct {
 	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 	TransactionId *int64                 `protobuf:"varint,3,opt,name=transaction_id,json=transactionId,proto3,oneof" json:"transaction_id,omitempty"`
 	Items         []*ReceiptItemInput    `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	// header corrections; unset fields are left alone
+	Merchant      *string      `protobuf:"bytes,5,opt,name=merchant,proto3,oneof" json:"merchant,omitempty"`
+	ReceiptDate   *date.Date   `protobuf:"bytes,6,opt,name=receipt_date,json=receiptDate,proto3,oneof" json:"receipt_date,omitempty"`
+	Currency      *string      `protobuf:"bytes,7,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	Subtotal      *money.Money `protobuf:"bytes,8,opt,name=subtotal,proto3,oneof" json:"subtotal,omitempty"`
+	Tax           *money.Money `protobuf:"bytes,9,opt,name=tax,proto3,oneof" json:"tax,omitempty"`
+	Total         *money.Money `protobuf:"bytes,10,opt,name=total,proto3,oneof" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateReceiptRequest) Reset() {
 	*x = UpdateReceiptRequest{}
-	mi := &file_null_v1_receipt_services_proto_msgTypes[6]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -399,7 +471,7 @@ func (x *UpdateReceiptRequest) String() string {
 func (*UpdateReceiptRequest) ProtoMessage() {}
 
 func (x *UpdateReceiptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_services_proto_msgTypes[6]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,7 +484,7 @@ func (x *UpdateReceiptRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateReceiptRequest.ProtoReflect.Descriptor instead.
 func (*UpdateReceiptRequest) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{6}
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateReceiptRequest) GetUserId() string {
@@ -443,6 +515,48 @@ func (x *UpdateReceiptRequest) GetItems() []*ReceiptItemInput {
 	return nil
 }
 
+func (x *UpdateReceiptRequest) GetMerchant() string {
+	if x != nil && x.Merchant != nil {
+		return *x.Merchant
+	}
+	return ""
+}
+
+func (x *UpdateReceiptRequest) GetReceiptDate() *date.Date {
+	if x != nil {
+		return x.ReceiptDate
+	}
+	return nil
+}
+
+func (x *UpdateReceiptRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *UpdateReceiptRequest) GetSubtotal() *money.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *UpdateReceiptRequest) GetTax() *money.Money {
+	if x != nil {
+		return x.Tax
+	}
+	return nil
+}
+
+func (x *UpdateReceiptRequest) GetTotal() *money.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
 type ReceiptItemInput struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -450,13 +564,14 @@ type ReceiptItemInput struct {
 	Name           *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
 	Quantity       float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	UnitPriceCents int64                  `protobuf:"varint,5,opt,name=unit_price_cents,json=unitPriceCents,proto3" json:"unit_price_cents,omitempty"`
+	CategoryId     *int64                 `protobuf:"varint,6,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ReceiptItemInput) Reset() {
 	*x = ReceiptItemInput{}
-	mi := &file_null_v1_receipt_services_proto_msgTypes[7]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -468,7 +583,7 @@ func (x *ReceiptItemInput) String() string {
 func (*ReceiptItemInput) ProtoMessage() {}
 
 func (x *ReceiptItemInput) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_services_proto_msgTypes[7]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,7 +596,7 @@ func (x *ReceiptItemInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReceiptItemInput.ProtoReflect.Descriptor instead.
 func (*ReceiptItemInput) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{7}
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ReceiptItemInput) GetId() int64 {
@@ -519,6 +634,13 @@ func (x *ReceiptItemInput) GetUnitPriceCents() int64 {
 	return 0
 }
 
+func (x *ReceiptItemInput) GetCategoryId() int64 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
 type UpdateReceiptResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
@@ -528,7 +650,7 @@ type UpdateReceiptResponse struct {
 
 func (x *UpdateReceiptResponse) Reset() {
 	*x = UpdateReceiptResponse{}
-	mi := &file_null_v1_receipt_services_proto_msgTypes[8]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -540,7 +662,7 @@ func (x *UpdateReceiptResponse) String() string {
 func (*UpdateReceiptResponse) ProtoMessage() {}
 
 func (x *UpdateReceiptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_services_proto_msgTypes[8]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -553,7 +675,7 @@ func (x *UpdateReceiptResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateReceiptResponse.ProtoReflect.Descriptor instead.
 func (*UpdateReceiptResponse) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{8}
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateReceiptResponse) GetReceipt() *Receipt {
@@ -573,7 +695,7 @@ type DeleteReceiptRequest struct {
 
 func (x *DeleteReceiptRequest) Reset() {
 	*x = DeleteReceiptRequest{}
-	mi := &file_null_v1_receipt_services_proto_msgTypes[9]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -585,7 +707,7 @@ func (x *DeleteReceiptRequest) String() string {
 func (*DeleteReceiptRequest) ProtoMessage() {}
 
 func (x *DeleteReceiptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_services_proto_msgTypes[9]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -598,7 +720,7 @@ func (x *DeleteReceiptRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteReceiptRequest.ProtoReflect.Descriptor instead.
 func (*DeleteReceiptRequest) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{9}
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeleteReceiptRequest) GetUserId() string {
@@ -623,7 +745,7 @@ type DeleteReceiptResponse struct {
 
 func (x *DeleteReceiptResponse) Reset() {
 	*x = DeleteReceiptResponse{}
-	mi := &file_null_v1_receipt_services_proto_msgTypes[10]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -635,7 +757,7 @@ func (x *DeleteReceiptResponse) String() string {
 func (*DeleteReceiptResponse) ProtoMessage() {}
 
 func (x *DeleteReceiptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_null_v1_receipt_services_proto_msgTypes[10]
+	mi := &file_null_v1_receipt_services_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,23 +770,399 @@ func (x *DeleteReceiptResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteReceiptResponse.ProtoReflect.Descriptor instead.
 func (*DeleteReceiptResponse) Descriptor() ([]byte, []int) {
-	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{10}
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{11}
+}
+
+type RetryReceiptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryReceiptRequest) Reset() {
+	*x = RetryReceiptRequest{}
+	mi := &file_null_v1_receipt_services_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryReceiptRequest) ProtoMessage() {}
+
+func (x *RetryReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_services_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryReceiptRequest.ProtoReflect.Descriptor instead.
+func (*RetryReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RetryReceiptRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RetryReceiptRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RetryReceiptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryReceiptResponse) Reset() {
+	*x = RetryReceiptResponse{}
+	mi := &file_null_v1_receipt_services_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryReceiptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryReceiptResponse) ProtoMessage() {}
+
+func (x *RetryReceiptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_services_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryReceiptResponse.ProtoReflect.Descriptor instead.
+func (*RetryReceiptResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RetryReceiptResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+type GetReceiptImageRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id     int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// return a short-lived direct url instead of the bytes when the blob store
+	// supports it
+	PreferUrl *bool             `protobuf:"varint,3,opt,name=prefer_url,json=preferUrl,proto3,oneof" json:"prefer_url,omitempty"`
+	Size      *ReceiptImageSize `protobuf:"varint,4,opt,name=size,proto3,enum=null.v1.ReceiptImageSize,oneof" json:"size,omitempty"`
+	// 1-based; defaults to the first page
+	Page          *int32 `protobuf:"varint,5,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptImageRequest) Reset() {
+	*x = GetReceiptImageRequest{}
+	mi := &file_null_v1_receipt_services_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptImageRequest) ProtoMessage() {}
+
+func (x *GetReceiptImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_services_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptImageRequest.ProtoReflect.Descriptor instead.
+func (*GetReceiptImageRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetReceiptImageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetReceiptImageRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetReceiptImageRequest) GetPreferUrl() bool {
+	if x != nil && x.PreferUrl != nil {
+		return *x.PreferUrl
+	}
+	return false
+}
+
+func (x *GetReceiptImageRequest) GetSize() ReceiptImageSize {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return ReceiptImageSize_RECEIPT_IMAGE_SIZE_UNSPECIFIED
+}
+
+func (x *GetReceiptImageRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+type GetReceiptImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImageData     []byte                 `protobuf:"bytes,1,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Url           *string                `protobuf:"bytes,3,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	UrlExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=url_expires_at,json=urlExpiresAt,proto3,oneof" json:"url_expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptImageResponse) Reset() {
+	*x = GetReceiptImageResponse{}
+	mi := &file_null_v1_receipt_services_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptImageResponse) ProtoMessage() {}
+
+func (x *GetReceiptImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_services_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptImageResponse.ProtoReflect.Descriptor instead.
+func (*GetReceiptImageResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetReceiptImageResponse) GetImageData() []byte {
+	if x != nil {
+		return x.ImageData
+	}
+	return nil
+}
+
+func (x *GetReceiptImageResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetReceiptImageResponse) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *GetReceiptImageResponse) GetUrlExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UrlExpiresAt
+	}
+	return nil
+}
+
+type GetPriceHistoryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// words the normalized item name must contain; empty lists every item
+	Query     string     `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	StartDate *date.Date `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	EndDate   *date.Date `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3,oneof" json:"end_date,omitempty"`
+	// most purchased items first
+	Limit         *int32 `protobuf:"varint,5,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_null_v1_receipt_services_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_services_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetPriceHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetStartDate() *date.Date {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryRequest) GetEndDate() *date.Date {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type GetPriceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ItemPriceHistory    `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	mi := &file_null_v1_receipt_services_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_receipt_services_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_receipt_services_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetPriceHistoryResponse) GetItems() []*ItemPriceHistory {
+	if x != nil {
+		return x.Items
+	}
+	return nil
 }
 
 var File_null_v1_receipt_services_proto protoreflect.FileDescriptor
 
 const file_null_v1_receipt_services_proto_rawDesc = "" +
 	"\n" +
-	"\x1enull/v1/receipt_services.proto\x12\anull.v1\x1a\x15null/v1/receipt.proto\x1a\x1bbuf/validate/validate.proto\x1a\x16google/type/date.proto\"\xbf\x01\n" +
+	"\x1enull/v1/receipt_services.proto\x12\anull.v1\x1a\x15null/v1/receipt.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16google/type/date.proto\x1a\x17google/type/money.proto\"\x98\x02\n" +
 	"\x14UploadReceiptRequest\x12!\n" +
-	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12+\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12)\n" +
 	"\n" +
-	"image_data\x18\x02 \x01(\fB\f\xbaH\tz\a\x10\x01\x18\x80\x80\x80\n" +
-	"R\timageData\x12W\n" +
-	"\fcontent_type\x18\x03 \x01(\tB4\xbaH1r/R\n" +
+	"image_data\x18\x02 \x01(\fB\n" +
+	"\xbaH\az\x05\x18\x80\x80\x80\n" +
+	"R\timageData\x12v\n" +
+	"\fcontent_type\x18\x03 \x01(\tBS\xbaHPrNR\x00R\n" +
+	"image/jpegR\timage/pngR\n" +
+	"image/webpR\n" +
+	"image/heicR\n" +
+	"image/heifR\x0fapplication/pdfR\vcontentType\x12:\n" +
+	"\x05pages\x18\x04 \x03(\v2\x1a.null.v1.ReceiptUploadPageB\b\xbaH\x05\x92\x01\x02\x10\x14R\x05pages\"\xab\x01\n" +
+	"\x11ReceiptUploadPage\x12 \n" +
+	"\x04data\x18\x01 \x01(\fB\f\xbaH\tz\a\x10\x01\x18\x80\x80\x80\n" +
+	"R\x04data\x12t\n" +
+	"\fcontent_type\x18\x02 \x01(\tBQ\xbaHNrLR\n" +
 	"image/jpegR\timage/pngR\n" +
 	"image/webpR\n" +
-	"image/heicR\vcontentType\"C\n" +
+	"image/heicR\n" +
+	"image/heifR\x0fapplication/pdfR\vcontentType\"C\n" +
 	"\x15UploadReceiptResponse\x12*\n" +
 	"\areceipt\x18\x01 \x01(\v2\x10.null.v1.ReceiptR\areceipt\"\x9b\x03\n" +
 	"\x13ListReceiptsRequest\x12!\n" +
@@ -691,33 +1189,87 @@ const file_null_v1_receipt_services_proto_rawDesc = "" +
 	"\x02id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\"\x88\x01\n" +
 	"\x12GetReceiptResponse\x12*\n" +
 	"\areceipt\x18\x01 \x01(\v2\x10.null.v1.ReceiptR\areceipt\x12F\n" +
-	"\x0flink_candidates\x18\x02 \x03(\v2\x1d.null.v1.ReceiptLinkCandidateR\x0elinkCandidates\"\xc2\x01\n" +
+	"\x0flink_candidates\x18\x02 \x03(\v2\x1d.null.v1.ReceiptLinkCandidateR\x0elinkCandidates\"\xa2\x04\n" +
 	"\x14UpdateReceiptRequest\x12!\n" +
 	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12\x17\n" +
 	"\x02id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\x12*\n" +
 	"\x0etransaction_id\x18\x03 \x01(\x03H\x00R\rtransactionId\x88\x01\x01\x12/\n" +
-	"\x05items\x18\x04 \x03(\v2\x19.null.v1.ReceiptItemInputR\x05itemsB\x11\n" +
-	"\x0f_transaction_id\"\xa5\x01\n" +
+	"\x05items\x18\x04 \x03(\v2\x19.null.v1.ReceiptItemInputR\x05items\x12\x1f\n" +
+	"\bmerchant\x18\x05 \x01(\tH\x01R\bmerchant\x88\x01\x01\x129\n" +
+	"\freceipt_date\x18\x06 \x01(\v2\x11.google.type.DateH\x02R\vreceiptDate\x88\x01\x01\x12)\n" +
+	"\bcurrency\x18\a \x01(\tB\b\xbaH\x05r\x03\x98\x01\x03H\x03R\bcurrency\x88\x01\x01\x123\n" +
+	"\bsubtotal\x18\b \x01(\v2\x12.google.type.MoneyH\x04R\bsubtotal\x88\x01\x01\x12)\n" +
+	"\x03tax\x18\t \x01(\v2\x12.google.type.MoneyH\x05R\x03tax\x88\x01\x01\x12-\n" +
+	"\x05total\x18\n" +
+	" \x01(\v2\x12.google.type.MoneyH\x06R\x05total\x88\x01\x01B\x11\n" +
+	"\x0f_transaction_idB\v\n" +
+	"\t_merchantB\x0f\n" +
+	"\r_receipt_dateB\v\n" +
+	"\t_currencyB\v\n" +
+	"\t_subtotalB\x06\n" +
+	"\x04_taxB\b\n" +
+	"\x06_total\"\xdb\x01\n" +
 	"\x10ReceiptItemInput\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
 	"\braw_name\x18\x02 \x01(\tR\arawName\x12\x17\n" +
 	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1a\n" +
 	"\bquantity\x18\x04 \x01(\x01R\bquantity\x12(\n" +
-	"\x10unit_price_cents\x18\x05 \x01(\x03R\x0eunitPriceCentsB\a\n" +
-	"\x05_name\"C\n" +
+	"\x10unit_price_cents\x18\x05 \x01(\x03R\x0eunitPriceCents\x12$\n" +
+	"\vcategory_id\x18\x06 \x01(\x03H\x01R\n" +
+	"categoryId\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_category_id\"C\n" +
 	"\x15UpdateReceiptResponse\x12*\n" +
 	"\areceipt\x18\x01 \x01(\v2\x10.null.v1.ReceiptR\areceipt\"R\n" +
 	"\x14DeleteReceiptRequest\x12!\n" +
 	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12\x17\n" +
 	"\x02id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\"\x17\n" +
-	"\x15DeleteReceiptResponse2\x94\x03\n" +
+	"\x15DeleteReceiptResponse\"Q\n" +
+	"\x13RetryReceiptRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12\x17\n" +
+	"\x02id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\"B\n" +
+	"\x14RetryReceiptResponse\x12*\n" +
+	"\areceipt\x18\x01 \x01(\v2\x10.null.v1.ReceiptR\areceipt\"\xef\x01\n" +
+	"\x16GetReceiptImageRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12\x17\n" +
+	"\x02id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\x12\"\n" +
+	"\n" +
+	"prefer_url\x18\x03 \x01(\bH\x00R\tpreferUrl\x88\x01\x01\x122\n" +
+	"\x04size\x18\x04 \x01(\x0e2\x19.null.v1.ReceiptImageSizeH\x01R\x04size\x88\x01\x01\x12 \n" +
+	"\x04page\x18\x05 \x01(\x05B\a\xbaH\x04\x1a\x02(\x01H\x02R\x04page\x88\x01\x01B\r\n" +
+	"\v_prefer_urlB\a\n" +
+	"\x05_sizeB\a\n" +
+	"\x05_page\"\xd4\x01\n" +
+	"\x17GetReceiptImageResponse\x12\x1d\n" +
+	"\n" +
+	"image_data\x18\x01 \x01(\fR\timageData\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x15\n" +
+	"\x03url\x18\x03 \x01(\tH\x00R\x03url\x88\x01\x01\x12E\n" +
+	"\x0eurl_expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\furlExpiresAt\x88\x01\x01B\x06\n" +
+	"\x04_urlB\x11\n" +
+	"\x0f_url_expires_at\"\x87\x02\n" +
+	"\x16GetPriceHistoryRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x125\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x11.google.type.DateH\x00R\tstartDate\x88\x01\x01\x121\n" +
+	"\bend_date\x18\x04 \x01(\v2\x11.google.type.DateH\x01R\aendDate\x88\x01\x01\x12$\n" +
+	"\x05limit\x18\x05 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01H\x02R\x05limit\x88\x01\x01B\r\n" +
+	"\v_start_dateB\v\n" +
+	"\t_end_dateB\b\n" +
+	"\x06_limit\"J\n" +
+	"\x17GetPriceHistoryResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.null.v1.ItemPriceHistoryR\x05items2\x8d\x05\n" +
 	"\x0eReceiptService\x12N\n" +
 	"\rUploadReceipt\x12\x1d.null.v1.UploadReceiptRequest\x1a\x1e.null.v1.UploadReceiptResponse\x12K\n" +
 	"\fListReceipts\x12\x1c.null.v1.ListReceiptsRequest\x1a\x1d.null.v1.ListReceiptsResponse\x12E\n" +
 	"\n" +
 	"GetReceipt\x12\x1a.null.v1.GetReceiptRequest\x1a\x1b.null.v1.GetReceiptResponse\x12N\n" +
 	"\rUpdateReceipt\x12\x1d.null.v1.UpdateReceiptRequest\x1a\x1e.null.v1.UpdateReceiptResponse\x12N\n" +
-	"\rDeleteReceipt\x12\x1d.null.v1.DeleteReceiptRequest\x1a\x1e.null.v1.DeleteReceiptResponseB\x89\x01\n" +
+	"\rDeleteReceipt\x12\x1d.null.v1.DeleteReceiptRequest\x1a\x1e.null.v1.DeleteReceiptResponse\x12K\n" +
+	"\fRetryReceipt\x12\x1c.null.v1.RetryReceiptRequest\x1a\x1d.null.v1.RetryReceiptResponse\x12T\n" +
+	"\x0fGetReceiptImage\x12\x1f.null.v1.GetReceiptImageRequest\x1a .null.v1.GetReceiptImageResponse\x12T\n" +
+	"\x0fGetPriceHistory\x12\x1f.null.v1.GetPriceHistoryRequest\x1a .null.v1.GetPriceHistoryResponseB\x89\x01\n" +
 	"\vcom.null.v1B\x14ReceiptServicesProtoP\x01Z%null-core/internal/gen/null/v1;nullv1\xa2\x02\x03NXX\xaa\x02\aNull.V1\xca\x02\bNull_\\V1\xe2\x02\x14Null_\\V1\\GPBMetadata\xea\x02\bNull::V1b\x06proto3"
 
 var (
@@ -732,49 +1284,77 @@ func file_null_v1_receipt_services_proto_rawDescGZIP() []byte {
 	return file_null_v1_receipt_services_proto_rawDescData
 }
 
-var file_null_v1_receipt_services_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_null_v1_receipt_services_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_null_v1_receipt_services_proto_goTypes = []any{
-	(*UploadReceiptRequest)(nil),  // 0: null.v1.UploadReceiptRequest
-	(*UploadReceiptResponse)(nil), // 1: null.v1.UploadReceiptResponse
-	(*ListReceiptsRequest)(nil),   // 2: null.v1.ListReceiptsRequest
-	(*ListReceiptsResponse)(nil),  // 3: null.v1.ListReceiptsResponse
-	(*GetReceiptRequest)(nil),     // 4: null.v1.GetReceiptRequest
-	(*GetReceiptResponse)(nil),    // 5: null.v1.GetReceiptResponse
-	(*UpdateReceiptRequest)(nil),  // 6: null.v1.UpdateReceiptRequest
-	(*ReceiptItemInput)(nil),      // 7: null.v1.ReceiptItemInput
-	(*UpdateReceiptResponse)(nil), // 8: null.v1.UpdateReceiptResponse
-	(*DeleteReceiptRequest)(nil),  // 9: null.v1.DeleteReceiptRequest
-	(*DeleteReceiptResponse)(nil), // 10: null.v1.DeleteReceiptResponse
-	(*Receipt)(nil),               // 11: null.v1.Receipt
-	(ReceiptStatus)(0),            // 12: null.v1.ReceiptStatus
-	(*date.Date)(nil),             // 13: google.type.Date
-	(*ReceiptLinkCandidate)(nil),  // 14: null.v1.ReceiptLinkCandidate
+	(*UploadReceiptRequest)(nil),    // 0: null.v1.UploadReceiptRequest
+	(*ReceiptUploadPage)(nil),       // 1: null.v1.ReceiptUploadPage
+	(*UploadReceiptResponse)(nil),   // 2: null.v1.UploadReceiptResponse
+	(*ListReceiptsRequest)(nil),     // 3: null.v1.ListReceiptsRequest
+	(*ListReceiptsResponse)(nil),    // 4: null.v1.ListReceiptsResponse
+	(*GetReceiptRequest)(nil),       // 5: null.v1.GetReceiptRequest
+	(*GetReceiptResponse)(nil),      // 6: null.v1.GetReceiptResponse
+	(*UpdateReceiptRequest)(nil),    // 7: null.v1.UpdateReceiptRequest
+	(*ReceiptItemInput)(nil),        // 8: null.v1.ReceiptItemInput
+	(*UpdateReceiptResponse)(nil),   // 9: null.v1.UpdateReceiptResponse
+	(*DeleteReceiptRequest)(nil),    // 10: null.v1.DeleteReceiptRequest
+	(*DeleteReceiptResponse)(nil),   // 11: null.v1.DeleteReceiptResponse
+	(*RetryReceiptRequest)(nil),     // 12: null.v1.RetryReceiptRequest
+	(*RetryReceiptResponse)(nil),    // 13: null.v1.RetryReceiptResponse
+	(*GetReceiptImageRequest)(nil),  // 14: null.v1.GetReceiptImageRequest
+	(*GetReceiptImageResponse)(nil), // 15: null.v1.GetReceiptImageResponse
+	(*GetPriceHistoryRequest)(nil),  // 16: null.v1.GetPriceHistoryRequest
+	(*GetPriceHistoryResponse)(nil), // 17: null.v1.GetPriceHistoryResponse
+	(*Receipt)(nil),                 // 18: null.v1.Receipt
+	(ReceiptStatus)(0),              // 19: null.v1.ReceiptStatus
+	(*date.Date)(nil),               // 20: google.type.Date
+	(*ReceiptLinkCandidate)(nil),    // 21: null.v1.ReceiptLinkCandidate
+	(*money.Money)(nil),             // 22: google.type.Money
+	(ReceiptImageSize)(0),           // 23: null.v1.ReceiptImageSize
+	(*timestamppb.Timestamp)(nil),   // 24: google.protobuf.Timestamp
+	(*ItemPriceHistory)(nil),        // 25: null.v1.ItemPriceHistory
 }
 var file_null_v1_receipt_services_proto_depIdxs = []int32{
-	11, // 0: null.v1.UploadReceiptResponse.receipt:type_name -> null.v1.Receipt
-	12, // 1: null.v1.ListReceiptsRequest.status:type_name -> null.v1.ReceiptStatus
-	13, // 2: null.v1.ListReceiptsRequest.start_date:type_name -> google.type.Date
-	13, // 3: null.v1.ListReceiptsRequest.end_date:type_name -> google.type.Date
-	11, // 4: null.v1.ListReceiptsResponse.receipts:type_name -> null.v1.Receipt
-	11, // 5: null.v1.GetReceiptResponse.receipt:type_name -> null.v1.Receipt
-	14, // 6: null.v1.GetReceiptResponse.link_candidates:type_name -> null.v1.ReceiptLinkCandidate
-	7,  // 7: null.v1.UpdateReceiptRequest.items:type_name -> null.v1.ReceiptItemInput
-	11, // 8: null.v1.UpdateReceiptResponse.receipt:type_name -> null.v1.Receipt
-	0,  // 9: null.v1.ReceiptService.UploadReceipt:input_type -> null.v1.UploadReceiptRequest
-	2,  // 10: null.v1.ReceiptService.ListReceipts:input_type -> null.v1.ListReceiptsRequest
-	4,  // 11: null.v1.ReceiptService.GetReceipt:input_type -> null.v1.GetReceiptRequest
-	6,  // 12: null.v1.ReceiptService.UpdateReceipt:input_type -> null.v1.UpdateReceiptRequest
-	9,  // 13: null.v1.ReceiptService.DeleteReceipt:input_type -> null.v1.DeleteReceiptRequest
-	1,  // 14: null.v1.ReceiptService.UploadReceipt:output_type -> null.v1.UploadReceiptResponse
-	3,  // 15: null.v1.ReceiptService.ListReceipts:output_type -> null.v1.ListReceiptsResponse
-	5,  // 16: null.v1.ReceiptService.GetReceipt:output_type -> null.v1.GetReceiptResponse
-	8,  // 17: null.v1.ReceiptService.UpdateReceipt:output_type -> null.v1.UpdateReceiptResponse
-	10, // 18: null.v1.ReceiptService.DeleteReceipt:output_type -> null.v1.DeleteReceiptResponse
-	14, // [14:19] is the sub-list for method output_type
-	9,  // [9:14] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	1,  // 0: null.v1.UploadReceiptRequest.pages:type_name -> null.v1.ReceiptUploadPage
+	18, // 1: null.v1.UploadReceiptResponse.receipt:type_name -> null.v1.Receipt
+	19, // 2: null.v1.ListReceiptsRequest.status:type_name -> null.v1.ReceiptStatus
+	20, // 3: null.v1.ListReceiptsRequest.start_date:type_name -> google.type.Date
+	20, // 4: null.v1.ListReceiptsRequest.end_date:type_name -> google.type.Date
+	18, // 5: null.v1.ListReceiptsResponse.receipts:type_name -> null.v1.Receipt
+	18, // 6: null.v1.GetReceiptResponse.receipt:type_name -> null.v1.Receipt
+	21, // 7: null.v1.GetReceiptResponse.link_candidates:type_name -> null.v1.ReceiptLinkCandidate
+	8,  // 8: null.v1.UpdateReceiptRequest.items:type_name -> null.v1.ReceiptItemInput
+	20, // 9: null.v1.UpdateReceiptRequest.receipt_date:type_name -> google.type.Date
+	22, // 10: null.v1.UpdateReceiptRequest.subtotal:type_name -> google.type.Money
+	22, // 11: null.v1.UpdateReceiptRequest.tax:type_name -> google.type.Money
+	22, // 12: null.v1.UpdateReceiptRequest.total:type_name -> google.type.Money
+	18, // 13: null.v1.UpdateReceiptResponse.receipt:type_name -> null.v1.Receipt
+	18, // 14: null.v1.RetryReceiptResponse.receipt:type_name -> null.v1.Receipt
+	23, // 15: null.v1.GetReceiptImageRequest.size:type_name -> null.v1.ReceiptImageSize
+	24, // 16: null.v1.GetReceiptImageResponse.url_expires_at:type_name -> google.protobuf.Timestamp
+	20, // 17: null.v1.GetPriceHistoryRequest.start_date:type_name -> google.type.Date
+	20, // 18: null.v1.GetPriceHistoryRequest.end_date:type_name -> google.type.Date
+	25, // 19: null.v1.GetPriceHistoryResponse.items:type_name -> null.v1.ItemPriceHistory
+	0,  // 20: null.v1.ReceiptService.UploadReceipt:input_type -> null.v1.UploadReceiptRequest
+	3,  // 21: null.v1.ReceiptService.ListReceipts:input_type -> null.v1.ListReceiptsRequest
+	5,  // 22: null.v1.ReceiptService.GetReceipt:input_type -> null.v1.GetReceiptRequest
+	7,  // 23: null.v1.ReceiptService.UpdateReceipt:input_type -> null.v1.UpdateReceiptRequest
+	10, // 24: null.v1.ReceiptService.DeleteReceipt:input_type -> null.v1.DeleteReceiptRequest
+	12, // 25: null.v1.ReceiptService.RetryReceipt:input_type -> null.v1.RetryReceiptRequest
+	14, // 26: null.v1.ReceiptService.GetReceiptImage:input_type -> null.v1.GetReceiptImageRequest
+	16, // 27: null.v1.ReceiptService.GetPriceHistory:input_type -> null.v1.GetPriceHistoryRequest
+	2,  // 28: null.v1.ReceiptService.UploadReceipt:output_type -> null.v1.UploadReceiptResponse
+	4,  // 29: null.v1.ReceiptService.ListReceipts:output_type -> null.v1.ListReceiptsResponse
+	6,  // 30: null.v1.ReceiptService.GetReceipt:output_type -> null.v1.GetReceiptResponse
+	9,  // 31: null.v1.ReceiptService.UpdateReceipt:output_type -> null.v1.UpdateReceiptResponse
+	11, // 32: null.v1.ReceiptService.DeleteReceipt:output_type -> null.v1.DeleteReceiptResponse
+	13, // 33: null.v1.ReceiptService.RetryReceipt:output_type -> null.v1.RetryReceiptResponse
+	15, // 34: null.v1.ReceiptService.GetReceiptImage:output_type -> null.v1.GetReceiptImageResponse
+	17, // 35: null.v1.ReceiptService.GetPriceHistory:output_type -> null.v1.GetPriceHistoryResponse
+	28, // [28:36] is the sub-list for method output_type
+	20, // [20:28] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_null_v1_receipt_services_proto_init() }
@@ -783,16 +1363,19 @@ func file_null_v1_receipt_services_proto_init() {
 		return
 	}
 	file_null_v1_receipt_proto_init()
-	file_null_v1_receipt_services_proto_msgTypes[2].OneofWrappers = []any{}
-	file_null_v1_receipt_services_proto_msgTypes[6].OneofWrappers = []any{}
+	file_null_v1_receipt_services_proto_msgTypes[3].OneofWrappers = []any{}
 	file_null_v1_receipt_services_proto_msgTypes[7].OneofWrappers = []any{}
+	file_null_v1_receipt_services_proto_msgTypes[8].OneofWrappers = []any{}
+	file_null_v1_receipt_services_proto_msgTypes[14].OneofWrappers = []any{}
+	file_null_v1_receipt_services_proto_msgTypes[15].OneofWrappers = []any{}
+	file_null_v1_receipt_services_proto_msgTypes[16].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_receipt_services_proto_rawDesc), len(file_null_v1_receipt_services_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,11 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ReceiptService_UploadReceipt_FullMethodName = "/null.v1.ReceiptService/UploadReceipt"
-	ReceiptService_ListReceipts_FullMethodName  = "/null.v1.ReceiptService/ListReceipts"
-	ReceiptService_GetReceipt_FullMethodName    = "/null.v1.ReceiptService/GetReceipt"
-	ReceiptService_UpdateReceipt_FullMethodName = "/null.v1.ReceiptService/UpdateReceipt"
-	ReceiptService_DeleteReceipt_FullMethodName = "/null.v1.ReceiptService/DeleteReceipt"
+	ReceiptService_UploadReceipt_FullMethodName   = "/null.v1.ReceiptService/UploadReceipt"
+	ReceiptService_ListReceipts_FullMethodName    = "/null.v1.ReceiptService/ListReceipts"
+	ReceiptService_GetReceipt_FullMethodName      = "/null.v1.ReceiptService/GetReceipt"
+	ReceiptService_UpdateReceipt_FullMethodName   = "/null.v1.ReceiptService/UpdateReceipt"
+	ReceiptService_DeleteReceipt_FullMethodName   = "/null.v1.ReceiptService/DeleteReceipt"
+	ReceiptService_RetryReceipt_FullMethodName    = "/null.v1.ReceiptService/RetryReceipt"
+	ReceiptService_GetReceiptImage_FullMethodName = "/null.v1.ReceiptService/GetReceiptImage"
+	ReceiptService_GetPriceHistory_FullMethodName = "/null.v1.ReceiptService/GetPriceHistory"
 )
 
 // ReceiptServiceClient is the client API for ReceiptService service.
@@ -35,6 +38,12 @@ type ReceiptServiceClient interface {
 	GetReceipt(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*GetReceiptResponse, error)
 	UpdateReceipt(ctx context.Context, in *UpdateReceiptRequest, opts ...grpc.CallOption) (*UpdateReceiptResponse, error)
 	DeleteReceipt(ctx context.Context, in *DeleteReceiptRequest, opts ...grpc.CallOption) (*DeleteReceiptResponse, error)
+	// requeue a failed receipt for OCR with a fresh attempt budget
+	RetryReceipt(ctx context.Context, in *RetryReceiptRequest, opts ...grpc.CallOption) (*RetryReceiptResponse, error)
+	// original receipt image or a thumbnail, inline or as a signed url
+	GetReceiptImage(ctx context.Context, in *GetReceiptImageRequest, opts ...grpc.CallOption) (*GetReceiptImageResponse, error)
+	// item prices over time, per merchant, grouped by normalized item name
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error)
 }
 
 type receiptServiceClient struct {
@@ -95,6 +104,36 @@ func (c *receiptServiceClient) DeleteReceipt(ctx context.Context, in *DeleteRece
 	return out, nil
 }
 
+func (c *receiptServiceClient) RetryReceipt(ctx context.Context, in *RetryReceiptRequest, opts ...grpc.CallOption) (*RetryReceiptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetryReceiptResponse)
+	err := c.cc.Invoke(ctx, ReceiptService_RetryReceipt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receiptServiceClient) GetReceiptImage(ctx context.Context, in *GetReceiptImageRequest, opts ...grpc.CallOption) (*GetReceiptImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReceiptImageResponse)
+	err := c.cc.Invoke(ctx, ReceiptService_GetReceiptImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *receiptServiceClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, ReceiptService_GetPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReceiptServiceServer is the server API for ReceiptService service.
 // All implementations must embed UnimplementedReceiptServiceServer
 // for forward compatibility.
@@ -104,6 +143,12 @@ type ReceiptServiceServer interface {
 	GetReceipt(context.Context, *GetReceiptRequest) (*GetReceiptResponse, error)
 	UpdateReceipt(context.Context, *UpdateReceiptRequest) (*UpdateReceiptResponse, error)
 	DeleteReceipt(context.Context, *DeleteReceiptRequest) (*DeleteReceiptResponse, error)
+	// requeue a failed receipt for OCR with a fresh attempt budget
+	RetryReceipt(context.Context, *RetryReceiptRequest) (*RetryReceiptResponse, error)
+	// original receipt image or a thumbnail, inline or as a signed url
+	GetReceiptImage(context.Context, *GetReceiptImageRequest) (*GetReceiptImageResponse, error)
+	// item prices over time, per merchant, grouped by normalized item name
+	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error)
 	mustEmbedUnimplementedReceiptServiceServer()
 }
 
@@ -129,6 +174,15 @@ func (UnimplementedReceiptServiceServer) UpdateReceipt(context.Context, *UpdateR
 func (UnimplementedReceiptServiceServer) DeleteReceipt(context.Context, *DeleteReceiptRequest) (*DeleteReceiptResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteReceipt not implemented")
 }
+func (UnimplementedReceiptServiceServer) RetryReceipt(context.Context, *RetryReceiptRequest) (*RetryReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryReceipt not implemented")
+}
+func (UnimplementedReceiptServiceServer) GetReceiptImage(context.Context, *GetReceiptImageRequest) (*GetReceiptImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceiptImage not implemented")
+}
+func (UnimplementedReceiptServiceServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
 func (UnimplementedReceiptServiceServer) mustEmbedUnimplementedReceiptServiceServer() {}
 func (UnimplementedReceiptServiceServer) testEmbeddedByValue()                        {}
 
@@ -240,6 +294,60 @@ func _ReceiptService_DeleteReceipt_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReceiptService_RetryReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceiptServiceServer).RetryReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceiptService_RetryReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceiptServiceServer).RetryReceipt(ctx, req.(*RetryReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReceiptService_GetReceiptImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceiptImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceiptServiceServer).GetReceiptImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceiptService_GetReceiptImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceiptServiceServer).GetReceiptImage(ctx, req.(*GetReceiptImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReceiptService_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceiptServiceServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceiptService_GetPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceiptServiceServer).GetPriceHistory(ctx, req.(*GetPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ReceiptService_ServiceDesc is the grpc.ServiceDesc for ReceiptService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +375,18 @@ var ReceiptService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteReceipt",
 			Handler:    _ReceiptService_DeleteReceipt_Handler,
 		},
+		{
+			MethodName: "RetryReceipt",
+			Handler:    _ReceiptService_RetryReceipt_Handler,
+		},
+		{
+			MethodName: "GetReceiptImage",
+			Handler:    _ReceiptService_GetReceiptImage_Handler,
+		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _ReceiptService_GetPriceHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "null/v1/receipt_services.proto",
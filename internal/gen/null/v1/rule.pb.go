@@ -164,11 +164,116 @@ func (x *Rule) GetMerchant() string {
 	return ""
 }
 
+// a rule mined from manual categorizations, not yet created
+type RuleSuggestion struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	RuleName   string                 `protobuf:"bytes,1,opt,name=rule_name,json=ruleName,proto3" json:"rule_name,omitempty"`
+	Conditions *structpb.Struct       `protobuf:"bytes,2,opt,name=conditions,proto3" json:"conditions,omitempty"`
+	CategoryId int64                  `protobuf:"varint,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// labelled transactions matched with the suggested category
+	Support int32 `protobuf:"varint,4,opt,name=support,proto3" json:"support,omitempty"`
+	// support / labelled transactions matched by the conditions
+	Precision float64 `protobuf:"fixed64,5,opt,name=precision,proto3" json:"precision,omitempty"`
+	// support / labelled transactions in the category
+	Coverage float64 `protobuf:"fixed64,6,opt,name=coverage,proto3" json:"coverage,omitempty"`
+	// all of the user's transactions the rule would match
+	TotalMatches         int32   `protobuf:"varint,7,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	SampleTransactionIds []int64 `protobuf:"varint,8,rep,packed,name=sample_transaction_ids,json=sampleTransactionIds,proto3" json:"sample_transaction_ids,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RuleSuggestion) Reset() {
+	*x = RuleSuggestion{}
+	mi := &file_null_v1_rule_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleSuggestion) ProtoMessage() {}
+
+func (x *RuleSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_rule_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleSuggestion.ProtoReflect.Descriptor instead.
+func (*RuleSuggestion) Descriptor() ([]byte, []int) {
+	return file_null_v1_rule_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RuleSuggestion) GetRuleName() string {
+	if x != nil {
+		return x.RuleName
+	}
+	return ""
+}
+
+func (x *RuleSuggestion) GetConditions() *structpb.Struct {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *RuleSuggestion) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *RuleSuggestion) GetSupport() int32 {
+	if x != nil {
+		return x.Support
+	}
+	return 0
+}
+
+func (x *RuleSuggestion) GetPrecision() float64 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+func (x *RuleSuggestion) GetCoverage() float64 {
+	if x != nil {
+		return x.Coverage
+	}
+	return 0
+}
+
+func (x *RuleSuggestion) GetTotalMatches() int32 {
+	if x != nil {
+		return x.TotalMatches
+	}
+	return 0
+}
+
+func (x *RuleSuggestion) GetSampleTransactionIds() []int64 {
+	if x != nil {
+		return x.SampleTransactionIds
+	}
+	return nil
+}
+
 var File_null_v1_rule_proto protoreflect.FileDescriptor
 
 const file_null_v1_rule_proto_rawDesc = "" +
 	"\n" +
-	"\x12null/v1/rule.proto\x12\anull.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\x8b\x05\n" +
+	"\x12null/v1/rule.proto\x12\anull.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\x96\x05\n" +
 	"\x04Rule\x12\x17\n" +
 	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
@@ -180,8 +285,8 @@ const file_null_v1_rule_proto_rawDesc = "" +
 	"conditions\x18\x05 \x01(\v2\x17.google.protobuf.StructR\n" +
 	"conditions\x12\x1b\n" +
 	"\tis_active\x18\x06 \x01(\bR\bisActive\x12%\n" +
-	"\x0epriority_order\x18\a \x01(\x05R\rpriorityOrder\x12O\n" +
-	"\vrule_source\x18\b \x01(\tB.\xbaH+r)R\fuser_createdR\fai_suggestedR\vai_approvedR\n" +
+	"\x0epriority_order\x18\a \x01(\x05R\rpriorityOrder\x12Z\n" +
+	"\vrule_source\x18\b \x01(\tB9\xbaH6r4R\fuser_createdR\tsuggestedR\fai_suggestedR\vai_approvedR\n" +
 	"ruleSource\x129\n" +
 	"\n" +
 	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
@@ -193,7 +298,19 @@ const file_null_v1_rule_proto_rawDesc = "" +
 	"\bmerchant\x18\r \x01(\tH\x02R\bmerchant\x88\x01\x01B\x0e\n" +
 	"\f_category_idB\x12\n" +
 	"\x10_last_applied_atB\v\n" +
-	"\t_merchantB~\n" +
+	"\t_merchant\"\xb6\x02\n" +
+	"\x0eRuleSuggestion\x12\x1b\n" +
+	"\trule_name\x18\x01 \x01(\tR\bruleName\x127\n" +
+	"\n" +
+	"conditions\x18\x02 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"conditions\x12\x1f\n" +
+	"\vcategory_id\x18\x03 \x01(\x03R\n" +
+	"categoryId\x12\x18\n" +
+	"\asupport\x18\x04 \x01(\x05R\asupport\x12\x1c\n" +
+	"\tprecision\x18\x05 \x01(\x01R\tprecision\x12\x1a\n" +
+	"\bcoverage\x18\x06 \x01(\x01R\bcoverage\x12#\n" +
+	"\rtotal_matches\x18\a \x01(\x05R\ftotalMatches\x124\n" +
+	"\x16sample_transaction_ids\x18\b \x03(\x03R\x14sampleTransactionIdsB~\n" +
 	"\vcom.null.v1B\tRuleProtoP\x01Z%null-core/internal/gen/null/v1;nullv1\xa2\x02\x03NXX\xaa\x02\aNull.V1\xca\x02\bNull_\\V1\xe2\x02\x14Null_\\V1\\GPBMetadata\xea\x02\bNull::V1b\x06proto3"
 
 var (
@@ -208,22 +325,24 @@ func file_null_v1_rule_proto_rawDescGZIP() []byte {
 	return file_null_v1_rule_proto_rawDescData
 }
 
-var file_null_v1_rule_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_null_v1_rule_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_null_v1_rule_proto_goTypes = []any{
 	(*Rule)(nil),                  // 0: null.v1.Rule
-	(*structpb.Struct)(nil),       // 1: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*RuleSuggestion)(nil),        // 1: null.v1.RuleSuggestion
+	(*structpb.Struct)(nil),       // 2: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
 }
 var file_null_v1_rule_proto_depIdxs = []int32{
-	1, // 0: null.v1.Rule.conditions:type_name -> google.protobuf.Struct
-	2, // 1: null.v1.Rule.created_at:type_name -> google.protobuf.Timestamp
-	2, // 2: null.v1.Rule.updated_at:type_name -> google.protobuf.Timestamp
-	2, // 3: null.v1.Rule.last_applied_at:type_name -> google.protobuf.Timestamp
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	2, // 0: null.v1.Rule.conditions:type_name -> google.protobuf.Struct
+	3, // 1: null.v1.Rule.created_at:type_name -> google.protobuf.Timestamp
+	3, // 2: null.v1.Rule.updated_at:type_name -> google.protobuf.Timestamp
+	3, // 3: null.v1.Rule.last_applied_at:type_name -> google.protobuf.Timestamp
+	2, // 4: null.v1.RuleSuggestion.conditions:type_name -> google.protobuf.Struct
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_null_v1_rule_proto_init() }
@@ -238,7 +357,7 @@ func file_null_v1_rule_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_rule_proto_rawDesc), len(file_null_v1_rule_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -216,8 +216,10 @@ type CreateRuleRequest struct {
 	Conditions      *structpb.Struct       `protobuf:"bytes,4,opt,name=conditions,proto3" json:"conditions,omitempty"`
 	ApplyToExisting *bool                  `protobuf:"varint,5,opt,name=apply_to_existing,json=applyToExisting,proto3,oneof" json:"apply_to_existing,omitempty"`
 	Merchant        *string                `protobuf:"bytes,6,opt,name=merchant,proto3,oneof" json:"merchant,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// set to "suggested" when accepting a RuleSuggestion
+	RuleSource    *string `protobuf:"bytes,7,opt,name=rule_source,json=ruleSource,proto3,oneof" json:"rule_source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateRuleRequest) Reset() {
@@ -292,6 +294,13 @@ func (x *CreateRuleRequest) GetMerchant() string {
 	return ""
 }
 
+func (x *CreateRuleRequest) GetRuleSource() string {
+	if x != nil && x.RuleSource != nil {
+		return *x.RuleSource
+	}
+	return ""
+}
+
 type CreateRuleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Rule          *Rule                  `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
@@ -748,6 +757,118 @@ func (x *ValidateRuleResponse) GetNormalizedConditions() *structpb.Struct {
 	return nil
 }
 
+type SuggestRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MinSupport    *int32                 `protobuf:"varint,2,opt,name=min_support,json=minSupport,proto3,oneof" json:"min_support,omitempty"`
+	MinPrecision  *float64               `protobuf:"fixed64,3,opt,name=min_precision,json=minPrecision,proto3,oneof" json:"min_precision,omitempty"`
+	Limit         *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestRulesRequest) Reset() {
+	*x = SuggestRulesRequest{}
+	mi := &file_null_v1_rule_services_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRulesRequest) ProtoMessage() {}
+
+func (x *SuggestRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_rule_services_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRulesRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRulesRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_rule_services_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SuggestRulesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SuggestRulesRequest) GetMinSupport() int32 {
+	if x != nil && x.MinSupport != nil {
+		return *x.MinSupport
+	}
+	return 0
+}
+
+func (x *SuggestRulesRequest) GetMinPrecision() float64 {
+	if x != nil && x.MinPrecision != nil {
+		return *x.MinPrecision
+	}
+	return 0
+}
+
+func (x *SuggestRulesRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type SuggestRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*RuleSuggestion      `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestRulesResponse) Reset() {
+	*x = SuggestRulesResponse{}
+	mi := &file_null_v1_rule_services_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRulesResponse) ProtoMessage() {}
+
+func (x *SuggestRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_rule_services_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRulesResponse.ProtoReflect.Descriptor instead.
+func (*SuggestRulesResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_rule_services_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SuggestRulesResponse) GetSuggestions() []*RuleSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
 var File_null_v1_rule_services_proto protoreflect.FileDescriptor
 
 const file_null_v1_rule_services_proto_rawDesc = "" +
@@ -761,7 +882,7 @@ const file_null_v1_rule_services_proto_rawDesc = "" +
 	"\arule_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06ruleId\x12!\n" +
 	"\auser_id\x18\x02 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\"4\n" +
 	"\x0fGetRuleResponse\x12!\n" +
-	"\x04rule\x18\x01 \x01(\v2\r.null.v1.RuleR\x04rule\"\xc3\x02\n" +
+	"\x04rule\x18\x01 \x01(\v2\r.null.v1.RuleR\x04rule\"\x99\x03\n" +
 	"\x11CreateRuleRequest\x12!\n" +
 	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12'\n" +
 	"\trule_name\x18\x02 \x01(\tB\n" +
@@ -772,10 +893,13 @@ const file_null_v1_rule_services_proto_rawDesc = "" +
 	"conditions\x18\x04 \x01(\v2\x17.google.protobuf.StructR\n" +
 	"conditions\x12/\n" +
 	"\x11apply_to_existing\x18\x05 \x01(\bH\x01R\x0fapplyToExisting\x88\x01\x01\x12\x1f\n" +
-	"\bmerchant\x18\x06 \x01(\tH\x02R\bmerchant\x88\x01\x01B\x0e\n" +
+	"\bmerchant\x18\x06 \x01(\tH\x02R\bmerchant\x88\x01\x01\x12D\n" +
+	"\vrule_source\x18\a \x01(\tB\x1e\xbaH\x1br\x19R\fuser_createdR\tsuggestedH\x03R\n" +
+	"ruleSource\x88\x01\x01B\x0e\n" +
 	"\f_category_idB\x14\n" +
 	"\x12_apply_to_existingB\v\n" +
-	"\t_merchant\"7\n" +
+	"\t_merchantB\x0e\n" +
+	"\f_rule_source\"7\n" +
 	"\x12CreateRuleResponse\x12!\n" +
 	"\x04rule\x18\x01 \x01(\v2\r.null.v1.RuleR\x04rule\"\xad\x04\n" +
 	"\x11UpdateRuleRequest\x12!\n" +
@@ -820,7 +944,18 @@ const file_null_v1_rule_services_proto_rawDesc = "" +
 	"\x14ValidateRuleResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x120\n" +
 	"\x06errors\x18\x02 \x03(\v2\x18.null.v1.ValidationErrorR\x06errors\x12L\n" +
-	"\x15normalized_conditions\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x14normalizedConditions2\xb1\x03\n" +
+	"\x15normalized_conditions\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x14normalizedConditions\"\xfc\x01\n" +
+	"\x13SuggestRulesRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12-\n" +
+	"\vmin_support\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x01H\x00R\n" +
+	"minSupport\x88\x01\x01\x12A\n" +
+	"\rmin_precision\x18\x03 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00\xf0?)\x00\x00\x00\x00\x00\x00\x00\x00H\x01R\fminPrecision\x88\x01\x01\x12$\n" +
+	"\x05limit\x18\x04 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d \x00H\x02R\x05limit\x88\x01\x01B\x0e\n" +
+	"\f_min_supportB\x10\n" +
+	"\x0e_min_precisionB\b\n" +
+	"\x06_limit\"Q\n" +
+	"\x14SuggestRulesResponse\x129\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x17.null.v1.RuleSuggestionR\vsuggestions2\xfe\x03\n" +
 	"\vRuleService\x12B\n" +
 	"\tListRules\x12\x19.null.v1.ListRulesRequest\x1a\x1a.null.v1.ListRulesResponse\x12<\n" +
 	"\aGetRule\x12\x17.null.v1.GetRuleRequest\x1a\x18.null.v1.GetRuleResponse\x12E\n" +
@@ -830,7 +965,8 @@ const file_null_v1_rule_services_proto_rawDesc = "" +
 	"UpdateRule\x12\x1a.null.v1.UpdateRuleRequest\x1a\x1b.null.v1.UpdateRuleResponse\x12E\n" +
 	"\n" +
 	"DeleteRule\x12\x1a.null.v1.DeleteRuleRequest\x1a\x1b.null.v1.DeleteRuleResponse\x12K\n" +
-	"\fValidateRule\x12\x1c.null.v1.ValidateRuleRequest\x1a\x1d.null.v1.ValidateRuleResponseB\x86\x01\n" +
+	"\fValidateRule\x12\x1c.null.v1.ValidateRuleRequest\x1a\x1d.null.v1.ValidateRuleResponse\x12K\n" +
+	"\fSuggestRules\x12\x1c.null.v1.SuggestRulesRequest\x1a\x1d.null.v1.SuggestRulesResponseB\x86\x01\n" +
 	"\vcom.null.v1B\x11RuleServicesProtoP\x01Z%null-core/internal/gen/null/v1;nullv1\xa2\x02\x03NXX\xaa\x02\aNull.V1\xca\x02\bNull_\\V1\xe2\x02\x14Null_\\V1\\GPBMetadata\xea\x02\bNull::V1b\x06proto3"
 
 var (
@@ -845,7 +981,7 @@ func file_null_v1_rule_services_proto_rawDescGZIP() []byte {
 	return file_null_v1_rule_services_proto_rawDescData
 }
 
-var file_null_v1_rule_services_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_null_v1_rule_services_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_null_v1_rule_services_proto_goTypes = []any{
 	(*ListRulesRequest)(nil),      // 0: null.v1.ListRulesRequest
 	(*ListRulesResponse)(nil),     // 1: null.v1.ListRulesResponse
@@ -860,37 +996,43 @@ var file_null_v1_rule_services_proto_goTypes = []any{
 	(*ValidateRuleRequest)(nil),   // 10: null.v1.ValidateRuleRequest
 	(*ValidationError)(nil),       // 11: null.v1.ValidationError
 	(*ValidateRuleResponse)(nil),  // 12: null.v1.ValidateRuleResponse
-	(*Rule)(nil),                  // 13: null.v1.Rule
-	(*structpb.Struct)(nil),       // 14: google.protobuf.Struct
-	(*fieldmaskpb.FieldMask)(nil), // 15: google.protobuf.FieldMask
+	(*SuggestRulesRequest)(nil),   // 13: null.v1.SuggestRulesRequest
+	(*SuggestRulesResponse)(nil),  // 14: null.v1.SuggestRulesResponse
+	(*Rule)(nil),                  // 15: null.v1.Rule
+	(*structpb.Struct)(nil),       // 16: google.protobuf.Struct
+	(*fieldmaskpb.FieldMask)(nil), // 17: google.protobuf.FieldMask
+	(*RuleSuggestion)(nil),        // 18: null.v1.RuleSuggestion
 }
 var file_null_v1_rule_services_proto_depIdxs = []int32{
-	13, // 0: null.v1.ListRulesResponse.rules:type_name -> null.v1.Rule
-	13, // 1: null.v1.GetRuleResponse.rule:type_name -> null.v1.Rule
-	14, // 2: null.v1.CreateRuleRequest.conditions:type_name -> google.protobuf.Struct
-	13, // 3: null.v1.CreateRuleResponse.rule:type_name -> null.v1.Rule
-	15, // 4: null.v1.UpdateRuleRequest.update_mask:type_name -> google.protobuf.FieldMask
-	14, // 5: null.v1.UpdateRuleRequest.conditions:type_name -> google.protobuf.Struct
-	14, // 6: null.v1.ValidateRuleRequest.conditions:type_name -> google.protobuf.Struct
+	15, // 0: null.v1.ListRulesResponse.rules:type_name -> null.v1.Rule
+	15, // 1: null.v1.GetRuleResponse.rule:type_name -> null.v1.Rule
+	16, // 2: null.v1.CreateRuleRequest.conditions:type_name -> google.protobuf.Struct
+	15, // 3: null.v1.CreateRuleResponse.rule:type_name -> null.v1.Rule
+	17, // 4: null.v1.UpdateRuleRequest.update_mask:type_name -> google.protobuf.FieldMask
+	16, // 5: null.v1.UpdateRuleRequest.conditions:type_name -> google.protobuf.Struct
+	16, // 6: null.v1.ValidateRuleRequest.conditions:type_name -> google.protobuf.Struct
 	11, // 7: null.v1.ValidateRuleResponse.errors:type_name -> null.v1.ValidationError
-	14, // 8: null.v1.ValidateRuleResponse.normalized_conditions:type_name -> google.protobuf.Struct
-	0,  // 9: null.v1.RuleService.ListRules:input_type -> null.v1.ListRulesRequest
-	2,  // 10: null.v1.RuleService.GetRule:input_type -> null.v1.GetRuleRequest
-	4,  // 11: null.v1.RuleService.CreateRule:input_type -> null.v1.CreateRuleRequest
-	6,  // 12: null.v1.RuleService.UpdateRule:input_type -> null.v1.UpdateRuleRequest
-	8,  // 13: null.v1.RuleService.DeleteRule:input_type -> null.v1.DeleteRuleRequest
-	10, // 14: null.v1.RuleService.ValidateRule:input_type -> null.v1.ValidateRuleRequest
-	1,  // 15: null.v1.RuleService.ListRules:output_type -> null.v1.ListRulesResponse
-	3,  // 16: null.v1.RuleService.GetRule:output_type -> null.v1.GetRuleResponse
-	5,  // 17: null.v1.RuleService.CreateRule:output_type -> null.v1.CreateRuleResponse
-	7,  // 18: null.v1.RuleService.UpdateRule:output_type -> null.v1.UpdateRuleResponse
-	9,  // 19: null.v1.RuleService.DeleteRule:output_type -> null.v1.DeleteRuleResponse
-	12, // 20: null.v1.RuleService.ValidateRule:output_type -> null.v1.ValidateRuleResponse
-	15, // [15:21] is the sub-list for method output_type
-	9,  // [9:15] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	16, // 8: null.v1.ValidateRuleResponse.normalized_conditions:type_name -> google.protobuf.Struct
+	18, // 9: null.v1.SuggestRulesResponse.suggestions:type_name -> null.v1.RuleSuggestion
+	0,  // 10: null.v1.RuleService.ListRules:input_type -> null.v1.ListRulesRequest
+	2,  // 11: null.v1.RuleService.GetRule:input_type -> null.v1.GetRuleRequest
+	4,  // 12: null.v1.RuleService.CreateRule:input_type -> null.v1.CreateRuleRequest
+	6,  // 13: null.v1.RuleService.UpdateRule:input_type -> null.v1.UpdateRuleRequest
+	8,  // 14: null.v1.RuleService.DeleteRule:input_type -> null.v1.DeleteRuleRequest
+	10, // 15: null.v1.RuleService.ValidateRule:input_type -> null.v1.ValidateRuleRequest
+	13, // 16: null.v1.RuleService.SuggestRules:input_type -> null.v1.SuggestRulesRequest
+	1,  // 17: null.v1.RuleService.ListRules:output_type -> null.v1.ListRulesResponse
+	3,  // 18: null.v1.RuleService.GetRule:output_type -> null.v1.GetRuleResponse
+	5,  // 19: null.v1.RuleService.CreateRule:output_type -> null.v1.CreateRuleResponse
+	7,  // 20: null.v1.RuleService.UpdateRule:output_type -> null.v1.UpdateRuleResponse
+	9,  // 21: null.v1.RuleService.DeleteRule:output_type -> null.v1.DeleteRuleResponse
+	12, // 22: null.v1.RuleService.ValidateRule:output_type -> null.v1.ValidateRuleResponse
+	14, // 23: null.v1.RuleService.SuggestRules:output_type -> null.v1.SuggestRulesResponse
+	17, // [17:24] is the sub-list for method output_type
+	10, // [10:17] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_null_v1_rule_services_proto_init() }
@@ -901,13 +1043,14 @@ func file_null_v1_rule_services_proto_init() {
 	file_null_v1_rule_proto_init()
 	file_null_v1_rule_services_proto_msgTypes[4].OneofWrappers = []any{}
 	file_null_v1_rule_services_proto_msgTypes[6].OneofWrappers = []any{}
+	file_null_v1_rule_services_proto_msgTypes[13].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_rule_services_proto_rawDesc), len(file_null_v1_rule_services_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,6 +25,7 @@ const (
 	RuleService_UpdateRule_FullMethodName   = "/null.v1.RuleService/UpdateRule"
 	RuleService_DeleteRule_FullMethodName   = "/null.v1.RuleService/DeleteRule"
 	RuleService_ValidateRule_FullMethodName = "/null.v1.RuleService/ValidateRule"
+	RuleService_SuggestRules_FullMethodName = "/null.v1.RuleService/SuggestRules"
 )
 
 // RuleServiceClient is the client API for RuleService service.
@@ -37,6 +38,8 @@ type RuleServiceClient interface {
 	UpdateRule(ctx context.Context, in *UpdateRuleRequest, opts ...grpc.CallOption) (*UpdateRuleResponse, error)
 	DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*DeleteRuleResponse, error)
 	ValidateRule(ctx context.Context, in *ValidateRuleRequest, opts ...grpc.CallOption) (*ValidateRuleResponse, error)
+	// mine manual categorizations for candidate rules
+	SuggestRules(ctx context.Context, in *SuggestRulesRequest, opts ...grpc.CallOption) (*SuggestRulesResponse, error)
 }
 
 type ruleServiceClient struct {
@@ -107,6 +110,16 @@ func (c *ruleServiceClient) ValidateRule(ctx context.Context, in *ValidateRuleRe
 	return out, nil
 }
 
+func (c *ruleServiceClient) SuggestRules(ctx context.Context, in *SuggestRulesRequest, opts ...grpc.CallOption) (*SuggestRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestRulesResponse)
+	err := c.cc.Invoke(ctx, RuleService_SuggestRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RuleServiceServer is the server API for RuleService service.
 // All implementations must embed UnimplementedRuleServiceServer
 // for forward compatibility.
@@ -117,6 +130,8 @@ type RuleServiceServer interface {
 	UpdateRule(context.Context, *UpdateRuleRequest) (*UpdateRuleResponse, error)
 	DeleteRule(context.Context, *DeleteRuleRequest) (*DeleteRuleResponse, error)
 	ValidateRule(context.Context, *ValidateRuleRequest) (*ValidateRuleResponse, error)
+	// mine manual categorizations for candidate rules
+	SuggestRules(context.Context, *SuggestRulesRequest) (*SuggestRulesResponse, error)
 	mustEmbedUnimplementedRuleServiceServer()
 }
 
@@ -145,6 +160,9 @@ func (UnimplementedRuleServiceServer) DeleteRule(context.Context, *DeleteRuleReq
 func (UnimplementedRuleServiceServer) ValidateRule(context.Context, *ValidateRuleRequest) (*ValidateRuleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateRule not implemented")
 }
+func (UnimplementedRuleServiceServer) SuggestRules(context.Context, *SuggestRulesRequest) (*SuggestRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestRules not implemented")
+}
 func (UnimplementedRuleServiceServer) mustEmbedUnimplementedRuleServiceServer() {}
 func (UnimplementedRuleServiceServer) testEmbeddedByValue()                     {}
 
@@ -274,6 +292,24 @@ func _RuleService_ValidateRule_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuleService_SuggestRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuleServiceServer).SuggestRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RuleService_SuggestRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuleServiceServer).SuggestRules(ctx, req.(*SuggestRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RuleService_ServiceDesc is the grpc.ServiceDesc for RuleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,6 +341,10 @@ var RuleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ValidateRule",
 			Handler:    _RuleService_ValidateRule_Handler,
 		},
+		{
+			MethodName: "SuggestRules",
+			Handler:    _RuleService_SuggestRules_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "null/v1/rule_services.proto",
@@ -49,8 +49,15 @@ type Transaction struct {
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	// additional fields for API responses
-	Category      *Category `protobuf:"bytes,18,opt,name=category,proto3,oneof" json:"category,omitempty"`
-	AccountName   *string   `protobuf:"bytes,19,opt,name=account_name,json=accountName,proto3,oneof" json:"account_name,omitempty"`
+	Category    *Category `protobuf:"bytes,18,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	AccountName *string   `protobuf:"bytes,19,opt,name=account_name,json=accountName,proto3,oneof" json:"account_name,omitempty"`
+	// provenance: the rules that set category / merchant, if any
+	CategoryRuleId *string `protobuf:"bytes,20,opt,name=category_rule_id,json=categoryRuleId,proto3,oneof" json:"category_rule_id,omitempty"`
+	MerchantRuleId *string `protobuf:"bytes,21,opt,name=merchant_rule_id,json=merchantRuleId,proto3,oneof" json:"merchant_rule_id,omitempty"`
+	// likely categories from the user's history, most likely first; only set while uncategorized
+	SuggestedCategoryIds []int64 `protobuf:"varint,22,rep,packed,name=suggested_category_ids,json=suggestedCategoryIds,proto3" json:"suggested_category_ids,omitempty"`
+	// canonical merchant the descriptor resolved to
+	MerchantId    *int64 `protobuf:"varint,23,opt,name=merchant_id,json=merchantId,proto3,oneof" json:"merchant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -218,6 +225,34 @@ func (x *Transaction) GetAccountName() string {
 	return ""
 }
 
+func (x *Transaction) GetCategoryRuleId() string {
+	if x != nil && x.CategoryRuleId != nil {
+		return *x.CategoryRuleId
+	}
+	return ""
+}
+
+func (x *Transaction) GetMerchantRuleId() string {
+	if x != nil && x.MerchantRuleId != nil {
+		return *x.MerchantRuleId
+	}
+	return ""
+}
+
+func (x *Transaction) GetSuggestedCategoryIds() []int64 {
+	if x != nil {
+		return x.SuggestedCategoryIds
+	}
+	return nil
+}
+
+func (x *Transaction) GetMerchantId() int64 {
+	if x != nil && x.MerchantId != nil {
+		return *x.MerchantId
+	}
+	return 0
+}
+
 type TransactionWithScore struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
@@ -334,7 +369,8 @@ var File_null_v1_transaction_proto protoreflect.FileDescriptor
 
 const file_null_v1_transaction_proto_rawDesc = "" +
 	"\n" +
-	"\x19null/v1/transaction.proto\x12\anull.v1\x1a\x16null/v1/category.proto\x1a\x13null/v1/enums.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17google/type/money.proto\"\xd1\b\n" +
+	"\x19null/v1/transaction.proto\x12\anull.v1\x1a\x16null/v1/category.proto\x1a\x13null/v1/enums.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17google/type/money.proto\"\xd9\n" +
+	"\n" +
 	"\vTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x123\n" +
 	"\atx_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06txDate\x12/\n" +
@@ -360,7 +396,13 @@ const file_null_v1_transaction_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x122\n" +
 	"\bcategory\x18\x12 \x01(\v2\x11.null.v1.CategoryH\bR\bcategory\x88\x01\x01\x12&\n" +
-	"\faccount_name\x18\x13 \x01(\tH\tR\vaccountName\x88\x01\x01B\v\n" +
+	"\faccount_name\x18\x13 \x01(\tH\tR\vaccountName\x88\x01\x01\x127\n" +
+	"\x10category_rule_id\x18\x14 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01H\n" +
+	"R\x0ecategoryRuleId\x88\x01\x01\x127\n" +
+	"\x10merchant_rule_id\x18\x15 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01H\vR\x0emerchantRuleId\x88\x01\x01\x124\n" +
+	"\x16suggested_category_ids\x18\x16 \x03(\x03R\x14suggestedCategoryIds\x12$\n" +
+	"\vmerchant_id\x18\x17 \x01(\x03H\fR\n" +
+	"merchantId\x88\x01\x01B\v\n" +
 	"\t_email_idB\x0e\n" +
 	"\f_descriptionB\x0e\n" +
 	"\f_category_idB\v\n" +
@@ -370,7 +412,10 @@ const file_null_v1_transaction_proto_rawDesc = "" +
 	"\x0f_foreign_amountB\x10\n" +
 	"\x0e_exchange_rateB\v\n" +
 	"\t_categoryB\x0f\n" +
-	"\r_account_name\"u\n" +
+	"\r_account_nameB\x13\n" +
+	"\x11_category_rule_idB\x13\n" +
+	"\x11_merchant_rule_idB\x0e\n" +
+	"\f_merchant_id\"u\n" +
 	"\x14TransactionWithScore\x126\n" +
 	"\vtransaction\x18\x01 \x01(\v2\x14.null.v1.TransactionR\vtransaction\x12%\n" +
 	"\x0emerchant_score\x18\x02 \x01(\x01R\rmerchantScore\"\x8a\x01\n" +
@@ -957,6 +957,118 @@ func (x *CategorizeTransactionsResponse) GetAffectedRows() int64 {
 	return 0
 }
 
+type ListUncategorizedTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Cursor        *Cursor                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUncategorizedTransactionsRequest) Reset() {
+	*x = ListUncategorizedTransactionsRequest{}
+	mi := &file_null_v1_transaction_services_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUncategorizedTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUncategorizedTransactionsRequest) ProtoMessage() {}
+
+func (x *ListUncategorizedTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_transaction_services_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUncategorizedTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListUncategorizedTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_transaction_services_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListUncategorizedTransactionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUncategorizedTransactionsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListUncategorizedTransactionsRequest) GetCursor() *Cursor {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
+type ListUncategorizedTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	NextCursor    *Cursor                `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUncategorizedTransactionsResponse) Reset() {
+	*x = ListUncategorizedTransactionsResponse{}
+	mi := &file_null_v1_transaction_services_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUncategorizedTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUncategorizedTransactionsResponse) ProtoMessage() {}
+
+func (x *ListUncategorizedTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_transaction_services_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUncategorizedTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListUncategorizedTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_transaction_services_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListUncategorizedTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListUncategorizedTransactionsResponse) GetNextCursor() *Cursor {
+	if x != nil {
+		return x.NextCursor
+	}
+	return nil
+}
+
 var File_null_v1_transaction_services_proto protoreflect.FileDescriptor
 
 const file_null_v1_transaction_services_proto_rawDesc = "" +
@@ -1090,14 +1202,27 @@ const file_null_v1_transaction_services_proto_rawDesc = "" +
 	"\vcategory_id\x18\x03 \x01(\x03R\n" +
 	"categoryId\"E\n" +
 	"\x1eCategorizeTransactionsResponse\x12#\n" +
-	"\raffected_rows\x18\x01 \x01(\x03R\faffectedRows2\xbf\x04\n" +
+	"\raffected_rows\x18\x01 \x01(\x03R\faffectedRows\"\xb3\x01\n" +
+	"$ListUncategorizedTransactionsRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12%\n" +
+	"\x05limit\x18\x02 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x01H\x00R\x05limit\x88\x01\x01\x12,\n" +
+	"\x06cursor\x18\x03 \x01(\v2\x0f.null.v1.CursorH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_cursor\"\xa8\x01\n" +
+	"%ListUncategorizedTransactionsResponse\x128\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x14.null.v1.TransactionR\ftransactions\x125\n" +
+	"\vnext_cursor\x18\x02 \x01(\v2\x0f.null.v1.CursorH\x00R\n" +
+	"nextCursor\x88\x01\x01B\x0e\n" +
+	"\f_next_cursor2\xbf\x05\n" +
 	"\x12TransactionService\x12W\n" +
 	"\x10ListTransactions\x12 .null.v1.ListTransactionsRequest\x1a!.null.v1.ListTransactionsResponse\x12Q\n" +
 	"\x0eGetTransaction\x12\x1e.null.v1.GetTransactionRequest\x1a\x1f.null.v1.GetTransactionResponse\x12Z\n" +
 	"\x11CreateTransaction\x12!.null.v1.CreateTransactionRequest\x1a\".null.v1.CreateTransactionResponse\x12Z\n" +
 	"\x11UpdateTransaction\x12!.null.v1.UpdateTransactionRequest\x1a\".null.v1.UpdateTransactionResponse\x12Z\n" +
 	"\x11DeleteTransaction\x12!.null.v1.DeleteTransactionRequest\x1a\".null.v1.DeleteTransactionResponse\x12i\n" +
-	"\x16CategorizeTransactions\x12&.null.v1.CategorizeTransactionsRequest\x1a'.null.v1.CategorizeTransactionsResponseB\x8d\x01\n" +
+	"\x16CategorizeTransactions\x12&.null.v1.CategorizeTransactionsRequest\x1a'.null.v1.CategorizeTransactionsResponse\x12~\n" +
+	"\x1dListUncategorizedTransactions\x12-.null.v1.ListUncategorizedTransactionsRequest\x1a..null.v1.ListUncategorizedTransactionsResponseB\x8d\x01\n" +
 	"\vcom.null.v1B\x18TransactionServicesProtoP\x01Z%null-core/internal/gen/null/v1;nullv1\xa2\x02\x03NXX\xaa\x02\aNull.V1\xca\x02\bNull_\\V1\xe2\x02\x14Null_\\V1\\GPBMetadata\xea\x02\bNull::V1b\x06proto3"
 
 var (
@@ -1112,69 +1237,76 @@ func file_null_v1_transaction_services_proto_rawDescGZIP() []byte {
 	return file_null_v1_transaction_services_proto_rawDescData
 }
 
-var file_null_v1_transaction_services_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_null_v1_transaction_services_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_null_v1_transaction_services_proto_goTypes = []any{
-	(*ListTransactionsRequest)(nil),        // 0: null.v1.ListTransactionsRequest
-	(*ListTransactionsResponse)(nil),       // 1: null.v1.ListTransactionsResponse
-	(*GetTransactionRequest)(nil),          // 2: null.v1.GetTransactionRequest
-	(*GetTransactionResponse)(nil),         // 3: null.v1.GetTransactionResponse
-	(*TransactionInput)(nil),               // 4: null.v1.TransactionInput
-	(*CreateTransactionRequest)(nil),       // 5: null.v1.CreateTransactionRequest
-	(*CreateTransactionResponse)(nil),      // 6: null.v1.CreateTransactionResponse
-	(*UpdateTransactionRequest)(nil),       // 7: null.v1.UpdateTransactionRequest
-	(*UpdateTransactionResponse)(nil),      // 8: null.v1.UpdateTransactionResponse
-	(*DeleteTransactionRequest)(nil),       // 9: null.v1.DeleteTransactionRequest
-	(*DeleteTransactionResponse)(nil),      // 10: null.v1.DeleteTransactionResponse
-	(*CategorizeTransactionsRequest)(nil),  // 11: null.v1.CategorizeTransactionsRequest
-	(*CategorizeTransactionsResponse)(nil), // 12: null.v1.CategorizeTransactionsResponse
-	(*timestamppb.Timestamp)(nil),          // 13: google.protobuf.Timestamp
-	(*Cursor)(nil),                         // 14: null.v1.Cursor
-	(*money.Money)(nil),                    // 15: google.type.Money
-	(TransactionDirection)(0),              // 16: null.v1.TransactionDirection
-	(*TimeOfDay)(nil),                      // 17: null.v1.TimeOfDay
-	(*Transaction)(nil),                    // 18: null.v1.Transaction
-	(*fieldmaskpb.FieldMask)(nil),          // 19: google.protobuf.FieldMask
+	(*ListTransactionsRequest)(nil),               // 0: null.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),              // 1: null.v1.ListTransactionsResponse
+	(*GetTransactionRequest)(nil),                 // 2: null.v1.GetTransactionRequest
+	(*GetTransactionResponse)(nil),                // 3: null.v1.GetTransactionResponse
+	(*TransactionInput)(nil),                      // 4: null.v1.TransactionInput
+	(*CreateTransactionRequest)(nil),              // 5: null.v1.CreateTransactionRequest
+	(*CreateTransactionResponse)(nil),             // 6: null.v1.CreateTransactionResponse
+	(*UpdateTransactionRequest)(nil),              // 7: null.v1.UpdateTransactionRequest
+	(*UpdateTransactionResponse)(nil),             // 8: null.v1.UpdateTransactionResponse
+	(*DeleteTransactionRequest)(nil),              // 9: null.v1.DeleteTransactionRequest
+	(*DeleteTransactionResponse)(nil),             // 10: null.v1.DeleteTransactionResponse
+	(*CategorizeTransactionsRequest)(nil),         // 11: null.v1.CategorizeTransactionsRequest
+	(*CategorizeTransactionsResponse)(nil),        // 12: null.v1.CategorizeTransactionsResponse
+	(*ListUncategorizedTransactionsRequest)(nil),  // 13: null.v1.ListUncategorizedTransactionsRequest
+	(*ListUncategorizedTransactionsResponse)(nil), // 14: null.v1.ListUncategorizedTransactionsResponse
+	(*timestamppb.Timestamp)(nil),                 // 15: google.protobuf.Timestamp
+	(*Cursor)(nil),                                // 16: null.v1.Cursor
+	(*money.Money)(nil),                           // 17: google.type.Money
+	(TransactionDirection)(0),                     // 18: null.v1.TransactionDirection
+	(*TimeOfDay)(nil),                             // 19: null.v1.TimeOfDay
+	(*Transaction)(nil),                           // 20: null.v1.Transaction
+	(*fieldmaskpb.FieldMask)(nil),                 // 21: google.protobuf.FieldMask
 }
 var file_null_v1_transaction_services_proto_depIdxs = []int32{
-	13, // 0: null.v1.ListTransactionsRequest.start_date:type_name -> google.protobuf.Timestamp
-	13, // 1: null.v1.ListTransactionsRequest.end_date:type_name -> google.protobuf.Timestamp
-	14, // 2: null.v1.ListTransactionsRequest.cursor:type_name -> null.v1.Cursor
-	15, // 3: null.v1.ListTransactionsRequest.amount_min:type_name -> google.type.Money
-	15, // 4: null.v1.ListTransactionsRequest.amount_max:type_name -> google.type.Money
-	16, // 5: null.v1.ListTransactionsRequest.direction:type_name -> null.v1.TransactionDirection
-	17, // 6: null.v1.ListTransactionsRequest.time_of_day_start:type_name -> null.v1.TimeOfDay
-	17, // 7: null.v1.ListTransactionsRequest.time_of_day_end:type_name -> null.v1.TimeOfDay
-	18, // 8: null.v1.ListTransactionsResponse.transactions:type_name -> null.v1.Transaction
-	14, // 9: null.v1.ListTransactionsResponse.next_cursor:type_name -> null.v1.Cursor
-	18, // 10: null.v1.GetTransactionResponse.transaction:type_name -> null.v1.Transaction
-	13, // 11: null.v1.TransactionInput.tx_date:type_name -> google.protobuf.Timestamp
-	15, // 12: null.v1.TransactionInput.tx_amount:type_name -> google.type.Money
-	16, // 13: null.v1.TransactionInput.direction:type_name -> null.v1.TransactionDirection
-	15, // 14: null.v1.TransactionInput.foreign_amount:type_name -> google.type.Money
+	15, // 0: null.v1.ListTransactionsRequest.start_date:type_name -> google.protobuf.Timestamp
+	15, // 1: null.v1.ListTransactionsRequest.end_date:type_name -> google.protobuf.Timestamp
+	16, // 2: null.v1.ListTransactionsRequest.cursor:type_name -> null.v1.Cursor
+	17, // 3: null.v1.ListTransactionsRequest.amount_min:type_name -> google.type.Money
+	17, // 4: null.v1.ListTransactionsRequest.amount_max:type_name -> google.type.Money
+	18, // 5: null.v1.ListTransactionsRequest.direction:type_name -> null.v1.TransactionDirection
+	19, // 6: null.v1.ListTransactionsRequest.time_of_day_start:type_name -> null.v1.TimeOfDay
+	19, // 7: null.v1.ListTransactionsRequest.time_of_day_end:type_name -> null.v1.TimeOfDay
+	20, // 8: null.v1.ListTransactionsResponse.transactions:type_name -> null.v1.Transaction
+	16, // 9: null.v1.ListTransactionsResponse.next_cursor:type_name -> null.v1.Cursor
+	20, // 10: null.v1.GetTransactionResponse.transaction:type_name -> null.v1.Transaction
+	15, // 11: null.v1.TransactionInput.tx_date:type_name -> google.protobuf.Timestamp
+	17, // 12: null.v1.TransactionInput.tx_amount:type_name -> google.type.Money
+	18, // 13: null.v1.TransactionInput.direction:type_name -> null.v1.TransactionDirection
+	17, // 14: null.v1.TransactionInput.foreign_amount:type_name -> google.type.Money
 	4,  // 15: null.v1.CreateTransactionRequest.transactions:type_name -> null.v1.TransactionInput
-	18, // 16: null.v1.CreateTransactionResponse.transactions:type_name -> null.v1.Transaction
-	19, // 17: null.v1.UpdateTransactionRequest.update_mask:type_name -> google.protobuf.FieldMask
-	13, // 18: null.v1.UpdateTransactionRequest.tx_date:type_name -> google.protobuf.Timestamp
-	15, // 19: null.v1.UpdateTransactionRequest.tx_amount:type_name -> google.type.Money
-	16, // 20: null.v1.UpdateTransactionRequest.direction:type_name -> null.v1.TransactionDirection
-	15, // 21: null.v1.UpdateTransactionRequest.foreign_amount:type_name -> google.type.Money
-	0,  // 22: null.v1.TransactionService.ListTransactions:input_type -> null.v1.ListTransactionsRequest
-	2,  // 23: null.v1.TransactionService.GetTransaction:input_type -> null.v1.GetTransactionRequest
-	5,  // 24: null.v1.TransactionService.CreateTransaction:input_type -> null.v1.CreateTransactionRequest
-	7,  // 25: null.v1.TransactionService.UpdateTransaction:input_type -> null.v1.UpdateTransactionRequest
-	9,  // 26: null.v1.TransactionService.DeleteTransaction:input_type -> null.v1.DeleteTransactionRequest
-	11, // 27: null.v1.TransactionService.CategorizeTransactions:input_type -> null.v1.CategorizeTransactionsRequest
-	1,  // 28: null.v1.TransactionService.ListTransactions:output_type -> null.v1.ListTransactionsResponse
-	3,  // 29: null.v1.TransactionService.GetTransaction:output_type -> null.v1.GetTransactionResponse
-	6,  // 30: null.v1.TransactionService.CreateTransaction:output_type -> null.v1.CreateTransactionResponse
-	8,  // 31: null.v1.TransactionService.UpdateTransaction:output_type -> null.v1.UpdateTransactionResponse
-	10, // 32: null.v1.TransactionService.DeleteTransaction:output_type -> null.v1.DeleteTransactionResponse
-	12, // 33: null.v1.TransactionService.CategorizeTransactions:output_type -> null.v1.CategorizeTransactionsResponse
-	28, // [28:34] is the sub-list for method output_type
-	22, // [22:28] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	20, // 16: null.v1.CreateTransactionResponse.transactions:type_name -> null.v1.Transaction
+	21, // 17: null.v1.UpdateTransactionRequest.update_mask:type_name -> google.protobuf.FieldMask
+	15, // 18: null.v1.UpdateTransactionRequest.tx_date:type_name -> google.protobuf.Timestamp
+	17, // 19: null.v1.UpdateTransactionRequest.tx_amount:type_name -> google.type.Money
+	18, // 20: null.v1.UpdateTransactionRequest.direction:type_name -> null.v1.TransactionDirection
+	17, // 21: null.v1.UpdateTransactionRequest.foreign_amount:type_name -> google.type.Money
+	16, // 22: null.v1.ListUncategorizedTransactionsRequest.cursor:type_name -> null.v1.Cursor
+	20, // 23: null.v1.ListUncategorizedTransactionsResponse.transactions:type_name -> null.v1.Transaction
+	16, // 24: null.v1.ListUncategorizedTransactionsResponse.next_cursor:type_name -> null.v1.Cursor
+	0,  // 25: null.v1.TransactionService.ListTransactions:input_type -> null.v1.ListTransactionsRequest
+	2,  // 26: null.v1.TransactionService.GetTransaction:input_type -> null.v1.GetTransactionRequest
+	5,  // 27: null.v1.TransactionService.CreateTransaction:input_type -> null.v1.CreateTransactionRequest
+	7,  // 28: null.v1.TransactionService.UpdateTransaction:input_type -> null.v1.UpdateTransactionRequest
+	9,  // 29: null.v1.TransactionService.DeleteTransaction:input_type -> null.v1.DeleteTransactionRequest
+	11, // 30: null.v1.TransactionService.CategorizeTransactions:input_type -> null.v1.CategorizeTransactionsRequest
+	13, // 31: null.v1.TransactionService.ListUncategorizedTransactions:input_type -> null.v1.ListUncategorizedTransactionsRequest
+	1,  // 32: null.v1.TransactionService.ListTransactions:output_type -> null.v1.ListTransactionsResponse
+	3,  // 33: null.v1.TransactionService.GetTransaction:output_type -> null.v1.GetTransactionResponse
+	6,  // 34: null.v1.TransactionService.CreateTransaction:output_type -> null.v1.CreateTransactionResponse
+	8,  // 35: null.v1.TransactionService.UpdateTransaction:output_type -> null.v1.UpdateTransactionResponse
+	10, // 36: null.v1.TransactionService.DeleteTransaction:output_type -> null.v1.DeleteTransactionResponse
+	12, // 37: null.v1.TransactionService.CategorizeTransactions:output_type -> null.v1.CategorizeTransactionsResponse
+	14, // 38: null.v1.TransactionService.ListUncategorizedTransactions:output_type -> null.v1.ListUncategorizedTransactionsResponse
+	32, // [32:39] is the sub-list for method output_type
+	25, // [25:32] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_null_v1_transaction_services_proto_init() }
@@ -1189,13 +1321,15 @@ func file_null_v1_transaction_services_proto_init() {
 	file_null_v1_transaction_services_proto_msgTypes[1].OneofWrappers = []any{}
 	file_null_v1_transaction_services_proto_msgTypes[4].OneofWrappers = []any{}
 	file_null_v1_transaction_services_proto_msgTypes[7].OneofWrappers = []any{}
+	file_null_v1_transaction_services_proto_msgTypes[13].OneofWrappers = []any{}
+	file_null_v1_transaction_services_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_transaction_services_proto_rawDesc), len(file_null_v1_transaction_services_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
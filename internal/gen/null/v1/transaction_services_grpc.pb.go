@@ -19,12 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TransactionService_ListTransactions_FullMethodName       = "/null.v1.TransactionService/ListTransactions"
-	TransactionService_GetTransaction_FullMethodName         = "/null.v1.TransactionService/GetTransaction"
-	TransactionService_CreateTransaction_FullMethodName      = "/null.v1.TransactionService/CreateTransaction"
-	TransactionService_UpdateTransaction_FullMethodName      = "/null.v1.TransactionService/UpdateTransaction"
-	TransactionService_DeleteTransaction_FullMethodName      = "/null.v1.TransactionService/DeleteTransaction"
-	TransactionService_CategorizeTransactions_FullMethodName = "/null.v1.TransactionService/CategorizeTransactions"
+	TransactionService_ListTransactions_FullMethodName              = "/null.v1.TransactionService/ListTransactions"
+	TransactionService_GetTransaction_FullMethodName                = "/null.v1.TransactionService/GetTransaction"
+	TransactionService_CreateTransaction_FullMethodName             = "/null.v1.TransactionService/CreateTransaction"
+	TransactionService_UpdateTransaction_FullMethodName             = "/null.v1.TransactionService/UpdateTransaction"
+	TransactionService_DeleteTransaction_FullMethodName             = "/null.v1.TransactionService/DeleteTransaction"
+	TransactionService_CategorizeTransactions_FullMethodName        = "/null.v1.TransactionService/CategorizeTransactions"
+	TransactionService_ListUncategorizedTransactions_FullMethodName = "/null.v1.TransactionService/ListUncategorizedTransactions"
 )
 
 // TransactionServiceClient is the client API for TransactionService service.
@@ -37,6 +38,8 @@ type TransactionServiceClient interface {
 	UpdateTransaction(ctx context.Context, in *UpdateTransactionRequest, opts ...grpc.CallOption) (*UpdateTransactionResponse, error)
 	DeleteTransaction(ctx context.Context, in *DeleteTransactionRequest, opts ...grpc.CallOption) (*DeleteTransactionResponse, error)
 	CategorizeTransactions(ctx context.Context, in *CategorizeTransactionsRequest, opts ...grpc.CallOption) (*CategorizeTransactionsResponse, error)
+	// uncategorized inbox: uncategorized transactions with category suggestions
+	ListUncategorizedTransactions(ctx context.Context, in *ListUncategorizedTransactionsRequest, opts ...grpc.CallOption) (*ListUncategorizedTransactionsResponse, error)
 }
 
 type transactionServiceClient struct {
@@ -107,6 +110,16 @@ func (c *transactionServiceClient) CategorizeTransactions(ctx context.Context, i
 	return out, nil
 }
 
+func (c *transactionServiceClient) ListUncategorizedTransactions(ctx context.Context, in *ListUncategorizedTransactionsRequest, opts ...grpc.CallOption) (*ListUncategorizedTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUncategorizedTransactionsResponse)
+	err := c.cc.Invoke(ctx, TransactionService_ListUncategorizedTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransactionServiceServer is the server API for TransactionService service.
 // All implementations must embed UnimplementedTransactionServiceServer
 // for forward compatibility.
@@ -117,6 +130,8 @@ type TransactionServiceServer interface {
 	UpdateTransaction(context.Context, *UpdateTransactionRequest) (*UpdateTransactionResponse, error)
 	DeleteTransaction(context.Context, *DeleteTransactionRequest) (*DeleteTransactionResponse, error)
 	CategorizeTransactions(context.Context, *CategorizeTransactionsRequest) (*CategorizeTransactionsResponse, error)
+	// uncategorized inbox: uncategorized transactions with category suggestions
+	ListUncategorizedTransactions(context.Context, *ListUncategorizedTransactionsRequest) (*ListUncategorizedTransactionsResponse, error)
 	mustEmbedUnimplementedTransactionServiceServer()
 }
 
@@ -145,6 +160,9 @@ func (UnimplementedTransactionServiceServer) DeleteTransaction(context.Context,
 func (UnimplementedTransactionServiceServer) CategorizeTransactions(context.Context, *CategorizeTransactionsRequest) (*CategorizeTransactionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CategorizeTransactions not implemented")
 }
+func (UnimplementedTransactionServiceServer) ListUncategorizedTransactions(context.Context, *ListUncategorizedTransactionsRequest) (*ListUncategorizedTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUncategorizedTransactions not implemented")
+}
 func (UnimplementedTransactionServiceServer) mustEmbedUnimplementedTransactionServiceServer() {}
 func (UnimplementedTransactionServiceServer) testEmbeddedByValue()                            {}
 
@@ -274,6 +292,24 @@ func _TransactionService_CategorizeTransactions_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionService_ListUncategorizedTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUncategorizedTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).ListUncategorizedTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionService_ListUncategorizedTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).ListUncategorizedTransactions(ctx, req.(*ListUncategorizedTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransactionService_ServiceDesc is the grpc.ServiceDesc for TransactionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,6 +341,10 @@ var TransactionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CategorizeTransactions",
 			Handler:    _TransactionService_CategorizeTransactions_Handler,
 		},
+		{
+			MethodName: "ListUncategorizedTransactions",
+			Handler:    _TransactionService_ListUncategorizedTransactions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "null/v1/transaction_services.proto",
@@ -23,6 +23,60 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ApiTokenScope int32
+
+const (
+	ApiTokenScope_API_TOKEN_SCOPE_UNSPECIFIED ApiTokenScope = 0
+	// Get*/List* calls and receipt images, except user and backup endpoints
+	ApiTokenScope_API_TOKEN_SCOPE_READ ApiTokenScope = 1
+	// create, update, delete and categorize transactions
+	ApiTokenScope_API_TOKEN_SCOPE_TRANSACTIONS_WRITE ApiTokenScope = 2
+	ApiTokenScope_API_TOKEN_SCOPE_RECEIPTS_UPLOAD    ApiTokenScope = 3
+)
+
+// Enum value maps for ApiTokenScope.
+var (
+	ApiTokenScope_name = map[int32]string{
+		0: "API_TOKEN_SCOPE_UNSPECIFIED",
+		1: "API_TOKEN_SCOPE_READ",
+		2: "API_TOKEN_SCOPE_TRANSACTIONS_WRITE",
+		3: "API_TOKEN_SCOPE_RECEIPTS_UPLOAD",
+	}
+	ApiTokenScope_value = map[string]int32{
+		"API_TOKEN_SCOPE_UNSPECIFIED":        0,
+		"API_TOKEN_SCOPE_READ":               1,
+		"API_TOKEN_SCOPE_TRANSACTIONS_WRITE": 2,
+		"API_TOKEN_SCOPE_RECEIPTS_UPLOAD":    3,
+	}
+)
+
+func (x ApiTokenScope) Enum() *ApiTokenScope {
+	p := new(ApiTokenScope)
+	*p = x
+	return p
+}
+
+func (x ApiTokenScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ApiTokenScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_null_v1_user_proto_enumTypes[0].Descriptor()
+}
+
+func (ApiTokenScope) Type() protoreflect.EnumType {
+	return &file_null_v1_user_proto_enumTypes[0]
+}
+
+func (x ApiTokenScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ApiTokenScope.Descriptor instead.
+func (ApiTokenScope) EnumDescriptor() ([]byte, []int) {
+	return file_null_v1_user_proto_rawDescGZIP(), []int{0}
+}
+
 type User struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -115,6 +169,100 @@ func (x *User) GetTimezone() string {
 	return ""
 }
 
+// personal access token; the secret itself is only returned on creation
+type ApiToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// first characters of the secret, to tell tokens apart
+	Prefix        string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Scopes        []ApiTokenScope        `protobuf:"varint,4,rep,packed,name=scopes,proto3,enum=null.v1.ApiTokenScope" json:"scopes,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3,oneof" json:"last_used_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiToken) Reset() {
+	*x = ApiToken{}
+	mi := &file_null_v1_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiToken) ProtoMessage() {}
+
+func (x *ApiToken) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiToken.ProtoReflect.Descriptor instead.
+func (*ApiToken) Descriptor() ([]byte, []int) {
+	return file_null_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ApiToken) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ApiToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiToken) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ApiToken) GetScopes() []ApiTokenScope {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *ApiToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 var File_null_v1_user_proto protoreflect.FileDescriptor
 
 const file_null_v1_user_proto_rawDesc = "" +
@@ -131,7 +279,25 @@ const file_null_v1_user_proto_rawDesc = "" +
 	"\x10primary_currency\x18\a \x01(\tB\x14\xbaH\x11r\x0f2\n" +
 	"^[A-Z]{3}$\x98\x01\x03R\x0fprimaryCurrency\x12%\n" +
 	"\btimezone\x18\b \x01(\tB\t\xbaH\x06r\x04\x10\x01\x182R\btimezoneB\x0f\n" +
-	"\r_display_nameB~\n" +
+	"\r_display_name\"\xd4\x02\n" +
+	"\bApiToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12.\n" +
+	"\x06scopes\x18\x04 \x03(\x0e2\x16.null.v1.ApiTokenScopeR\x06scopes\x12>\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01\x12A\n" +
+	"\flast_used_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\n" +
+	"lastUsedAt\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\r\n" +
+	"\v_expires_atB\x0f\n" +
+	"\r_last_used_at*\x97\x01\n" +
+	"\rApiTokenScope\x12\x1f\n" +
+	"\x1bAPI_TOKEN_SCOPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14API_TOKEN_SCOPE_READ\x10\x01\x12&\n" +
+	"\"API_TOKEN_SCOPE_TRANSACTIONS_WRITE\x10\x02\x12#\n" +
+	"\x1fAPI_TOKEN_SCOPE_RECEIPTS_UPLOAD\x10\x03B~\n" +
 	"\vcom.null.v1B\tUserProtoP\x01Z%null-core/internal/gen/null/v1;nullv1\xa2\x02\x03NXX\xaa\x02\aNull.V1\xca\x02\bNull_\\V1\xe2\x02\x14Null_\\V1\\GPBMetadata\xea\x02\bNull::V1b\x06proto3"
 
 var (
@@ -146,19 +312,26 @@ func file_null_v1_user_proto_rawDescGZIP() []byte {
 	return file_null_v1_user_proto_rawDescData
 }
 
-var file_null_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_null_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_null_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_null_v1_user_proto_goTypes = []any{
-	(*User)(nil),                  // 0: null.v1.User
-	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(ApiTokenScope)(0),            // 0: null.v1.ApiTokenScope
+	(*User)(nil),                  // 1: null.v1.User
+	(*ApiToken)(nil),              // 2: null.v1.ApiToken
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
 }
 var file_null_v1_user_proto_depIdxs = []int32{
-	1, // 0: null.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	1, // 1: null.v1.User.updated_at:type_name -> google.protobuf.Timestamp
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 0: null.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	3, // 1: null.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 2: null.v1.ApiToken.scopes:type_name -> null.v1.ApiTokenScope
+	3, // 3: null.v1.ApiToken.expires_at:type_name -> google.protobuf.Timestamp
+	3, // 4: null.v1.ApiToken.last_used_at:type_name -> google.protobuf.Timestamp
+	3, // 5: null.v1.ApiToken.created_at:type_name -> google.protobuf.Timestamp
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_null_v1_user_proto_init() }
@@ -167,18 +340,20 @@ func file_null_v1_user_proto_init() {
 		return
 	}
 	file_null_v1_user_proto_msgTypes[0].OneofWrappers = []any{}
+	file_null_v1_user_proto_msgTypes[1].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_user_proto_rawDesc), len(file_null_v1_user_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_null_v1_user_proto_goTypes,
 		DependencyIndexes: file_null_v1_user_proto_depIdxs,
+		EnumInfos:         file_null_v1_user_proto_enumTypes,
 		MessageInfos:      file_null_v1_user_proto_msgTypes,
 	}.Build()
 	File_null_v1_user_proto = out.File
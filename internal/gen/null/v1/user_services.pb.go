@@ -10,6 +10,7 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -406,11 +407,309 @@ func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
 	return file_null_v1_user_services_proto_rawDescGZIP(), []int{7}
 }
 
+type CreateApiTokenRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes []ApiTokenScope        `protobuf:"varint,3,rep,packed,name=scopes,proto3,enum=null.v1.ApiTokenScope" json:"scopes,omitempty"`
+	// never expires when unset
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiTokenRequest) Reset() {
+	*x = CreateApiTokenRequest{}
+	mi := &file_null_v1_user_services_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiTokenRequest) ProtoMessage() {}
+
+func (x *CreateApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_user_services_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_user_services_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateApiTokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateApiTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApiTokenRequest) GetScopes() []ApiTokenScope {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateApiTokenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateApiTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token *ApiToken              `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// send as "Authorization: Bearer <secret>"; it can't be retrieved again
+	Secret        string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiTokenResponse) Reset() {
+	*x = CreateApiTokenResponse{}
+	mi := &file_null_v1_user_services_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiTokenResponse) ProtoMessage() {}
+
+func (x *CreateApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_user_services_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_user_services_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateApiTokenResponse) GetToken() *ApiToken {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *CreateApiTokenResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ListApiTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiTokensRequest) Reset() {
+	*x = ListApiTokensRequest{}
+	mi := &file_null_v1_user_services_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiTokensRequest) ProtoMessage() {}
+
+func (x *ListApiTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_user_services_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListApiTokensRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_user_services_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListApiTokensRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListApiTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*ApiToken            `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiTokensResponse) Reset() {
+	*x = ListApiTokensResponse{}
+	mi := &file_null_v1_user_services_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiTokensResponse) ProtoMessage() {}
+
+func (x *ListApiTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_user_services_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListApiTokensResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_user_services_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListApiTokensResponse) GetTokens() []*ApiToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type DeleteApiTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteApiTokenRequest) Reset() {
+	*x = DeleteApiTokenRequest{}
+	mi := &file_null_v1_user_services_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteApiTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteApiTokenRequest) ProtoMessage() {}
+
+func (x *DeleteApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_user_services_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*DeleteApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_null_v1_user_services_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteApiTokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteApiTokenRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteApiTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteApiTokenResponse) Reset() {
+	*x = DeleteApiTokenResponse{}
+	mi := &file_null_v1_user_services_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteApiTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteApiTokenResponse) ProtoMessage() {}
+
+func (x *DeleteApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_null_v1_user_services_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*DeleteApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_null_v1_user_services_proto_rawDescGZIP(), []int{13}
+}
+
 var File_null_v1_user_services_proto protoreflect.FileDescriptor
 
 const file_null_v1_user_services_proto_rawDesc = "" +
 	"\n" +
-	"\x1bnull/v1/user_services.proto\x12\anull.v1\x1a\x12null/v1/user.proto\x1a\x1bbuf/validate/validate.proto\" \n" +
+	"\x1bnull/v1/user_services.proto\x12\anull.v1\x1a\x12null/v1/user.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\" \n" +
 	"\x0eGetUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"4\n" +
 	"\x0fGetUserResponse\x12!\n" +
@@ -436,7 +735,25 @@ const file_null_v1_user_services_proto_rawDesc = "" +
 	"\x12UpdateUserResponse\"#\n" +
 	"\x11DeleteUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
-	"\x12DeleteUserResponse2\xa0\x02\n" +
+	"\x12DeleteUserResponse\"\xed\x01\n" +
+	"\x15CreateApiTokenRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12\x1d\n" +
+	"\x04name\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18dR\x04name\x12C\n" +
+	"\x06scopes\x18\x03 \x03(\x0e2\x16.null.v1.ApiTokenScopeB\x13\xbaH\x10\x92\x01\r\b\x01\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\x06scopes\x12>\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01B\r\n" +
+	"\v_expires_at\"Y\n" +
+	"\x16CreateApiTokenResponse\x12'\n" +
+	"\x05token\x18\x01 \x01(\v2\x11.null.v1.ApiTokenR\x05token\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"9\n" +
+	"\x14ListApiTokensRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\"B\n" +
+	"\x15ListApiTokensResponse\x12)\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x11.null.v1.ApiTokenR\x06tokens\"S\n" +
+	"\x15DeleteApiTokenRequest\x12!\n" +
+	"\auser_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06userId\x12\x17\n" +
+	"\x02id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x02id\"\x18\n" +
+	"\x16DeleteApiTokenResponse2\x96\x04\n" +
 	"\vUserService\x12<\n" +
 	"\aGetUser\x12\x17.null.v1.GetUserRequest\x1a\x18.null.v1.GetUserResponse\x12E\n" +
 	"\n" +
@@ -444,7 +761,10 @@ const file_null_v1_user_services_proto_rawDesc = "" +
 	"\n" +
 	"UpdateUser\x12\x1a.null.v1.UpdateUserRequest\x1a\x1b.null.v1.UpdateUserResponse\x12E\n" +
 	"\n" +
-	"DeleteUser\x12\x1a.null.v1.DeleteUserRequest\x1a\x1b.null.v1.DeleteUserResponseB\x86\x01\n" +
+	"DeleteUser\x12\x1a.null.v1.DeleteUserRequest\x1a\x1b.null.v1.DeleteUserResponse\x12Q\n" +
+	"\x0eCreateApiToken\x12\x1e.null.v1.CreateApiTokenRequest\x1a\x1f.null.v1.CreateApiTokenResponse\x12N\n" +
+	"\rListApiTokens\x12\x1d.null.v1.ListApiTokensRequest\x1a\x1e.null.v1.ListApiTokensResponse\x12Q\n" +
+	"\x0eDeleteApiToken\x12\x1e.null.v1.DeleteApiTokenRequest\x1a\x1f.null.v1.DeleteApiTokenResponseB\x86\x01\n" +
 	"\vcom.null.v1B\x11UserServicesProtoP\x01Z%null-core/internal/gen/null/v1;nullv1\xa2\x02\x03NXX\xaa\x02\aNull.V1\xca\x02\bNull_\\V1\xe2\x02\x14Null_\\V1\\GPBMetadata\xea\x02\bNull::V1b\x06proto3"
 
 var (
@@ -459,34 +779,53 @@ func file_null_v1_user_services_proto_rawDescGZIP() []byte {
 	return file_null_v1_user_services_proto_rawDescData
 }
 
-var file_null_v1_user_services_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_null_v1_user_services_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_null_v1_user_services_proto_goTypes = []any{
-	(*GetUserRequest)(nil),     // 0: null.v1.GetUserRequest
-	(*GetUserResponse)(nil),    // 1: null.v1.GetUserResponse
-	(*CreateUserRequest)(nil),  // 2: null.v1.CreateUserRequest
-	(*CreateUserResponse)(nil), // 3: null.v1.CreateUserResponse
-	(*UpdateUserRequest)(nil),  // 4: null.v1.UpdateUserRequest
-	(*UpdateUserResponse)(nil), // 5: null.v1.UpdateUserResponse
-	(*DeleteUserRequest)(nil),  // 6: null.v1.DeleteUserRequest
-	(*DeleteUserResponse)(nil), // 7: null.v1.DeleteUserResponse
-	(*User)(nil),               // 8: null.v1.User
+	(*GetUserRequest)(nil),         // 0: null.v1.GetUserRequest
+	(*GetUserResponse)(nil),        // 1: null.v1.GetUserResponse
+	(*CreateUserRequest)(nil),      // 2: null.v1.CreateUserRequest
+	(*CreateUserResponse)(nil),     // 3: null.v1.CreateUserResponse
+	(*UpdateUserRequest)(nil),      // 4: null.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),     // 5: null.v1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),      // 6: null.v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),     // 7: null.v1.DeleteUserResponse
+	(*CreateApiTokenRequest)(nil),  // 8: null.v1.CreateApiTokenRequest
+	(*CreateApiTokenResponse)(nil), // 9: null.v1.CreateApiTokenResponse
+	(*ListApiTokensRequest)(nil),   // 10: null.v1.ListApiTokensRequest
+	(*ListApiTokensResponse)(nil),  // 11: null.v1.ListApiTokensResponse
+	(*DeleteApiTokenRequest)(nil),  // 12: null.v1.DeleteApiTokenRequest
+	(*DeleteApiTokenResponse)(nil), // 13: null.v1.DeleteApiTokenResponse
+	(*User)(nil),                   // 14: null.v1.User
+	(ApiTokenScope)(0),             // 15: null.v1.ApiTokenScope
+	(*timestamppb.Timestamp)(nil),  // 16: google.protobuf.Timestamp
+	(*ApiToken)(nil),               // 17: null.v1.ApiToken
 }
 var file_null_v1_user_services_proto_depIdxs = []int32{
-	8, // 0: null.v1.GetUserResponse.user:type_name -> null.v1.User
-	8, // 1: null.v1.CreateUserResponse.user:type_name -> null.v1.User
-	0, // 2: null.v1.UserService.GetUser:input_type -> null.v1.GetUserRequest
-	2, // 3: null.v1.UserService.CreateUser:input_type -> null.v1.CreateUserRequest
-	4, // 4: null.v1.UserService.UpdateUser:input_type -> null.v1.UpdateUserRequest
-	6, // 5: null.v1.UserService.DeleteUser:input_type -> null.v1.DeleteUserRequest
-	1, // 6: null.v1.UserService.GetUser:output_type -> null.v1.GetUserResponse
-	3, // 7: null.v1.UserService.CreateUser:output_type -> null.v1.CreateUserResponse
-	5, // 8: null.v1.UserService.UpdateUser:output_type -> null.v1.UpdateUserResponse
-	7, // 9: null.v1.UserService.DeleteUser:output_type -> null.v1.DeleteUserResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	14, // 0: null.v1.GetUserResponse.user:type_name -> null.v1.User
+	14, // 1: null.v1.CreateUserResponse.user:type_name -> null.v1.User
+	15, // 2: null.v1.CreateApiTokenRequest.scopes:type_name -> null.v1.ApiTokenScope
+	16, // 3: null.v1.CreateApiTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	17, // 4: null.v1.CreateApiTokenResponse.token:type_name -> null.v1.ApiToken
+	17, // 5: null.v1.ListApiTokensResponse.tokens:type_name -> null.v1.ApiToken
+	0,  // 6: null.v1.UserService.GetUser:input_type -> null.v1.GetUserRequest
+	2,  // 7: null.v1.UserService.CreateUser:input_type -> null.v1.CreateUserRequest
+	4,  // 8: null.v1.UserService.UpdateUser:input_type -> null.v1.UpdateUserRequest
+	6,  // 9: null.v1.UserService.DeleteUser:input_type -> null.v1.DeleteUserRequest
+	8,  // 10: null.v1.UserService.CreateApiToken:input_type -> null.v1.CreateApiTokenRequest
+	10, // 11: null.v1.UserService.ListApiTokens:input_type -> null.v1.ListApiTokensRequest
+	12, // 12: null.v1.UserService.DeleteApiToken:input_type -> null.v1.DeleteApiTokenRequest
+	1,  // 13: null.v1.UserService.GetUser:output_type -> null.v1.GetUserResponse
+	3,  // 14: null.v1.UserService.CreateUser:output_type -> null.v1.CreateUserResponse
+	5,  // 15: null.v1.UserService.UpdateUser:output_type -> null.v1.UpdateUserResponse
+	7,  // 16: null.v1.UserService.DeleteUser:output_type -> null.v1.DeleteUserResponse
+	9,  // 17: null.v1.UserService.CreateApiToken:output_type -> null.v1.CreateApiTokenResponse
+	11, // 18: null.v1.UserService.ListApiTokens:output_type -> null.v1.ListApiTokensResponse
+	13, // 19: null.v1.UserService.DeleteApiToken:output_type -> null.v1.DeleteApiTokenResponse
+	13, // [13:20] is the sub-list for method output_type
+	6,  // [6:13] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_null_v1_user_services_proto_init() }
@@ -497,13 +836,14 @@ func file_null_v1_user_services_proto_init() {
 	file_null_v1_user_proto_init()
 	file_null_v1_user_services_proto_msgTypes[2].OneofWrappers = []any{}
 	file_null_v1_user_services_proto_msgTypes[4].OneofWrappers = []any{}
+	file_null_v1_user_services_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_null_v1_user_services_proto_rawDesc), len(file_null_v1_user_services_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
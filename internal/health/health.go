@@ -0,0 +1,212 @@
+// Package health probes the server's dependencies in the background and
+// reports per-service gRPC health, plus liveness and readiness endpoints for
+// the orchestrator.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/grpchealth"
+	"github.com/charmbracelet/log"
+)
+
+const maxProbeTimeout = 5 * time.Second
+
+// Dependency is something outside the process the API relies on
+type Dependency struct {
+	Name  string
+	Probe func(ctx context.Context) error
+	// connect services that can't work while it's down; empty means all
+	Services []string
+	// the instance isn't ready to take traffic while a critical dependency
+	// is down; others only take their services out
+	Critical bool
+}
+
+func (d *Dependency) affects(service string) bool {
+	return len(d.Services) == 0 || slices.Contains(d.Services, service)
+}
+
+// Checker implements grpchealth.Checker from the latest probe results
+type Checker struct {
+	services []string
+	deps     []Dependency
+	interval time.Duration
+	log      *log.Logger
+
+	mu      sync.RWMutex
+	probed  bool
+	down    map[string]error // dependency -> why
+	offline map[string]bool  // services taken out by hand; "" means all
+}
+
+func NewChecker(services []string, deps []Dependency, interval time.Duration, logger *log.Logger) *Checker {
+	return &Checker{
+		services: services,
+		deps:     deps,
+		interval: interval,
+		log:      logger,
+		down:     make(map[string]error),
+		offline:  make(map[string]bool),
+	}
+}
+
+// Run probes every dependency right away and then every interval until ctx
+// is done
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.probeAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Checker) probeAll(ctx context.Context) {
+	results := make([]error, len(c.deps))
+
+	var wg sync.WaitGroup
+	for i := range c.deps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, min(c.interval, maxProbeTimeout))
+			defer cancel()
+			results[i] = c.deps[i].Probe(probeCtx)
+		}(i)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		// probes cancelled by shutdown say nothing about the dependencies
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, dep := range c.deps {
+		err, wasDown := c.down[dep.Name]
+		switch {
+		case results[i] != nil && !wasDown:
+			c.log.Warn("dependency down", "name", dep.Name, "error", results[i])
+		case results[i] != nil && err.Error() != results[i].Error():
+			c.log.Debug("dependency still down", "name", dep.Name, "error", results[i])
+		case results[i] == nil && wasDown:
+			c.log.Info("dependency recovered", "name", dep.Name)
+		}
+
+		if results[i] != nil {
+			c.down[dep.Name] = results[i]
+		} else {
+			delete(c.down, dep.Name)
+		}
+	}
+	c.probed = true
+}
+
+// SetServing takes a service out of (or back into) rotation regardless of
+// its dependencies; "" covers every service
+func (c *Checker) SetServing(service string, serving bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if serving {
+		delete(c.offline, service)
+	} else {
+		c.offline[service] = true
+	}
+}
+
+func (c *Checker) Check(_ context.Context, req *grpchealth.CheckRequest) (*grpchealth.CheckResponse, error) {
+	if req.Service != "" && !slices.Contains(c.services, req.Service) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("unknown service %s", req.Service))
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.serving(req.Service) {
+		return &grpchealth.CheckResponse{Status: grpchealth.StatusServing}, nil
+	}
+	return &grpchealth.CheckResponse{Status: grpchealth.StatusNotServing}, nil
+}
+
+// serving answers for one service, or for the server as a whole when
+// service is "" (which only critical dependencies affect). c.mu must be held.
+func (c *Checker) serving(service string) bool {
+	if c.offline[""] || c.offline[service] {
+		return false
+	}
+
+	for _, dep := range c.deps {
+		if _, down := c.down[dep.Name]; !down {
+			continue
+		}
+		if service == "" && dep.Critical {
+			return false
+		}
+		if service != "" && dep.affects(service) {
+			return false
+		}
+	}
+	return true
+}
+
+// Ready reports whether this instance should get traffic, and if not, why
+func (c *Checker) Ready() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.offline[""] {
+		return errors.New("draining")
+	}
+	if !c.probed {
+		return errors.New("dependencies not checked yet")
+	}
+
+	var reasons []string
+	for _, dep := range c.deps {
+		if err, down := c.down[dep.Name]; down && dep.Critical {
+			reasons = append(reasons, fmt.Sprintf("%s: %v", dep.Name, err))
+		}
+	}
+	if len(reasons) > 0 {
+		return errors.New(strings.Join(reasons, "; "))
+	}
+	return nil
+}
+
+// LivenessHandler answers as long as the process can serve HTTP at all;
+// dependencies being down is no reason to restart it
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok\n"))
+	})
+}
+
+// ReadinessHandler returns 503 while draining, before the first probes and
+// while a critical dependency is down
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if err := c.Ready(); err != nil {
+			http.Error(w, "not ready: "+err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok\n"))
+	})
+}
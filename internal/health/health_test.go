@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"connectrpc.com/grpchealth"
+	"github.com/charmbracelet/log"
+)
+
+func TestCheckerMapsDependencies(t *testing.T) {
+	var dbErr, ocrErr error
+	c := NewChecker(
+		[]string{"null.v1.ReceiptService", "null.v1.AccountService"},
+		[]Dependency{
+			{Name: "database", Probe: func(context.Context) error { return dbErr }, Critical: true},
+			{Name: "ocr", Probe: func(context.Context) error { return ocrErr }, Services: []string{"null.v1.ReceiptService"}},
+		},
+		time.Second,
+		log.New(io.Discard),
+	)
+
+	status := func(service string) grpchealth.Status {
+		t.Helper()
+		resp, err := c.Check(context.Background(), &grpchealth.CheckRequest{Service: service})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Status
+	}
+
+	if err := c.Ready(); err == nil {
+		t.Error("Expected not ready before the first probe")
+	}
+
+	ocrErr = errors.New("connection refused")
+	c.probeAll(context.Background())
+
+	if status("null.v1.ReceiptService") != grpchealth.StatusNotServing {
+		t.Error("Expected ReceiptService down with OCR")
+	}
+	if status("null.v1.AccountService") != grpchealth.StatusServing {
+		t.Error("Expected AccountService unaffected by OCR")
+	}
+	if status("") != grpchealth.StatusServing || c.Ready() != nil {
+		t.Error("Expected a non-critical outage to leave the server ready")
+	}
+
+	dbErr = errors.New("timeout")
+	c.probeAll(context.Background())
+
+	if status("null.v1.AccountService") != grpchealth.StatusNotServing || c.Ready() == nil {
+		t.Error("Expected the database to take everything down")
+	}
+
+	dbErr, ocrErr = nil, nil
+	c.probeAll(context.Background())
+	c.SetServing("", false)
+
+	if status("null.v1.AccountService") != grpchealth.StatusNotServing || c.Ready() == nil {
+		t.Error("Expected draining to override healthy dependencies")
+	}
+
+	if _, err := c.Check(context.Background(), &grpchealth.CheckRequest{Service: "nope"}); err == nil {
+		t.Error("Expected unknown services to be rejected")
+	}
+}
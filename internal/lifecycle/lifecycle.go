@@ -0,0 +1,139 @@
+// Package lifecycle ties the process's background work to SIGINT/SIGTERM
+// and shuts it down in order, within one drain deadline.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+type Manager struct {
+	ctx    context.Context
+	stop   context.CancelFunc
+	cancel context.CancelFunc
+	log    *log.Logger
+
+	mu      sync.Mutex
+	hooks   []hook
+	running map[string]int
+	wg      sync.WaitGroup
+}
+
+// New returns a manager whose Context is cancelled on the first SIGINT or
+// SIGTERM, or by Shutdown
+func New(logger *log.Logger) *Manager {
+	signalled, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := context.WithCancel(signalled)
+
+	return &Manager{
+		ctx:     ctx,
+		stop:    stop,
+		cancel:  cancel,
+		log:     logger,
+		running: make(map[string]int),
+	}
+}
+
+// Context is the root context; background work should stop taking on
+// anything new once it's done
+func (m *Manager) Context() context.Context {
+	return m.ctx
+}
+
+// Done is closed when a shutdown signal arrives
+func (m *Manager) Done() <-chan struct{} {
+	return m.ctx.Done()
+}
+
+// Go runs fn in the background. fn should return once ctx is done, after
+// finishing whatever it's in the middle of; Shutdown waits for it.
+func (m *Manager) Go(name string, fn func(ctx context.Context)) {
+	m.mu.Lock()
+	m.running[name]++
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer func() {
+			m.mu.Lock()
+			m.running[name]--
+			m.mu.Unlock()
+			m.wg.Done()
+		}()
+		fn(m.ctx)
+	}()
+}
+
+// OnShutdown registers fn to run during Shutdown, after the root context is
+// cancelled; hooks run in the order they were added
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// Shutdown cancels the root context, runs the hooks and waits for everything
+// started with Go, all within timeout. A second signal while draining kills
+// the process straight away.
+func (m *Manager) Shutdown(timeout time.Duration) error {
+	m.stop()
+	m.cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	m.mu.Lock()
+	hooks := slices.Clone(m.hooks)
+	m.mu.Unlock()
+
+	var firstErr error
+	for _, h := range hooks {
+		m.log.Debug("running shutdown hook", "name", h.name)
+		if err := h.fn(ctx); err != nil {
+			m.log.Error("shutdown hook failed", "name", h.name, "error", err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", h.name, err)
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return firstErr
+	case <-ctx.Done():
+		return fmt.Errorf("gave up after %s waiting for %v", timeout, m.stillRunning())
+	}
+}
+
+func (m *Manager) stillRunning() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var names []string
+	for name, n := range m.running {
+		if n > 0 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
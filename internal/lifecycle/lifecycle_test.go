@@ -0,0 +1,57 @@
+package lifecycle
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestShutdownOrder(t *testing.T) {
+	m := New(log.New(io.Discard))
+
+	var order []string
+	finished := false
+	m.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond) // finishing the current job
+		finished = true
+	})
+	m.OnShutdown("health", func(ctx context.Context) error {
+		if m.Context().Err() == nil {
+			t.Error("Expected the root context to be cancelled before hooks run")
+		}
+		order = append(order, "health")
+		return nil
+	})
+	m.OnShutdown("http", func(ctx context.Context) error {
+		order = append(order, "http")
+		return nil
+	})
+
+	if err := m.Shutdown(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(order, ",") != "health,http" {
+		t.Errorf("Expected hooks in order, got %v", order)
+	}
+	if !finished {
+		t.Error("Expected Shutdown to wait for the worker")
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	m := New(log.New(io.Discard))
+
+	block := make(chan struct{})
+	defer close(block)
+	m.Go("stuck", func(context.Context) { <-block })
+
+	err := m.Shutdown(20 * time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "stuck") {
+		t.Errorf("Expected a timeout naming the stuck worker, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package logging holds what request-scoped log lines share: the request ID,
+// trace fields, and redaction of request and response payloads.
+package logging
+
+import (
+	"context"
+
+	"null-core/internal/tracing"
+
+	"github.com/charmbracelet/log"
+)
+
+type contextKey struct{}
+
+// WithRequestID returns ctx carrying the ID of the request it serves
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// RequestID returns ctx's request ID, or "" outside a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Fields returns request_id, trace_id and span_id for ctx, whichever are set
+func Fields(ctx context.Context) []any {
+	fields := tracing.LogFields(ctx)
+	if id := RequestID(ctx); id != "" {
+		fields = append([]any{"request_id", id}, fields...)
+	}
+	return fields
+}
+
+// Logger returns logger with ctx's request and trace IDs attached, so every
+// line a request produces can be found together
+func Logger(ctx context.Context, logger *log.Logger) *log.Logger {
+	fields := Fields(ctx)
+	if fields == nil {
+		return logger
+	}
+	return logger.With(fields...)
+}
@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	pb "null-core/internal/gen/null/v1"
+
+	"github.com/charmbracelet/log"
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLoggerAddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(&buf)
+
+	if Logger(context.Background(), logger) != logger {
+		t.Error("Expected the logger back unchanged outside a request")
+	}
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	Logger(ctx, logger).Info("created")
+	if !strings.Contains(buf.String(), "request_id=req-1") {
+		t.Errorf("Expected the request id in the log line, got %q", buf.String())
+	}
+}
+
+func redact(t *testing.T, r *Redactor, msg proto.Message) map[string]any {
+	t.Helper()
+	payload, err := r.Payload(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal([]byte(payload), &out); err != nil {
+		t.Fatalf("Payload is not JSON: %v\n%s", err, payload)
+	}
+	return out
+}
+
+func TestRedactorAllowlist(t *testing.T) {
+	r := NewRedactor([]string{"id", "*_id", "*_at", "total_count"})
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	out := redact(t, r, &pb.ListTransactionsResponse{
+		TotalCount: 1,
+		Transactions: []*pb.Transaction{{
+			Id:          7,
+			AccountId:   3,
+			TxAmount:    &money.Money{CurrencyCode: "CAD", Units: 42},
+			Description: proto.String("rent"),
+			UserNotes:   proto.String("for march"),
+			Direction:   pb.TransactionDirection_DIRECTION_OUTGOING,
+			CreatedAt:   timestamppb.New(created),
+		}},
+	})
+
+	if out["total_count"] != "1" {
+		t.Errorf("Expected total_count logged as-is, got %v", out["total_count"])
+	}
+
+	txs, ok := out["transactions"].([]any)
+	if !ok || len(txs) != 1 {
+		t.Fatalf("Expected the transactions list to be walked, got %v", out["transactions"])
+	}
+	tx := txs[0].(map[string]any)
+
+	want := map[string]any{
+		"id":          "7",
+		"account_id":  "3",
+		"direction":   "DIRECTION_OUTGOING",
+		"created_at":  "2026-03-01T12:00:00Z",
+		"tx_amount":   Redacted,
+		"description": Redacted,
+		"user_notes":  Redacted,
+	}
+	for field, v := range want {
+		if tx[field] != v {
+			t.Errorf("%s: expected %v, got %v", field, v, tx[field])
+		}
+	}
+}
+
+func TestRedactorPaths(t *testing.T) {
+	r := NewRedactor([]string{"transaction.description"})
+
+	out := redact(t, r, &pb.UpdateTransactionRequest{
+		Description: proto.String("top level"),
+	})
+	if out["description"] != Redacted {
+		t.Errorf("Expected a path pattern not to match elsewhere, got %v", out["description"])
+	}
+}
+
+func TestRedactorDebugRedact(t *testing.T) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("redact_test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Login"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("user_id"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				JsonName: proto.String("userId"),
+				Options:  &descriptorpb.FieldOptions{DebugRedact: proto.Bool(true)},
+			}},
+		}},
+	}
+	fd, err := protodesc.NewFile(file, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := dynamicpb.NewMessage(fd.Messages().Get(0))
+	msg.Set(fd.Messages().Get(0).Fields().Get(0), protoreflect.ValueOfString("u-1"))
+
+	out := redact(t, NewRedactor([]string{"*"}), msg)
+	if out["user_id"] != Redacted {
+		t.Errorf("Expected debug_redact to beat the allowlist, got %v", out["user_id"])
+	}
+}
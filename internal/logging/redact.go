@@ -0,0 +1,129 @@
+package logging
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Redacted replaces the value of every field that isn't allowed
+const Redacted = "[redacted]"
+
+// Redactor renders proto messages for logs with only allowlisted fields
+// visible. A pattern without a dot matches field names anywhere ("id",
+// "*_id"); one with a dot matches the path from the top ("transaction.amount").
+// Nested messages are walked, bools and enums are always shown, and fields
+// marked [debug_redact = true] are always hidden.
+type Redactor struct {
+	allow     []string
+	marshaler protojson.MarshalOptions
+}
+
+func NewRedactor(allow []string) *Redactor {
+	return &Redactor{
+		allow:     allow,
+		marshaler: protojson.MarshalOptions{UseProtoNames: true},
+	}
+}
+
+// Payload renders msg as JSON with disallowed values replaced by Redacted
+func (r *Redactor) Payload(msg proto.Message) (string, error) {
+	out, err := r.message(msg.ProtoReflect(), "")
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(out)
+	return string(data), err
+}
+
+// message renders m's allowed fields through protojson, so they look like
+// they would on the wire, and fills in the rest field by field
+func (r *Redactor) message(m protoreflect.Message, prefix string) (map[string]any, error) {
+	kept := m.Type().New()
+	out := make(map[string]any)
+
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		fieldPath := name
+		if prefix != "" {
+			fieldPath = prefix + "." + name
+		}
+
+		switch {
+		case debugRedact(fd):
+			out[name] = Redacted
+		case walked(fd) && fd.IsList():
+			list := v.List()
+			items := make([]any, list.Len())
+			for i := range items {
+				if items[i], err = r.message(list.Get(i).Message(), fieldPath); err != nil {
+					return false
+				}
+			}
+			out[name] = items
+		case walked(fd):
+			if out[name], err = r.message(v.Message(), fieldPath); err != nil {
+				return false
+			}
+		case r.allowed(fd, fieldPath):
+			kept.Set(fd, v)
+		default:
+			out[name] = Redacted
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := r.marshaler.Marshal(kept.Interface())
+	if err != nil {
+		return nil, err
+	}
+	var plain map[string]json.RawMessage
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return nil, err
+	}
+	for name, raw := range plain {
+		out[name] = raw
+	}
+	return out, nil
+}
+
+func (r *Redactor) allowed(fd protoreflect.FieldDescriptor, fieldPath string) bool {
+	if !fd.IsMap() && (fd.Kind() == protoreflect.BoolKind || fd.Kind() == protoreflect.EnumKind) {
+		return true
+	}
+
+	name := string(fd.Name())
+	for _, pattern := range r.allow {
+		subject := name
+		if strings.Contains(pattern, ".") {
+			subject = fieldPath
+		}
+		if ok, _ := path.Match(pattern, subject); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// walked reports whether fd holds messages of our own to redact field by
+// field; well-known types like Timestamp and Money are treated as values
+func walked(fd protoreflect.FieldDescriptor) bool {
+	if fd.IsMap() || fd.Kind() != protoreflect.MessageKind {
+		return false
+	}
+	return !strings.HasPrefix(string(fd.Message().FullName()), "google.")
+}
+
+func debugRedact(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	return ok && opts.GetDebugRedact()
+}
@@ -0,0 +1,93 @@
+package merchants
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"null-core/internal/db/sqlc"
+)
+
+// Matcher resolves descriptors to one of a user's merchants
+type Matcher struct {
+	patterns []patternEntry
+	aliases  []aliasEntry
+}
+
+type patternEntry struct {
+	re       *regexp.Regexp
+	merchant *sqlc.Merchant
+}
+
+type aliasEntry struct {
+	alias    string
+	merchant *sqlc.Merchant
+}
+
+// NewMatcher prepares merchants for matching. Invalid patterns are skipped
+// and reported together in the returned error; the matcher is still usable.
+func NewMatcher(rows []sqlc.Merchant) (*Matcher, error) {
+	m := &Matcher{}
+
+	var errs []error
+	for i := range rows {
+		merchant := &rows[i]
+
+		for _, pattern := range merchant.Patterns {
+			re, err := regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("merchant %d pattern %q: %w", merchant.ID, pattern, err))
+				continue
+			}
+			m.patterns = append(m.patterns, patternEntry{re: re, merchant: merchant})
+		}
+
+		// the canonical name always acts as an alias
+		seen := make(map[string]bool)
+		for _, alias := range append([]string{merchant.Name}, merchant.Aliases...) {
+			normalized := Normalize(alias)
+			if normalized == "" || seen[normalized] {
+				continue
+			}
+			seen[normalized] = true
+			m.aliases = append(m.aliases, aliasEntry{alias: normalized, merchant: merchant})
+		}
+	}
+
+	// most specific alias first, so "blue bottle coffee" beats "blue bottle"
+	sort.SliceStable(m.aliases, func(i, j int) bool {
+		return len(m.aliases[i].alias) > len(m.aliases[j].alias)
+	})
+
+	return m, errors.Join(errs...)
+}
+
+// Match returns the merchant for a raw descriptor, or nil. Patterns are
+// tried first since they're explicit, then aliases.
+func (m *Matcher) Match(descriptor string) *sqlc.Merchant {
+	if m == nil || descriptor == "" {
+		return nil
+	}
+
+	for _, p := range m.patterns {
+		if p.re.MatchString(descriptor) {
+			return p.merchant
+		}
+	}
+
+	normalized := Normalize(descriptor)
+	if normalized == "" {
+		return nil
+	}
+
+	padded := " " + normalized + " "
+	for _, a := range m.aliases {
+		if strings.Contains(padded, " "+a.alias+" ") {
+			return a.merchant
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package merchants
+
+import (
+	"testing"
+
+	"null-core/internal/db/sqlc"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"SQ *BLUE BOTTLE 0423 SAN FRANCISCO", "blue bottle san francisco"},
+		{"BLUE BOTTLE COFFEE #12", "blue bottle coffee"},
+		{"TST* Joe's Pizza - 2231", "joe's pizza"},
+		{"PAYPAL *SPOTIFY", "spotify"},
+		{"AMZN Mktp CA*2K4LL1Q40", "amzn mktp ca"},
+		{"#1234 0423", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Normalize(tt.input); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	rows := []sqlc.Merchant{
+		{ID: 1, Name: "Blue Bottle"},
+		{ID: 2, Name: "Blue Bottle Coffee Roasters", Aliases: []string{"blue bottle coffee"}},
+		{ID: 3, Name: "Amazon", Patterns: []string{`^AMZN\s+Mktp`, `^AMAZON\.`}},
+		{ID: 4, Name: "Broken", Patterns: []string{"[invalid"}},
+	}
+
+	matcher, err := NewMatcher(rows)
+	if err == nil {
+		t.Errorf("Expected error for invalid pattern")
+	}
+
+	tests := []struct {
+		descriptor string
+		expected   int64
+	}{
+		{"SQ *BLUE BOTTLE 0423 SAN FRANCISCO", 1},
+		{"BLUE BOTTLE COFFEE #12", 2},
+		{"AMZN Mktp CA*2K4LL1Q40", 3},
+		{"amazon.ca prime", 3},
+		{"BLUEBOTTLE", 0},
+		{"STARBUCKS #22", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.descriptor, func(t *testing.T) {
+			got := matcher.Match(tt.descriptor)
+			if tt.expected == 0 {
+				if got != nil {
+					t.Errorf("Expected no match, got merchant %d", got.ID)
+				}
+				return
+			}
+			if got == nil || got.ID != tt.expected {
+				t.Errorf("Expected merchant %d, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Package merchants turns raw bank descriptors into canonical merchants.
+//
+// A descriptor like "SQ *BLUE BOTTLE 0423 SAN FRANCISCO" is first cleaned up
+// by Normalize (processor prefixes, store numbers and punctuation removed),
+// then resolved against the user's merchants by a Matcher using either
+// aliases (word-aligned substrings of the normalized descriptor) or regex
+// patterns (matched against the raw descriptor).
+package merchants
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// processorPrefix matches payment processor markers that precede the real
+// merchant name, e.g. "SQ *", "TST* ", "PAYPAL *", "POS PURCHASE ". short
+// codes only count when followed by '*' so "IN N OUT" survives
+var processorPrefix = regexp.MustCompile(`(?i)^\s*(?:(?:sq|tst|sp|pp|paypal|py|in|dd|sumup|zettle|ckc|wpy)\s*\*|(?:pos purchase|pos|pre-auth|preauth|fpos)\s)\s*`)
+
+// Normalize returns a lowercase, whitespace-collapsed version of a
+// descriptor with processor prefixes, store/terminal numbers and
+// punctuation stripped. It returns "" when nothing meaningful is left.
+func Normalize(descriptor string) string {
+	s := processorPrefix.ReplaceAllString(descriptor, "")
+	s = strings.ToLower(s)
+
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '*' || r == '/' || r == ',' || r == '.' || r == '-' || r == '_'
+	})
+
+	kept := words[:0]
+	for _, w := range words {
+		// store numbers, dates, reference codes: "#12", "0423", "12/05", "x4821"
+		if strings.HasPrefix(w, "#") || hasDigit(w) {
+			continue
+		}
+
+		w = strings.TrimFunc(w, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '&'
+		})
+		if w == "" {
+			continue
+		}
+		kept = append(kept, w)
+	}
+
+	return strings.Join(kept, " ")
+}
+
+func hasDigit(s string) bool {
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
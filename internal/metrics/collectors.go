@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// how long a scrape may wait on the database
+const collectTimeout = 2 * time.Second
+
+// ----- pgx pool ----------------------------------------------------------------------------
+
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	conns         *prometheus.Desc
+	maxConns      *prometheus.Desc
+	acquires      *prometheus.Desc
+	acquireWait   *prometheus.Desc
+	emptyAcquires *prometheus.Desc
+}
+
+// RegisterPool exports pool's connection stats
+func RegisterPool(pool *pgxpool.Pool) {
+	name := func(n string) string { return prometheus.BuildFQName(namespace, "db_pool", n) }
+
+	Registry.MustRegister(&poolCollector{
+		pool:          pool,
+		conns:         prometheus.NewDesc(name("connections"), "Pool connections by state (acquired, idle, constructing).", []string{"state"}, nil),
+		maxConns:      prometheus.NewDesc(name("max_connections"), "Largest size the pool may grow to.", nil, nil),
+		acquires:      prometheus.NewDesc(name("acquires_total"), "Connections acquired from the pool.", nil, nil),
+		acquireWait:   prometheus.NewDesc(name("acquire_wait_seconds_total"), "Total time spent acquiring connections.", nil, nil),
+		emptyAcquires: prometheus.NewDesc(name("empty_acquires_total"), "Acquires that had to wait because the pool was empty.", nil, nil),
+	})
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.conns
+	ch <- c.maxConns
+	ch <- c.acquires
+	ch <- c.acquireWait
+	ch <- c.emptyAcquires
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(stat.AcquiredConns()), "acquired")
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(stat.IdleConns()), "idle")
+	ch <- prometheus.MustNewConstMetric(c.conns, prometheus.GaugeValue, float64(stat.ConstructingConns()), "constructing")
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireWait, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+}
+
+// ----- receipt queue -----------------------------------------------------------------------
+
+// QueueDepth counts receipts waiting on the OCR worker or on a person
+type QueueDepth struct {
+	Pending     int64 // not parsed yet, including ones backing off
+	Due         int64 // pending and ready to be claimed now
+	NeedsReview int64
+}
+
+type queueCollector struct {
+	depth func(ctx context.Context) (QueueDepth, error)
+	desc  *prometheus.Desc
+}
+
+// RegisterReceiptQueue exports the receipt queue depth, counted on every
+// scrape
+func RegisterReceiptQueue(depth func(ctx context.Context) (QueueDepth, error)) {
+	Registry.MustRegister(&queueCollector{
+		depth: depth,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "receipts", "queue_depth"),
+			"Receipts by queue state (pending, due, needs_review).",
+			[]string{"state"}, nil,
+		),
+	})
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	depth, err := c.depth(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(depth.Pending), "pending")
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(depth.Due), "due")
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(depth.NeedsReview), "needs_review")
+}
@@ -0,0 +1,82 @@
+// Package metrics defines what null-core exports to Prometheus. Everything
+// is registered on Registry rather than the global default, so only our own
+// metrics (plus the Go runtime's) end up on /metrics.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "null"
+
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+var (
+	RPCRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "requests_total",
+		Help:      "Connect RPCs handled, by procedure and result code.",
+	}, []string{"procedure", "code"})
+
+	RPCDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "duration_seconds",
+		Help:      "Time spent handling connect RPCs.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"procedure"})
+
+	OCRDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "receipts",
+		Name:      "ocr_duration_seconds",
+		Help:      "Time the receipt parser took per receipt, by result (ok, error, manual).",
+		Buckets:   []float64{.5, 1, 2, 5, 10, 20, 30, 60, 120, 300},
+	}, []string{"result"})
+
+	ExchangeRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "exchange",
+		Name:      "requests_total",
+		Help:      "Calls to the exchange rate API, by endpoint and result (ok, error).",
+	}, []string{"endpoint", "result"})
+
+	RuleEvaluation = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rules",
+		Name:      "evaluation_seconds",
+		Help:      "Time spent matching transactions against a user's rules, by mode (single, bulk).",
+		Buckets:   prometheus.ExponentialBuckets(.00001, 4, 10),
+	}, []string{"mode"})
+)
+
+// Result turns an error into the result label used across these metrics
+func Result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+// Handler serves Registry. A collector failing (the database being down,
+// say) drops its metrics from the scrape instead of failing all of it.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
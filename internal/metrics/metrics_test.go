@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func scrape(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	return string(body)
+}
+
+func TestReceiptQueue(t *testing.T) {
+	var fail bool
+	RegisterReceiptQueue(func(context.Context) (QueueDepth, error) {
+		if fail {
+			return QueueDepth{}, errors.New("database down")
+		}
+		return QueueDepth{Pending: 4, Due: 3, NeedsReview: 2}, nil
+	})
+	ExchangeRequests.WithLabelValues("rates", Result(nil)).Inc()
+
+	body := scrape(t)
+	for _, want := range []string{
+		`null_receipts_queue_depth{state="pending"} 4`,
+		`null_receipts_queue_depth{state="due"} 3`,
+		`null_receipts_queue_depth{state="needs_review"} 2`,
+		`null_exchange_requests_total{endpoint="rates",result="ok"} 1`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %q in scrape, got:\n%s", want, body)
+		}
+	}
+
+	// a failing collector drops out but the rest is still served
+	fail = true
+	body = scrape(t)
+	if strings.Contains(body, "null_receipts_queue_depth{") {
+		t.Errorf("Expected no queue depth while the database is down")
+	}
+	if !strings.Contains(body, "null_exchange_requests_total") {
+		t.Errorf("Expected other metrics to survive a failing collector")
+	}
+}
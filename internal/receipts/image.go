@@ -0,0 +1,203 @@
+package receipts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+
+	"github.com/gen2brain/heic"
+)
+
+func init() {
+	// heic registers the plain "heic" brand only; iPhones also write heix,
+	// and generic HEIF files lead with mif1/msf1
+	for _, brand := range []string{"heix", "hevc", "hevx", "mif1", "msf1"} {
+		image.RegisterFormat("heic", "????ftyp"+brand, heic.Decode, heic.DecodeConfig)
+	}
+}
+
+// DecodeImage decodes a receipt photo (any registered format, HEIC
+// included) and turns it upright according to its EXIF orientation.
+// rotated is true when the pixels no longer match the encoded image, so the
+// original bytes can't be stored as-is.
+func DecodeImage(data []byte) (img image.Image, format string, rotated bool, err error) {
+	img, format, err = image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	// libheif already applies the container's irot/imir boxes, which take
+	// precedence over the EXIF tag in HEIF files
+	if format == "heic" {
+		return img, format, false, nil
+	}
+
+	orientation := exifOrientation(data)
+	if orientation <= 1 || orientation > 8 {
+		return img, format, false, nil
+	}
+	return orient(img, orientation), format, true, nil
+}
+
+// ----- exif --------------------------------------------------------------------------------
+
+const exifOrientationTag = 0x0112
+
+// exifOrientation finds the EXIF orientation of a JPEG, PNG or WebP file;
+// 0 when there is none
+func exifOrientation(data []byte) int {
+	switch {
+	case len(data) > 2 && data[0] == 0xFF && data[1] == 0xD8:
+		return tiffOrientation(jpegExif(data))
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return tiffOrientation(pngExif(data))
+	case len(data) > 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return tiffOrientation(webpExif(data))
+	}
+	return 0
+}
+
+// jpegExif returns the TIFF payload of the APP1 Exif segment
+func jpegExif(data []byte) []byte {
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		// start of scan: metadata segments are all before it
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		if size < 2 || i+2+size > len(data) {
+			return nil
+		}
+		segment := data[i+4 : i+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		i += 2 + size
+	}
+	return nil
+}
+
+// pngExif returns the eXIf chunk, which holds a bare TIFF structure
+func pngExif(data []byte) []byte {
+	for i := 8; i+8 <= len(data); {
+		size := int(binary.BigEndian.Uint32(data[i:]))
+		kind := string(data[i+4 : i+8])
+		if size < 0 || i+8+size > len(data) {
+			return nil
+		}
+		if kind == "eXIf" {
+			return data[i+8 : i+8+size]
+		}
+		if kind == "IDAT" || kind == "IEND" {
+			// eXIf must come before the image data
+			return nil
+		}
+		i += 12 + size
+	}
+	return nil
+}
+
+// webpExif returns the EXIF chunk of an extended (VP8X) WebP
+func webpExif(data []byte) []byte {
+	for i := 12; i+8 <= len(data); {
+		kind := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		if size < 0 || i+8+size > len(data) {
+			return nil
+		}
+		if kind == "EXIF" {
+			// some writers keep the JPEG-style header
+			return bytes.TrimPrefix(data[i+8:i+8+size], []byte("Exif\x00\x00"))
+		}
+		i += 8 + size + size%2
+	}
+	return nil
+}
+
+// tiffOrientation reads the orientation tag from IFD0 of a TIFF header
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			// SHORT, stored left-aligned in the value field
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
+
+// ----- orientation -------------------------------------------------------------------------
+
+// orient applies EXIF orientation 2-8 so the result displays upright:
+// 2/4 mirror, 3 turns 180°, 6/8 turn 90° clockwise/counter-clockwise and
+// 5/7 transpose across either diagonal
+func orient(img image.Image, orientation int) image.Image {
+	b := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			si := src.PixOffset(sx, sy)
+			di := dst.PixOffset(x, y)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,197 @@
+package receipts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+var (
+	red  = color.RGBA{255, 0, 0, 255}
+	blue = color.RGBA{0, 0, 255, 255}
+)
+
+// tiffWithOrientation builds a minimal little-endian TIFF header holding
+// only the orientation tag
+func tiffWithOrientation(o uint16) []byte {
+	b := make([]byte, 26)
+	copy(b, "II")
+	binary.LittleEndian.PutUint16(b[2:], 42)
+	binary.LittleEndian.PutUint32(b[4:], 8)
+	binary.LittleEndian.PutUint16(b[8:], 1)
+	binary.LittleEndian.PutUint16(b[10:], exifOrientationTag)
+	binary.LittleEndian.PutUint16(b[12:], 3) // SHORT
+	binary.LittleEndian.PutUint32(b[14:], 1)
+	binary.LittleEndian.PutUint16(b[18:], o)
+	return b
+}
+
+// halves is w x h, red on the left half and blue on the right
+func halves(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < w/2 {
+				img.Set(x, y, red)
+			} else {
+				img.Set(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+func jpegWithOrientation(t *testing.T, img image.Image, o uint16) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	payload := append([]byte("Exif\x00\x00"), tiffWithOrientation(o)...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(payload)+2))
+	app1 = append(app1, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+func pngWithOrientation(t *testing.T, img image.Image, o uint16) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// eXIf goes right after IHDR (8 byte signature + 25 byte chunk)
+	tiff := tiffWithOrientation(o)
+	chunk := make([]byte, 8, 12+len(tiff))
+	binary.BigEndian.PutUint32(chunk, uint32(len(tiff)))
+	copy(chunk[4:], "eXIf")
+	chunk = append(chunk, tiff...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	out := append([]byte{}, data[:33]...)
+	out = append(out, chunk...)
+	return append(out, data[33:]...)
+}
+
+func near(c color.Color, want color.RGBA) bool {
+	r, g, b, _ := c.RGBA()
+	diff := func(a uint32, b uint8) bool {
+		d := int(a>>8) - int(b)
+		return d > -40 && d < 40
+	}
+	return diff(r, want.R) && diff(g, want.G) && diff(b, want.B)
+}
+
+func TestExifOrientation(t *testing.T) {
+	img := halves(16, 8)
+
+	if got := exifOrientation(jpegWithOrientation(t, img, 6)); got != 6 {
+		t.Errorf("Expected JPEG orientation 6, got %d", got)
+	}
+	if got := exifOrientation(pngWithOrientation(t, img, 3)); got != 3 {
+		t.Errorf("Expected PNG orientation 3, got %d", got)
+	}
+
+	var plain bytes.Buffer
+	jpeg.Encode(&plain, img, nil)
+	if got := exifOrientation(plain.Bytes()); got != 0 {
+		t.Errorf("Expected no orientation, got %d", got)
+	}
+
+	webp := []byte("RIFF\x00\x00\x00\x00WEBPVP8X\x0a\x00\x00\x00")
+	webp = append(webp, make([]byte, 10)...)
+	tiff := append([]byte("Exif\x00\x00"), tiffWithOrientation(8)...)
+	webp = append(webp, "EXIF"...)
+	webp = binary.LittleEndian.AppendUint32(webp, uint32(len(tiff)))
+	webp = append(webp, tiff...)
+	if got := exifOrientation(webp); got != 8 {
+		t.Errorf("Expected WebP orientation 8, got %d", got)
+	}
+}
+
+func TestDecodeImageRotates(t *testing.T) {
+	img := halves(16, 8)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		w, h        int
+		topLeft     color.RGBA
+		bottomRight color.RGBA
+	}{
+		// 6: turn 90° clockwise, the left (red) half ends up on top
+		{"jpeg 6", jpegWithOrientation(t, img, 6), 8, 16, red, blue},
+		// 8: turn 90° counter-clockwise, the right (blue) half ends up on top
+		{"jpeg 8", jpegWithOrientation(t, img, 8), 8, 16, blue, red},
+		{"png 3", pngWithOrientation(t, img, 3), 16, 8, blue, red},
+		{"png 2", pngWithOrientation(t, img, 2), 16, 8, blue, red},
+		{"png 1", pngWithOrientation(t, img, 1), 16, 8, red, blue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, rotated, err := DecodeImage(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b := got.Bounds()
+			if b.Dx() != tt.w || b.Dy() != tt.h {
+				t.Fatalf("Expected %dx%d, got %dx%d", tt.w, tt.h, b.Dx(), b.Dy())
+			}
+			if rotated != (tt.name != "png 1") {
+				t.Errorf("Expected rotated=%v, got %v", tt.name != "png 1", rotated)
+			}
+			if !near(got.At(b.Min.X, b.Min.Y), tt.topLeft) {
+				t.Errorf("Expected top left %v, got %v", tt.topLeft, got.At(b.Min.X, b.Min.Y))
+			}
+			if !near(got.At(b.Max.X-1, b.Max.Y-1), tt.bottomRight) {
+				t.Errorf("Expected bottom right %v, got %v", tt.bottomRight, got.At(b.Max.X-1, b.Max.Y-1))
+			}
+		})
+	}
+}
+
+func TestOrientTransposes(t *testing.T) {
+	// 2x3 with distinct pixels, checked against the EXIF spec's corner
+	// mapping for the diagonal orientations
+	src := image.NewRGBA(image.Rect(0, 0, 2, 3))
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 2; x++ {
+			src.Set(x, y, color.RGBA{uint8(x), uint8(y), 0, 255})
+		}
+	}
+
+	tests := []struct {
+		orientation int
+		// source pixel expected at the destination's top left
+		x, y uint8
+	}{
+		{5, 0, 0},
+		{6, 0, 2},
+		{7, 1, 2},
+		{8, 1, 0},
+	}
+
+	for _, tt := range tests {
+		got := orient(src, tt.orientation).(*image.RGBA)
+		if got.Bounds().Dx() != 3 || got.Bounds().Dy() != 2 {
+			t.Errorf("orientation %d: expected 3x2, got %v", tt.orientation, got.Bounds())
+			continue
+		}
+		c := got.RGBAAt(0, 0)
+		if c.R != tt.x || c.G != tt.y {
+			t.Errorf("orientation %d: expected source (%d,%d) at top left, got (%d,%d)", tt.orientation, tt.x, tt.y, c.R, c.G)
+		}
+	}
+}
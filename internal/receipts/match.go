@@ -0,0 +1,209 @@
+// Package receipts holds receipt logic that doesn't need the database:
+// scoring transactions as link candidates for a parsed receipt, checking
+// parses for review, reading PDF text and tracking item prices.
+package receipts
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"null-core/internal/db/sqlc"
+	"null-core/internal/merchants"
+)
+
+const (
+	// DateWindowDays is how far either side of the receipt date to look;
+	// card transactions usually post within a few days of the purchase
+	DateWindowDays = 4
+	// MaxTipRatio is the largest tip, as a share of the receipt total, that
+	// still counts as the same purchase
+	MaxTipRatio = 0.30
+	// FXTolerance covers rate drift between the receipt's currency and the
+	// amount the bank charged
+	FXTolerance = 0.05
+	// AutoLinkThreshold is the minimum score for linking without asking
+	AutoLinkThreshold = 0.85
+)
+
+// Candidate is a transaction scored against a receipt
+type Candidate struct {
+	Tx              *sqlc.ListReceiptLinkCandidatesRow
+	Score           float64
+	AmountDiffCents int64
+	DateDiffDays    int32
+}
+
+// SearchWindow returns the date and amount bounds to fetch candidate
+// transactions with. ok is false when the receipt has no total to match on.
+// Receipts without a parsed date are anchored on their upload time.
+func SearchWindow(receipt *sqlc.Receipt) (start, end time.Time, minCents, maxCents int64, ok bool) {
+	if receipt.TotalCents == nil || *receipt.TotalCents <= 0 {
+		return time.Time{}, time.Time{}, 0, 0, false
+	}
+
+	anchor := receiptDay(receipt)
+	start = anchor.AddDate(0, 0, -DateWindowDays)
+	end = anchor.AddDate(0, 0, DateWindowDays+1)
+
+	total := float64(*receipt.TotalCents)
+	minCents = int64(math.Floor(total * (1 - FXTolerance)))
+	maxCents = int64(math.Ceil(total * (1 + MaxTipRatio + FXTolerance)))
+
+	return start, end, minCents, maxCents, true
+}
+
+// Rank scores every transaction against the receipt and returns the ones
+// that could plausibly match, best first
+func Rank(receipt *sqlc.Receipt, txs []sqlc.ListReceiptLinkCandidatesRow) []Candidate {
+	if receipt.TotalCents == nil || *receipt.TotalCents <= 0 {
+		return nil
+	}
+
+	candidates := make([]Candidate, 0, len(txs))
+	for i := range txs {
+		c := score(receipt, &txs[i])
+		if c.Score > 0 {
+			candidates = append(candidates, c)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	return candidates
+}
+
+// AutoLinkTarget returns the candidate to link automatically: the only one
+// scoring at or above AutoLinkThreshold. Ambiguous receipts are left alone.
+func AutoLinkTarget(candidates []Candidate) (*Candidate, bool) {
+	var target *Candidate
+	for i := range candidates {
+		if candidates[i].Score < AutoLinkThreshold {
+			continue
+		}
+		if target != nil {
+			return nil, false
+		}
+		target = &candidates[i]
+	}
+	return target, target != nil
+}
+
+// ----- internal helpers --------------------------------------------------------------------
+
+func score(receipt *sqlc.Receipt, tx *sqlc.ListReceiptLinkCandidatesRow) Candidate {
+	total := *receipt.TotalCents
+	charged := chargedCents(receipt, tx)
+
+	c := Candidate{
+		Tx:              tx,
+		AmountDiffCents: charged - total,
+		DateDiffDays:    dayDiff(receiptDay(receipt), tx.TxDate),
+	}
+
+	amount := amountScore(total, charged)
+	if amount == 0 {
+		return c
+	}
+	date := dateScore(c.DateDiffDays)
+
+	// without a parsed merchant, amount and date carry all the weight
+	if receipt.Merchant == nil || merchants.Normalize(*receipt.Merchant) == "" {
+		c.Score = 0.65*amount + 0.35*date
+		return c
+	}
+
+	c.Score = 0.5*amount + 0.25*date + 0.25*merchantSimilarity(*receipt.Merchant, tx)
+	return c
+}
+
+// chargedCents is the transaction amount in the receipt's currency when the
+// bank recorded one, otherwise the settled amount
+func chargedCents(receipt *sqlc.Receipt, tx *sqlc.ListReceiptLinkCandidatesRow) int64 {
+	if receipt.Currency != nil && tx.ForeignCurrency != nil && tx.ForeignAmountCents != nil &&
+		strings.EqualFold(*receipt.Currency, *tx.ForeignCurrency) {
+		return *tx.ForeignAmountCents
+	}
+	return tx.TxAmountCents
+}
+
+// amountScore rates how well the charged amount explains the receipt total:
+// exact beats rounding/FX noise beats a tip on top
+func amountScore(total, charged int64) float64 {
+	diff := charged - total
+	ratio := float64(diff) / float64(total)
+
+	switch {
+	case diff == 0:
+		return 1
+	case math.Abs(ratio) <= 0.01:
+		return 0.9
+	case ratio > 0 && ratio <= MaxTipRatio:
+		return 0.8 - ratio
+	case math.Abs(ratio) <= FXTolerance:
+		return 0.6
+	default:
+		return 0
+	}
+}
+
+func dateScore(days int32) float64 {
+	return math.Max(0, 1-0.2*float64(days))
+}
+
+// merchantSimilarity is the token overlap between the receipt's merchant and
+// the transaction's merchant or description, whichever is closer
+func merchantSimilarity(receiptMerchant string, tx *sqlc.ListReceiptLinkCandidatesRow) float64 {
+	want := strings.Fields(merchants.Normalize(receiptMerchant))
+
+	best := 0.0
+	for _, s := range []*string{tx.Merchant, tx.TxDesc} {
+		if s == nil {
+			continue
+		}
+		best = math.Max(best, overlap(want, strings.Fields(merchants.Normalize(*s))))
+	}
+	return best
+}
+
+// overlap is |a ∩ b| / min(|a|, |b|), so "costco" fully matches "costco wholesale"
+func overlap(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, t := range a {
+		set[t] = true
+	}
+
+	shared := 0
+	for _, t := range b {
+		if set[t] {
+			shared++
+			delete(set, t)
+		}
+	}
+
+	return float64(shared) / float64(min(len(a), len(b)))
+}
+
+func receiptDay(receipt *sqlc.Receipt) time.Time {
+	if receipt.ReceiptDate != nil {
+		return truncateDay(*receipt.ReceiptDate)
+	}
+	return truncateDay(receipt.CreatedAt)
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func dayDiff(a, b time.Time) int32 {
+	days := truncateDay(b).Sub(truncateDay(a)).Hours() / 24
+	return int32(math.Abs(math.Round(days)))
+}
@@ -0,0 +1,96 @@
+package receipts
+
+import (
+	"testing"
+	"time"
+
+	"null-core/internal/db/sqlc"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func candidateTx(id int64, date time.Time, cents int64, merchant string) sqlc.ListReceiptLinkCandidatesRow {
+	return sqlc.ListReceiptLinkCandidatesRow{
+		ID:            id,
+		TxDate:        date,
+		TxAmountCents: cents,
+		TxCurrency:    "CAD",
+		TxDesc:        ptr(merchant),
+	}
+}
+
+func TestRank(t *testing.T) {
+	day := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	receipt := &sqlc.Receipt{
+		Merchant:    ptr("COSTCO WHOLESALE #552"),
+		ReceiptDate: ptr(day),
+		TotalCents:  ptr(int64(12345)),
+		Currency:    ptr("CAD"),
+	}
+
+	txs := []sqlc.ListReceiptLinkCandidatesRow{
+		candidateTx(1, day.Add(3*24*time.Hour), 12345, "UBER EATS"),
+		candidateTx(2, day.Add(20*time.Hour), 12345, "COSTCO WHSE 552"),
+		candidateTx(3, day, 14200, "COSTCO WHOLESALE"),
+		candidateTx(4, day, 99999, "COSTCO WHOLESALE"),
+	}
+
+	ranked := Rank(receipt, txs)
+	if len(ranked) != 3 {
+		t.Fatalf("Expected 3 plausible candidates, got %d", len(ranked))
+	}
+	if ranked[0].Tx.ID != 2 {
+		t.Errorf("Expected exact amount and merchant to rank first, got tx %d", ranked[0].Tx.ID)
+	}
+	if ranked[0].DateDiffDays != 0 || ranked[0].AmountDiffCents != 0 {
+		t.Errorf("Expected same day / 0 cents diff, got %d / %d", ranked[0].DateDiffDays, ranked[0].AmountDiffCents)
+	}
+
+	target, ok := AutoLinkTarget(ranked)
+	if !ok || target.Tx.ID != 2 {
+		t.Errorf("Expected auto-link to tx 2, got %+v", target)
+	}
+}
+
+func TestAutoLinkAmbiguous(t *testing.T) {
+	day := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	receipt := &sqlc.Receipt{
+		Merchant:    ptr("Starbucks"),
+		ReceiptDate: ptr(day),
+		TotalCents:  ptr(int64(575)),
+	}
+
+	// two identical coffees on the same day: don't guess
+	ranked := Rank(receipt, []sqlc.ListReceiptLinkCandidatesRow{
+		candidateTx(1, day, 575, "STARBUCKS #1234"),
+		candidateTx(2, day, 575, "STARBUCKS #1234"),
+	})
+
+	if _, ok := AutoLinkTarget(ranked); ok {
+		t.Errorf("Expected no auto-link with two equally good candidates")
+	}
+}
+
+func TestForeignAmount(t *testing.T) {
+	day := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+	receipt := &sqlc.Receipt{
+		ReceiptDate: ptr(day),
+		TotalCents:  ptr(int64(2000)),
+		Currency:    ptr("USD"),
+	}
+
+	tx := candidateTx(1, day, 2730, "SOME SHOP NYC")
+	tx.ForeignAmountCents = ptr(int64(2000))
+	tx.ForeignCurrency = ptr("USD")
+
+	ranked := Rank(receipt, []sqlc.ListReceiptLinkCandidatesRow{tx})
+	if len(ranked) != 1 || ranked[0].AmountDiffCents != 0 {
+		t.Fatalf("Expected foreign amount to match exactly, got %+v", ranked)
+	}
+}
+
+func TestSearchWindowNoTotal(t *testing.T) {
+	if _, _, _, _, ok := SearchWindow(&sqlc.Receipt{}); ok {
+		t.Errorf("Expected no search window without a total")
+	}
+}
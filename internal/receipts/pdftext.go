@@ -0,0 +1,314 @@
+package receipts
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// minPDFTextChars is how many letters/digits a PDF needs before its embedded
+// text is trusted over OCR; scanned PDFs have none, or only a header
+const minPDFTextChars = 40
+
+// inflate limits: a few KB of deflate can expand to gigabytes, and no
+// receipt needs more than this to draw its text
+const (
+	maxPDFStreamBytes = 4 << 20
+	maxPDFTotalBytes  = 16 << 20
+)
+
+var (
+	pdfStream = regexp.MustCompile(`(?s)<<(.*?)>>\s*stream\r?\n`)
+	pdfFilter = regexp.MustCompile(`/Filter\s*\[?\s*/(\w+)`)
+
+	errPDFTooLarge = errors.New("pdf stream too large")
+)
+
+// PDFText pulls the text drawn by a PDF's content streams. It understands
+// uncompressed and FlateDecode streams and the standard text operators,
+// which covers the e-receipts and invoices merchants generate. ok is false
+// for scanned/image-only PDFs or text it can't decode (embedded CID fonts),
+// in which case the PDF should go through OCR instead. So are PDFs whose
+// streams inflate past maxPDFStreamBytes each or maxPDFTotalBytes overall.
+func PDFText(data []byte) (text string, ok bool) {
+	var out strings.Builder
+	budget := maxPDFTotalBytes
+
+	for _, loc := range pdfStream.FindAllSubmatchIndex(data, -1) {
+		dict := data[loc[2]:loc[3]]
+		body := data[loc[1]:]
+
+		end := bytes.Index(body, []byte("endstream"))
+		if end < 0 {
+			continue
+		}
+		body = body[:end]
+
+		if bytes.Contains(dict, []byte("/Subtype/Image")) || bytes.Contains(dict, []byte("/Subtype /Image")) {
+			continue
+		}
+
+		switch filter := pdfFilter.FindSubmatch(dict); {
+		case filter == nil:
+		case string(filter[1]) == "FlateDecode":
+			inflated, err := inflate(body, min(maxPDFStreamBytes, budget))
+			if errors.Is(err, errPDFTooLarge) {
+				return "", false
+			}
+			if err != nil {
+				continue
+			}
+			budget -= len(inflated)
+			body = inflated
+		default:
+			// DCT/JBIG2/... are images, anything else we can't read
+			continue
+		}
+
+		extractText(body, &out)
+	}
+
+	text = strings.TrimSpace(out.String())
+	return text, looksLikeText(text)
+}
+
+// inflate decompresses a FlateDecode stream, failing with errPDFTooLarge
+// once it would decode to more than limit bytes
+func inflate(data []byte, limit int) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	// streams are often followed by a stray EOL; keep whatever decoded
+	out, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if len(out) > limit {
+		return nil, errPDFTooLarge
+	}
+	if len(out) > 0 {
+		return out, nil
+	}
+	return nil, err
+}
+
+// extractText walks a content stream and writes the strings shown by Tj, TJ,
+// ' and " inside BT/ET blocks, breaking lines on text positioning operators
+func extractText(content []byte, out *strings.Builder) {
+	var (
+		inText  bool
+		pending []string // string operands waiting for their operator
+	)
+
+	newline := func() {
+		s := out.String()
+		if len(s) > 0 && !strings.HasSuffix(s, "\n") {
+			out.WriteByte('\n')
+		}
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+
+		switch {
+		case c == '(':
+			s, n := readLiteral(content[i:])
+			pending = append(pending, latin1(s))
+			i += n
+			continue
+		case c == '<' && i+1 < len(content) && content[i+1] != '<':
+			s, n := readHex(content[i:])
+			pending = append(pending, latin1(s))
+			i += n
+			continue
+		case c == '[':
+			pending = pending[:0]
+			i++
+			continue
+		case c == ']':
+			i++
+			continue
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+			continue
+		case isPDFSpace(c):
+			i++
+			continue
+		}
+
+		// a number or operator token
+		start := i
+		for i < len(content) && !isPDFSpace(content[i]) && !isPDFDelim(content[i]) {
+			i++
+		}
+		if i == start {
+			i++
+			continue
+		}
+		tok := string(content[start:i])
+
+		// big negative kerning inside a TJ array is a word gap
+		if len(pending) > 0 && (tok[0] == '-' || tok[0] == '.' || (tok[0] >= '0' && tok[0] <= '9')) {
+			if v, err := strconv.ParseFloat(tok, 64); err == nil && v <= -200 {
+				pending = append(pending, " ")
+			}
+			continue
+		}
+
+		switch tok {
+		case "BT":
+			inText = true
+		case "ET":
+			inText = false
+			newline()
+		case "Tj", "TJ":
+			if inText {
+				out.WriteString(strings.Join(pending, ""))
+			}
+		case "'", `"`:
+			if inText {
+				newline()
+				out.WriteString(strings.Join(pending, ""))
+			}
+		case "Td", "TD", "T*", "Tm":
+			if inText {
+				newline()
+			}
+		}
+		pending = pending[:0]
+	}
+}
+
+// readLiteral decodes a (...) string with nested parens and escapes,
+// returning it and the number of bytes consumed
+func readLiteral(b []byte) (string, int) {
+	var out []byte
+	depth := 0
+
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch c {
+		case '(':
+			depth++
+			if depth == 1 {
+				continue
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				return string(out), i + 1
+			}
+		case '\\':
+			i++
+			if i >= len(b) {
+				break
+			}
+			switch e := b[i]; e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b', 'f':
+			case '\r':
+				if i+1 < len(b) && b[i+1] == '\n' {
+					i++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					v := 0
+					j := 0
+					for ; j < 3 && i+j < len(b) && b[i+j] >= '0' && b[i+j] <= '7'; j++ {
+						v = v*8 + int(b[i+j]-'0')
+					}
+					i += j - 1
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+			continue
+		}
+		out = append(out, c)
+	}
+
+	return string(out), len(b)
+}
+
+func readHex(b []byte) (string, int) {
+	end := bytes.IndexByte(b, '>')
+	if end < 0 {
+		return "", len(b)
+	}
+
+	var digits []byte
+	for _, c := range b[1:end] {
+		if !isPDFSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	out := make([]byte, 0, len(digits)/2)
+	for i := 0; i < len(digits); i += 2 {
+		out = append(out, unhex(digits[i])<<4|unhex(digits[i+1]))
+	}
+	return string(out), end + 1
+}
+
+func unhex(c byte) byte {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10
+	}
+	return 0
+}
+
+// latin1 maps single-byte font encodings onto runes; close enough to
+// WinAnsi for the characters receipts use
+func latin1(s string) string {
+	runes := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		runes[i] = rune(s[i])
+	}
+	return string(runes)
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelim(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+// looksLikeText rejects output that is mostly control bytes or glyph ids,
+// which is what CID-keyed fonts decode to without their CMap
+func looksLikeText(s string) bool {
+	var alnum, printable, total int
+	for _, r := range s {
+		total++
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			alnum++
+		}
+		if unicode.IsPrint(r) || unicode.IsSpace(r) {
+			printable++
+		}
+	}
+	return alnum >= minPDFTextChars && float64(printable) >= 0.95*float64(total)
+}
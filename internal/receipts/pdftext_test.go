@@ -0,0 +1,92 @@
+package receipts
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func buildPDF(t *testing.T, content string, compress bool) []byte {
+	t.Helper()
+
+	body := []byte(content)
+	dict := fmt.Sprintf("/Length %d", len(body))
+	if compress {
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		w.Write(body)
+		w.Close()
+		body = buf.Bytes()
+		dict = fmt.Sprintf("/Length %d /Filter /FlateDecode", len(body))
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\n")
+	fmt.Fprintf(&pdf, "4 0 obj\n<< %s >>\nstream\n", dict)
+	pdf.Write(body)
+	pdf.WriteString("\nendstream\nendobj\n%%EOF\n")
+	return pdf.Bytes()
+}
+
+const receiptContent = `BT
+/F1 12 Tf
+72 720 Td
+(COSTCO WHOLESALE #552) Tj
+0 -14 Td
+[(KIRKLAND) -300 (PAPER) -300 (TOWELS)] TJ
+0 -14 Td
+(TOTAL \(CAD\)   23.99) Tj
+T*
+(Thank you for shopping with us) Tj
+ET`
+
+func TestPDFText(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		text, ok := PDFText(buildPDF(t, receiptContent, compress))
+		if !ok {
+			t.Fatalf("Expected usable text (compressed=%v), got %q", compress, text)
+		}
+
+		lines := strings.Split(text, "\n")
+		want := []string{
+			"COSTCO WHOLESALE #552",
+			"KIRKLAND PAPER TOWELS",
+			"TOTAL (CAD)   23.99",
+			"Thank you for shopping with us",
+		}
+		if len(lines) != len(want) {
+			t.Fatalf("Expected %d lines, got %q", len(want), lines)
+		}
+		for i := range want {
+			if lines[i] != want[i] {
+				t.Errorf("Expected line %d to be %q, got %q", i, want[i], lines[i])
+			}
+		}
+	}
+}
+
+func TestPDFTextScanned(t *testing.T) {
+	// an image-only page draws the scan and nothing else
+	pdf := buildPDF(t, "q 612 0 0 792 0 0 cm /Im0 Do Q", true)
+	if text, ok := PDFText(pdf); ok {
+		t.Errorf("Expected scanned PDF to need OCR, got %q", text)
+	}
+}
+
+func TestPDFTextCIDFont(t *testing.T) {
+	// two-byte glyph ids without a ToUnicode map are not text
+	glyphs := strings.Repeat("<0024004F0046>Tj ", 30)
+	if text, ok := PDFText(buildPDF(t, "BT "+glyphs+"ET", true)); ok {
+		t.Errorf("Expected CID glyphs to be rejected, got %q", text)
+	}
+}
+
+func TestPDFTextInflateCap(t *testing.T) {
+	// a real receipt padded with a comment that inflates past the stream cap
+	padding := "%" + strings.Repeat("A", maxPDFStreamBytes) + "\n"
+	if text, ok := PDFText(buildPDF(t, padding+receiptContent, true)); ok {
+		t.Errorf("Expected an oversized stream to fall back to OCR, got %d bytes of text", len(text))
+	}
+}
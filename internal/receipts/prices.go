@@ -0,0 +1,331 @@
+package receipts
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"null-core/internal/merchants"
+)
+
+// itemAbbreviations expands the shorthand stores print on receipts so
+// "ORG BNLS CHKN BRST" and "Organic Boneless Chicken Breast" meet
+var itemAbbreviations = map[string]string{
+	"org":   "organic",
+	"orgnc": "organic",
+	"bnls":  "boneless",
+	"sknls": "skinless",
+	"chkn":  "chicken",
+	"chk":   "chicken",
+	"brst":  "breast",
+	"grnd":  "ground",
+	"whl":   "whole",
+	"wht":   "white",
+	"grn":   "green",
+	"veg":   "vegetable",
+	"frz":   "frozen",
+	"lg":    "large",
+	"sm":    "small",
+	"med":   "medium",
+	"btl":   "bottle",
+	"pkg":   "pack",
+}
+
+// itemUnits are the size units kept glued to their number ("2 L" -> "2l")
+var itemUnits = map[string]string{
+	"g":     "g",
+	"gr":    "g",
+	"kg":    "kg",
+	"mg":    "mg",
+	"ml":    "ml",
+	"l":     "l",
+	"lt":    "l",
+	"ltr":   "l",
+	"litre": "l",
+	"liter": "l",
+	"lb":    "lb",
+	"lbs":   "lb",
+	"oz":    "oz",
+	"pk":    "pk",
+	"pack":  "pk",
+	"ct":    "ct",
+	"count": "ct",
+	"dozen": "doz",
+	"doz":   "doz",
+}
+
+// itemFlags are tax and promo markers printed after the price
+var itemFlags = map[string]bool{
+	"mrj":  true,
+	"hmrj": true,
+	"gst":  true,
+	"pst":  true,
+	"hst":  true,
+	"qst":  true,
+	"tx":   true,
+	"fp":   true,
+}
+
+// NormalizeItemName folds the ways one product shows up across receipts
+// into a single key: lowercase, abbreviations expanded, sizes kept
+// ("2 L" -> "2l"), plurals singular, and SKU/PLU codes, prices and tax
+// flags dropped. It returns "" when nothing meaningful is left.
+func NormalizeItemName(name string) string {
+	tokens := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.'
+	})
+
+	var kept []string
+	for i := 0; i < len(tokens); i++ {
+		tok := strings.Trim(tokens[i], ".")
+		if tok == "" {
+			continue
+		}
+
+		if isNumber(tok) {
+			// a quantity followed by its unit: "2 l", "500 g"
+			if i+1 < len(tokens) {
+				if unit, ok := itemUnits[strings.Trim(tokens[i+1], ".")]; ok {
+					kept = append(kept, tok+unit)
+					i++
+					continue
+				}
+			}
+			// prices carry a decimal point, SKU/PLU codes are long; counts
+			// like "12" in "eggs 12" are kept
+			if strings.Contains(tok, ".") || len(tok) >= 4 {
+				continue
+			}
+			kept = append(kept, tok)
+			continue
+		}
+
+		if size, ok := splitSize(tok); ok {
+			kept = append(kept, size)
+			continue
+		}
+
+		// tax flags at the end of the line ("H", "MRJ") and other noise
+		if len(tok) == 1 || itemFlags[tok] || hasDigit(tok) {
+			continue
+		}
+
+		if full, ok := itemAbbreviations[tok]; ok {
+			tok = full
+		}
+		kept = append(kept, singular(tok))
+	}
+
+	return strings.Join(kept, " ")
+}
+
+// PriceObservation is one purchase of an item
+type PriceObservation struct {
+	ReceiptID      int64
+	Name           string // as printed, or as cleaned up by the parser
+	Merchant       string
+	Date           time.Time
+	UnitPriceCents int64
+	Currency       string
+}
+
+// ItemHistory is everything known about the price of one normalized item in
+// one currency
+type ItemHistory struct {
+	Key       string // NormalizeItemName output
+	Name      string // the most common printed name
+	Currency  string
+	Points    []PriceObservation // oldest first
+	Merchants []MerchantPrices   // cheapest latest price first
+	// ChangePercent compares the latest price with the first; nil with a
+	// single observation
+	ChangePercent *float64
+}
+
+// MerchantPrices summarizes what one merchant charged for an item
+type MerchantPrices struct {
+	Merchant      string
+	Latest        PriceObservation
+	MinCents      int64
+	AvgCents      int64
+	Count         int
+	ChangePercent *float64
+}
+
+// PriceHistory groups observations by normalized item name and currency and
+// keeps the items whose name contains every word of query (all items when
+// query is empty). Items seen most often come first; limit <= 0 keeps all.
+func PriceHistory(obs []PriceObservation, query string, limit int) []ItemHistory {
+	queryWords := strings.Fields(NormalizeItemName(query))
+
+	type groupKey struct{ key, currency string }
+	groups := make(map[groupKey]*ItemHistory)
+	names := make(map[groupKey]map[string]int)
+	var order []groupKey
+
+	for _, o := range obs {
+		key := NormalizeItemName(o.Name)
+		if key == "" || !containsWords(key, queryWords) {
+			continue
+		}
+
+		gk := groupKey{key, o.Currency}
+		g, ok := groups[gk]
+		if !ok {
+			g = &ItemHistory{Key: key, Currency: o.Currency}
+			groups[gk] = g
+			names[gk] = make(map[string]int)
+			order = append(order, gk)
+		}
+		g.Points = append(g.Points, o)
+		names[gk][strings.TrimSpace(o.Name)]++
+	}
+
+	items := make([]ItemHistory, 0, len(order))
+	for _, gk := range order {
+		g := groups[gk]
+		sort.SliceStable(g.Points, func(i, j int) bool {
+			return g.Points[i].Date.Before(g.Points[j].Date)
+		})
+		g.Name = mostCommon(names[gk])
+		g.Merchants = merchantPrices(g.Points)
+		g.ChangePercent = changePercent(g.Points)
+		items = append(items, *g)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if len(items[i].Points) != len(items[j].Points) {
+			return len(items[i].Points) > len(items[j].Points)
+		}
+		return items[i].Key < items[j].Key
+	})
+
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// ----- internal helpers --------------------------------------------------------------------
+
+// merchantPrices buckets date-ordered points by merchant, matching names the
+// same way transactions are ("WALMART #1234" and "Walmart" are one store)
+func merchantPrices(points []PriceObservation) []MerchantPrices {
+	byKey := make(map[string]*MerchantPrices)
+	firsts := make(map[string]int64)
+	totals := make(map[string]int64)
+	var order []string
+
+	for _, p := range points {
+		key := merchants.Normalize(p.Merchant)
+		m, ok := byKey[key]
+		if !ok {
+			m = &MerchantPrices{Merchant: strings.TrimSpace(p.Merchant), MinCents: p.UnitPriceCents}
+			byKey[key] = m
+			firsts[key] = p.UnitPriceCents
+			order = append(order, key)
+		}
+		m.Latest = p
+		m.MinCents = min(m.MinCents, p.UnitPriceCents)
+		m.Count++
+		totals[key] += p.UnitPriceCents
+	}
+
+	out := make([]MerchantPrices, 0, len(order))
+	for _, key := range order {
+		m := byKey[key]
+		m.AvgCents = totals[key] / int64(m.Count)
+		if m.Count > 1 {
+			m.ChangePercent = percent(firsts[key], m.Latest.UnitPriceCents)
+		}
+		out = append(out, *m)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Latest.UnitPriceCents < out[j].Latest.UnitPriceCents
+	})
+	return out
+}
+
+func changePercent(points []PriceObservation) *float64 {
+	if len(points) < 2 {
+		return nil
+	}
+	return percent(points[0].UnitPriceCents, points[len(points)-1].UnitPriceCents)
+}
+
+func percent(from, to int64) *float64 {
+	if from == 0 {
+		return nil
+	}
+	v := float64(to-from) / float64(from) * 100
+	return &v
+}
+
+// mostCommon picks the display name, breaking ties alphabetically so the
+// result doesn't depend on map order
+func mostCommon(counts map[string]int) string {
+	best, bestCount := "", 0
+	for name, count := range counts {
+		if count > bestCount || (count == bestCount && name < best) {
+			best, bestCount = name, count
+		}
+	}
+	return best
+}
+
+func containsWords(key string, words []string) bool {
+	if len(words) == 0 {
+		return true
+	}
+	have := strings.Fields(key)
+	for _, w := range words {
+		found := false
+		for _, h := range have {
+			if h == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// splitSize canonicalizes a size written as one token: "500g", "1.5kg"
+func splitSize(tok string) (string, bool) {
+	i := strings.IndexFunc(tok, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+	if i <= 0 {
+		return "", false
+	}
+	unit, ok := itemUnits[tok[i:]]
+	if !ok {
+		return "", false
+	}
+	return tok[:i] + unit, true
+}
+
+// singular trims a plural "s" so "bananas" and "banana" meet; short words
+// and "-ss" endings ("glass") are left alone
+func singular(w string) string {
+	if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func hasDigit(s string) bool {
+	return strings.IndexFunc(s, unicode.IsDigit) >= 0
+}
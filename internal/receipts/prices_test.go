@@ -0,0 +1,82 @@
+package receipts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNormalizeItemName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"ORG BANANAS 4011", "organic banana"},
+		{"Organic Bananas", "organic banana"},
+		{"ORG BNLS CHKN BRST", "organic boneless chicken breast"},
+		{"2% MILK 4 L        H", "2 milk 4l"},
+		{"2% Milk 4L", "2 milk 4l"},
+		{"CHEDDAR 500 G 6.99 MRJ", "cheddar 500g"},
+		{"Eggs Large 12", "egg large 12"},
+		{"GLASS CLEANER", "glass cleaner"},
+		{"0627735 CHIPS", "chip"},
+		{"#1234", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeItemName(tt.in); got != tt.want {
+			t.Errorf("NormalizeItemName(%q): expected %q, got %q", tt.in, tt.want, got)
+		}
+	}
+}
+
+func TestPriceHistory(t *testing.T) {
+	day := func(m, d int) time.Time { return time.Date(2025, time.Month(m), d, 0, 0, 0, 0, time.UTC) }
+
+	obs := []PriceObservation{
+		{ReceiptID: 1, Name: "ORG BANANAS 4011", Merchant: "WALMART #1234", Date: day(1, 5), UnitPriceCents: 150, Currency: "CAD"},
+		{ReceiptID: 2, Name: "Organic Bananas", Merchant: "Loblaws", Date: day(2, 5), UnitPriceCents: 180, Currency: "CAD"},
+		{ReceiptID: 3, Name: "ORG BANANAS 4011", Merchant: "Walmart", Date: day(3, 5), UnitPriceCents: 165, Currency: "CAD"},
+		{ReceiptID: 3, Name: "2% MILK 4L", Merchant: "Walmart", Date: day(3, 5), UnitPriceCents: 629, Currency: "CAD"},
+		{ReceiptID: 4, Name: "Bananas", Merchant: "Trader Joe's", Date: day(3, 7), UnitPriceCents: 25, Currency: "USD"},
+	}
+
+	items := PriceHistory(obs, "bananas", 0)
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(items))
+	}
+
+	bananas := items[0]
+	if bananas.Key != "organic banana" || bananas.Currency != "CAD" {
+		t.Fatalf("Expected organic banana in CAD first, got %q in %s", bananas.Key, bananas.Currency)
+	}
+	if bananas.Name != "ORG BANANAS 4011" {
+		t.Errorf("Expected most common name, got %q", bananas.Name)
+	}
+	if len(bananas.Points) != 3 || bananas.Points[0].ReceiptID != 1 || bananas.Points[2].ReceiptID != 3 {
+		t.Errorf("Expected 3 points oldest first, got %+v", bananas.Points)
+	}
+	if bananas.ChangePercent == nil || *bananas.ChangePercent != 10 {
+		t.Errorf("Expected 10%% change, got %v", bananas.ChangePercent)
+	}
+
+	if len(bananas.Merchants) != 2 {
+		t.Fatalf("Expected walmart branches to merge into 2 merchants, got %+v", bananas.Merchants)
+	}
+	walmart := bananas.Merchants[0]
+	if walmart.Merchant != "WALMART #1234" || walmart.Count != 2 || walmart.Latest.UnitPriceCents != 165 {
+		t.Errorf("Expected walmart cheapest with 2 purchases at 1.65 latest, got %+v", walmart)
+	}
+	if walmart.MinCents != 150 || walmart.AvgCents != 157 {
+		t.Errorf("Expected min 150 and avg 157, got %d and %d", walmart.MinCents, walmart.AvgCents)
+	}
+	if bananas.Merchants[1].Merchant != "Loblaws" || bananas.Merchants[1].ChangePercent != nil {
+		t.Errorf("Expected loblaws second with no change, got %+v", bananas.Merchants[1])
+	}
+
+	if items[1].Key != "banana" || items[1].Currency != "USD" {
+		t.Errorf("Expected USD bananas kept apart, got %q in %s", items[1].Key, items[1].Currency)
+	}
+
+	if all := PriceHistory(obs, "", 1); len(all) != 1 || all[0].Key != "organic banana" {
+		t.Errorf("Expected limit to keep the most bought item, got %+v", all)
+	}
+}
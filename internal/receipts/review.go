@@ -0,0 +1,122 @@
+package receipts
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"null-core/internal/db/sqlc"
+)
+
+const (
+	// ItemsTolerance is how far, as a share of the subtotal, line items may
+	// drift from it before the parse is doubted; covers rounding on
+	// per-kg prices and deposits the parser folds into items
+	ItemsTolerance = 0.02
+	// MinItemsToleranceCents keeps tiny receipts from tripping on a cent
+	MinItemsToleranceCents = 5
+	// TotalToleranceCents is the allowed rounding between subtotal + tax and total
+	TotalToleranceCents = 2
+	// MaxReceiptAgeYears is the oldest receipt date taken at face value
+	MaxReceiptAgeYears = 5
+)
+
+// ReviewOptions tunes Review; KnownCurrency may be nil to skip the check
+type ReviewOptions struct {
+	MinConfidence float32
+	Now           time.Time
+	KnownCurrency func(code string) bool
+}
+
+// Review checks a parsed receipt for internal consistency and returns why a
+// human should look at it, or nothing when it's plausible
+func Review(receipt *sqlc.Receipt, items []sqlc.ReceiptItem, opts ReviewOptions) []string {
+	var reasons []string
+
+	if receipt.Confidence != nil && *receipt.Confidence < opts.MinConfidence {
+		reasons = append(reasons, fmt.Sprintf("confidence %.2f is below %.2f", *receipt.Confidence, opts.MinConfidence))
+	}
+
+	if receipt.TotalCents == nil {
+		reasons = append(reasons, "no total found")
+	}
+
+	itemsCents, hasItems := sumItems(items)
+
+	switch {
+	case receipt.SubtotalCents != nil && hasItems:
+		diff := abs(itemsCents - *receipt.SubtotalCents)
+		allowed := max(MinItemsToleranceCents, int64(math.Round(float64(abs(*receipt.SubtotalCents))*ItemsTolerance)))
+		if diff > allowed {
+			reasons = append(reasons, fmt.Sprintf("items add up to %s but subtotal is %s", dollars(itemsCents), dollars(*receipt.SubtotalCents)))
+		}
+	case receipt.SubtotalCents == nil && hasItems && receipt.TotalCents != nil:
+		// no subtotal printed: items plus tax should still reach the total
+		expected := itemsCents + deref(receipt.TaxCents)
+		allowed := max(MinItemsToleranceCents, int64(math.Round(float64(abs(*receipt.TotalCents))*ItemsTolerance)))
+		if abs(expected-*receipt.TotalCents) > allowed {
+			reasons = append(reasons, fmt.Sprintf("items and tax add up to %s but total is %s", dollars(expected), dollars(*receipt.TotalCents)))
+		}
+	}
+
+	if receipt.SubtotalCents != nil && receipt.TotalCents != nil {
+		expected := *receipt.SubtotalCents + deref(receipt.TaxCents)
+		if abs(expected-*receipt.TotalCents) > TotalToleranceCents {
+			reasons = append(reasons, fmt.Sprintf("subtotal and tax add up to %s but total is %s", dollars(expected), dollars(*receipt.TotalCents)))
+		}
+	}
+
+	if receipt.ReceiptDate != nil {
+		day := truncateDay(*receipt.ReceiptDate)
+		today := truncateDay(opts.Now)
+		switch {
+		case day.After(today.AddDate(0, 0, 1)):
+			reasons = append(reasons, fmt.Sprintf("date %s is in the future", day.Format(time.DateOnly)))
+		case day.Before(today.AddDate(-MaxReceiptAgeYears, 0, 0)):
+			reasons = append(reasons, fmt.Sprintf("date %s is more than %d years ago", day.Format(time.DateOnly), MaxReceiptAgeYears))
+		}
+	}
+
+	if receipt.Currency != nil && opts.KnownCurrency != nil {
+		code := strings.ToUpper(strings.TrimSpace(*receipt.Currency))
+		if !opts.KnownCurrency(code) {
+			reasons = append(reasons, fmt.Sprintf("unknown currency %q", *receipt.Currency))
+		}
+	}
+
+	return reasons
+}
+
+// ----- internal helpers --------------------------------------------------------------------
+
+func sumItems(items []sqlc.ReceiptItem) (int64, bool) {
+	var total float64
+	for _, item := range items {
+		total += item.Quantity * float64(item.UnitPriceCents)
+	}
+	return int64(math.Round(total)), len(items) > 0
+}
+
+func dollars(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func deref(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
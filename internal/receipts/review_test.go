@@ -0,0 +1,66 @@
+package receipts
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"null-core/internal/db/sqlc"
+)
+
+func item(qty float64, cents int64) sqlc.ReceiptItem {
+	return sqlc.ReceiptItem{Quantity: qty, UnitPriceCents: cents}
+}
+
+func TestReview(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	known := func(code string) bool { return code == "CAD" || code == "USD" }
+
+	base := func() *sqlc.Receipt {
+		return &sqlc.Receipt{
+			ReceiptDate:   ptr(time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC)),
+			Currency:      ptr("CAD"),
+			SubtotalCents: ptr(int64(1000)),
+			TaxCents:      ptr(int64(130)),
+			TotalCents:    ptr(int64(1130)),
+			Confidence:    ptr(float32(0.9)),
+		}
+	}
+	items := []sqlc.ReceiptItem{item(2, 250), item(1, 500)}
+
+	tests := []struct {
+		name    string
+		mutate  func(r *sqlc.Receipt)
+		items   []sqlc.ReceiptItem
+		reasons []string
+	}{
+		{"consistent", func(r *sqlc.Receipt) {}, items, nil},
+		{"low confidence", func(r *sqlc.Receipt) { r.Confidence = ptr(float32(0.3)) }, items, []string{"confidence"}},
+		{"items off", func(r *sqlc.Receipt) {}, []sqlc.ReceiptItem{item(1, 700)}, []string{"items add up to 7.00"}},
+		{"items within rounding", func(r *sqlc.Receipt) {}, []sqlc.ReceiptItem{item(0.498, 2012)}, nil},
+		{"total mismatch", func(r *sqlc.Receipt) { r.TotalCents = ptr(int64(1250)) }, items, []string{"subtotal and tax"}},
+		{"no subtotal", func(r *sqlc.Receipt) { r.SubtotalCents = nil }, items, nil},
+		{"no subtotal mismatch", func(r *sqlc.Receipt) { r.SubtotalCents = nil; r.TotalCents = ptr(int64(2000)) }, items, []string{"items and tax"}},
+		{"no total", func(r *sqlc.Receipt) { r.TotalCents = nil }, items, []string{"no total"}},
+		{"future date", func(r *sqlc.Receipt) { r.ReceiptDate = ptr(now.AddDate(0, 1, 0)) }, items, []string{"in the future"}},
+		{"ancient date", func(r *sqlc.Receipt) { r.ReceiptDate = ptr(now.AddDate(-20, 0, 0)) }, items, []string{"years ago"}},
+		{"unknown currency", func(r *sqlc.Receipt) { r.Currency = ptr("XYZ") }, items, []string{"unknown currency"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := base()
+			tt.mutate(r)
+
+			got := Review(r, tt.items, ReviewOptions{MinConfidence: 0.6, Now: now, KnownCurrency: known})
+			if len(got) != len(tt.reasons) {
+				t.Fatalf("Expected %d reasons, got %q", len(tt.reasons), got)
+			}
+			for i, want := range tt.reasons {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("Expected reason containing %q, got %q", want, got[i])
+				}
+			}
+		})
+	}
+}
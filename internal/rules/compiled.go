@@ -0,0 +1,355 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"null-core/internal/db/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// RuleSet is a user's active rules, parsed and validated once so they can be
+// evaluated against many transactions without touching JSON or regexp.Compile
+type RuleSet struct {
+	rules []CompiledRule
+}
+
+// CompiledRule is a single rule with its conditions prepared for evaluation
+type CompiledRule struct {
+	ID         uuid.UUID
+	CategoryID *int64
+	Merchant   *string
+
+	logic      LogicOperator
+	conditions []compiledCondition
+}
+
+// Match is the outcome of running a RuleSet over one transaction.
+// The first matching rule (in priority order) wins for each action independently.
+type Match struct {
+	CategoryID     *int64
+	CategoryRuleID *uuid.UUID
+	Merchant       *string
+	MerchantRuleID *uuid.UUID
+}
+
+type compiledCondition struct {
+	field         FieldType
+	operator      OperatorType
+	caseSensitive bool
+
+	// string operators; lowercased up front unless case sensitive
+	str  string
+	strs []string
+	re   *regexp.Regexp
+
+	// numeric operators
+	num      float64
+	min, max float64
+}
+
+// CompileRuleSet compiles rules in the order given. Rules that fail to parse
+// are left out of the set and reported together in the returned error, so
+// one broken rule doesn't disable the rest.
+func CompileRuleSet(rows []sqlc.TransactionRule) (*RuleSet, error) {
+	set := &RuleSet{rules: make([]CompiledRule, 0, len(rows))}
+
+	var errs []error
+	for i := range rows {
+		compiled, err := CompileRule(&rows[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rows[i].RuleID, err))
+			continue
+		}
+		set.rules = append(set.rules, *compiled)
+	}
+
+	return set, errors.Join(errs...)
+}
+
+// CompileRule parses and validates a stored rule's conditions
+func CompileRule(row *sqlc.TransactionRule) (*CompiledRule, error) {
+	parsed, err := ParseRuleConditions(row.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled, err := CompileConditions(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled.ID = row.RuleID
+	compiled.CategoryID = row.CategoryID
+	compiled.Merchant = row.Merchant
+
+	return compiled, nil
+}
+
+// CompileConditions prepares already-validated conditions for evaluation
+func CompileConditions(rule *RuleConditions) (*CompiledRule, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("rule cannot be nil")
+	}
+
+	compiled := &CompiledRule{
+		logic:      LogicOperator(rule.Logic),
+		conditions: make([]compiledCondition, len(rule.Conditions)),
+	}
+
+	for i := range rule.Conditions {
+		cond, err := compileCondition(&rule.Conditions[i])
+		if err != nil {
+			return nil, fmt.Errorf("condition %d: %w", i+1, err)
+		}
+		compiled.conditions[i] = cond
+	}
+
+	return compiled, nil
+}
+
+// Len returns the number of rules in the set
+func (rs *RuleSet) Len() int {
+	if rs == nil {
+		return 0
+	}
+	return len(rs.rules)
+}
+
+// Match evaluates every rule against the transaction, stopping once both a
+// category and a merchant have been found
+func (rs *RuleSet) Match(tx *sqlc.Transaction, account *sqlc.Account) Match {
+	var result Match
+	if rs == nil || tx == nil {
+		return result
+	}
+
+	for i := range rs.rules {
+		rule := &rs.rules[i]
+
+		wantsCategory := result.CategoryID == nil && rule.CategoryID != nil
+		wantsMerchant := result.Merchant == nil && rule.Merchant != nil
+		if !wantsCategory && !wantsMerchant {
+			continue
+		}
+
+		if !rule.Matches(tx, account) {
+			continue
+		}
+
+		if wantsCategory {
+			result.CategoryID = rule.CategoryID
+			result.CategoryRuleID = &rule.ID
+		}
+		if wantsMerchant {
+			result.Merchant = rule.Merchant
+			result.MerchantRuleID = &rule.ID
+		}
+
+		if result.CategoryID != nil && result.Merchant != nil {
+			break
+		}
+	}
+
+	return result
+}
+
+// Matches reports whether the rule's conditions hold for the transaction
+func (r *CompiledRule) Matches(tx *sqlc.Transaction, account *sqlc.Account) bool {
+	if r == nil || tx == nil {
+		return false
+	}
+
+	switch r.logic {
+	case LogicAND:
+		for i := range r.conditions {
+			if !r.conditions[i].matches(tx, account) {
+				return false
+			}
+		}
+		return true
+
+	case LogicOR:
+		for i := range r.conditions {
+			if r.conditions[i].matches(tx, account) {
+				return true
+			}
+		}
+		return false
+
+	default:
+		return false
+	}
+}
+
+// ----- internal helpers --------------------------------------------------------------------
+
+func compileCondition(condition *Condition) (compiledCondition, error) {
+	cond := compiledCondition{
+		field:         FieldType(condition.Field),
+		operator:      OperatorType(condition.Operator),
+		caseSensitive: condition.CaseSensitive != nil && *condition.CaseSensitive,
+	}
+
+	if IsNumericField(cond.field) {
+		switch cond.operator {
+		case OpBetween:
+			if condition.MinValue == nil || condition.MaxValue == nil {
+				return cond, fmt.Errorf("between requires min_value and max_value")
+			}
+			cond.min, cond.max = *condition.MinValue, *condition.MaxValue
+		default:
+			num, err := getNumericValue(condition.Value)
+			if err != nil {
+				return cond, err
+			}
+			cond.num = num
+		}
+		return cond, nil
+	}
+
+	switch cond.operator {
+	case OpContainsAny:
+		cond.strs = make([]string, len(condition.Values))
+		for i, v := range condition.Values {
+			cond.strs[i] = cond.fold(v)
+		}
+
+	case OpRegex:
+		pattern, err := getStringValue(condition.Value)
+		if err != nil {
+			return cond, err
+		}
+		if !cond.caseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		cond.re, err = regexp.Compile(pattern)
+		if err != nil {
+			return cond, err
+		}
+
+	default:
+		str, err := getStringValue(condition.Value)
+		if err != nil {
+			return cond, err
+		}
+		cond.str = cond.fold(str)
+	}
+
+	return cond, nil
+}
+
+func (c *compiledCondition) fold(s string) string {
+	if c.caseSensitive {
+		return s
+	}
+	return strings.ToLower(s)
+}
+
+func (c *compiledCondition) matches(tx *sqlc.Transaction, account *sqlc.Account) bool {
+	if IsNumericField(c.field) {
+		var value float64
+		switch c.field {
+		case FieldAmount:
+			value = float64(tx.TxAmountCents) / 100.0
+		case FieldTxDirection:
+			value = float64(tx.TxDirection)
+		}
+		return c.matchesNumber(value)
+	}
+
+	value, ok := stringFieldValue(c.field, tx, account)
+	if !ok {
+		return false
+	}
+	return c.matchesString(value)
+}
+
+func (c *compiledCondition) matchesString(original string) bool {
+	// regexes carry their own (?i) flag and run on the untouched value
+	if c.operator == OpRegex {
+		return c.re.MatchString(original)
+	}
+
+	value := c.fold(original)
+
+	switch c.operator {
+	case OpEquals:
+		return value == c.str
+	case OpNotEquals:
+		return value != c.str
+	case OpContains:
+		return strings.Contains(value, c.str)
+	case OpNotContains:
+		return !strings.Contains(value, c.str)
+	case OpStartsWith:
+		return strings.HasPrefix(value, c.str)
+	case OpEndsWith:
+		return strings.HasSuffix(value, c.str)
+	case OpContainsAny:
+		for _, s := range c.strs {
+			if strings.Contains(value, s) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func (c *compiledCondition) matchesNumber(value float64) bool {
+	switch c.operator {
+	case OpEquals:
+		return value == c.num
+	case OpNotEquals:
+		return value != c.num
+	case OpGreaterThan:
+		return value > c.num
+	case OpLessThan:
+		return value < c.num
+	case OpBetween:
+		return value >= c.min && value <= c.max
+	default:
+		return false
+	}
+}
+
+// stringFieldValue returns the raw value for a string field; ok is false when
+// the field is unset (nil merchant, missing account, ...)
+func stringFieldValue(field FieldType, tx *sqlc.Transaction, account *sqlc.Account) (string, bool) {
+	switch field {
+	case FieldMerchant:
+		if tx.Merchant == nil {
+			return "", false
+		}
+		return *tx.Merchant, true
+	case FieldTxDesc:
+		if tx.TxDesc == nil {
+			return "", false
+		}
+		return *tx.TxDesc, true
+	case FieldCurrency:
+		return tx.TxCurrency, true
+	case FieldAccountType:
+		if account == nil {
+			return "", false
+		}
+		return account.AccountType.String(), true
+	case FieldAccountName:
+		if account == nil {
+			return "", false
+		}
+		return account.Name, true
+	case FieldBank:
+		if account == nil {
+			return "", false
+		}
+		return account.Bank, true
+	default:
+		return "", false
+	}
+}
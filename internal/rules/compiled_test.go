@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	"null-core/internal/db/sqlc"
+	pb "null-core/internal/gen/null/v1"
+
+	"github.com/google/uuid"
+)
+
+func strPtr(s string) *string { return &s }
+
+func testRules(n int) []sqlc.TransactionRule {
+	rows := make([]sqlc.TransactionRule, n)
+	for i := range rows {
+		categoryID := int64(i + 1)
+		var conditions string
+		switch i % 4 {
+		case 0:
+			conditions = fmt.Sprintf(`{"logic":"AND","conditions":[{"field":"merchant","operator":"contains_any","values":["shop%d","store%d","market%d"]}]}`, i, i, i)
+		case 1:
+			conditions = fmt.Sprintf(`{"logic":"AND","conditions":[{"field":"tx_desc","operator":"regex","value":"^POS\\s+VENDOR%d\\b"},{"field":"amount","operator":"between","min_value":1,"max_value":500}]}`, i)
+		case 2:
+			conditions = fmt.Sprintf(`{"logic":"OR","conditions":[{"field":"tx_desc","operator":"starts_with","value":"ACH PAYROLL %d"},{"field":"bank","operator":"equals","value":"Bank %d"}]}`, i, i)
+		case 3:
+			conditions = fmt.Sprintf(`{"logic":"AND","conditions":[{"field":"merchant","operator":"equals","value":"Vendor %d"},{"field":"currency","operator":"equals","value":"cad"}]}`, i)
+		}
+		rows[i] = sqlc.TransactionRule{
+			RuleID:     uuid.New(),
+			RuleName:   fmt.Sprintf("rule %d", i),
+			CategoryID: &categoryID,
+			Conditions: []byte(conditions),
+		}
+	}
+	return rows
+}
+
+func testTransactions(n int) []sqlc.Transaction {
+	txs := make([]sqlc.Transaction, n)
+	for i := range txs {
+		txs[i] = sqlc.Transaction{
+			ID:            int64(i + 1),
+			TxAmountCents: int64(100 + i%50000),
+			TxCurrency:    "CAD",
+			TxDirection:   pb.TransactionDirection_DIRECTION_OUTGOING,
+			TxDesc:        strPtr(fmt.Sprintf("POS VENDOR%d PURCHASE", i%400)),
+			Merchant:      strPtr(fmt.Sprintf("Vendor %d", i%400)),
+		}
+	}
+	return txs
+}
+
+var testAccount = &sqlc.Account{ID: 1, Name: "Chequing", Bank: "Bank 2", AccountType: pb.AccountType_ACCOUNT_CHEQUING}
+
+// legacyMatch mirrors the per-transaction parse + evaluate path the compiled
+// rule set replaces, and doubles as the reference implementation
+func legacyMatch(rows []sqlc.TransactionRule, tx *sqlc.Transaction, account *sqlc.Account) *int64 {
+	accountRow := &sqlc.GetAccountRow{Account: *account}
+	for _, row := range rows {
+		conditions, err := ParseRuleConditions(row.Conditions)
+		if err != nil {
+			continue
+		}
+		matches, err := EvaluateRule(conditions, tx, accountRow)
+		if err != nil || !matches {
+			continue
+		}
+		if row.CategoryID != nil {
+			return row.CategoryID
+		}
+	}
+	return nil
+}
+
+func TestRuleSetMatchesLegacyEvaluation(t *testing.T) {
+	rows := testRules(40)
+	txs := testTransactions(1000)
+
+	set, err := CompileRuleSet(rows)
+	if err != nil {
+		t.Fatalf("Unexpected compile error: %v", err)
+	}
+
+	for i := range txs {
+		want := legacyMatch(rows, &txs[i], testAccount)
+		got := set.Match(&txs[i], testAccount).CategoryID
+
+		if (want == nil) != (got == nil) || (want != nil && *want != *got) {
+			t.Fatalf("Transaction %d: expected category %v, got %v", txs[i].ID, want, got)
+		}
+	}
+}
+
+func TestCompileRuleSetSkipsInvalidRules(t *testing.T) {
+	rows := testRules(3)
+	rows[1].Conditions = []byte(`{"logic":"AND","conditions":[{"field":"merchant","operator":"regex","value":"[invalid"}]}`)
+
+	set, err := CompileRuleSet(rows)
+	if err == nil {
+		t.Errorf("Expected error for invalid rule")
+	}
+	if set.Len() != 2 {
+		t.Errorf("Expected 2 compiled rules, got %d", set.Len())
+	}
+}
+
+func BenchmarkLegacyEvaluation(b *testing.B) {
+	rows := testRules(200)
+	txs := testTransactions(1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		legacyMatch(rows, &txs[i%len(txs)], testAccount)
+	}
+}
+
+func BenchmarkRuleSetMatch(b *testing.B) {
+	rows := testRules(200)
+	txs := testTransactions(1000)
+
+	set, err := CompileRuleSet(rows)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.Match(&txs[i%len(txs)], testAccount)
+	}
+}
+
+func BenchmarkCompileRuleSet(b *testing.B) {
+	rows := testRules(200)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := CompileRuleSet(rows); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
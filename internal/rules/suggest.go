@@ -0,0 +1,294 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"null-core/internal/db/sqlc"
+)
+
+// SuggestOptions tunes how aggressive rule mining is
+type SuggestOptions struct {
+	// MinSupport is the minimum number of manually categorized transactions
+	// a candidate must correctly match
+	MinSupport int
+	// MinPrecision is the minimum share of matched labelled transactions that
+	// carry the candidate's category
+	MinPrecision float64
+	// Limit caps the number of suggestions returned (0 = no limit)
+	Limit int
+}
+
+// DefaultSuggestOptions are used for any zero-valued option
+var DefaultSuggestOptions = SuggestOptions{
+	MinSupport:   3,
+	MinPrecision: 0.9,
+	Limit:        20,
+}
+
+// Suggestion is a candidate rule mined from manual categorizations
+type Suggestion struct {
+	Name       string
+	Conditions RuleConditions
+	CategoryID int64
+
+	// Support is how many labelled transactions the rule matches with the right category
+	Support int
+	// Precision is Support over all labelled transactions the rule matches
+	Precision float64
+	// Coverage is Support over all labelled transactions in the category
+	Coverage float64
+	// TotalMatches is how many of the user's transactions the rule would match
+	TotalMatches int
+	// SampleTransactionIDs are a few of the labelled transactions behind the suggestion
+	SampleTransactionIDs []int64
+
+	supporting []int64
+}
+
+const maxSuggestionSamples = 5
+
+// ignoredTokens are bank/processor boilerplate that say nothing about the merchant
+var ignoredTokens = map[string]bool{
+	"pos": true, "purchase": true, "payment": true, "debit": true, "credit": true,
+	"card": true, "visa": true, "mastercard": true, "interac": true, "online": true,
+	"transfer": true, "the": true, "and": true, "www": true, "com": true, "inc": true,
+	"ltd": true, "llc": true, "store": true, "transaction": true, "fee": true,
+}
+
+type candidate struct {
+	field      FieldType
+	operator   OperatorType
+	value      string
+	categoryID int64
+}
+
+// SuggestRules mines transactions whose category was set by hand for
+// merchant / description patterns that reliably map to one category.
+// Candidates already fully handled by an existing rule are dropped.
+func SuggestRules(txs []sqlc.Transaction, existing *RuleSet, opts SuggestOptions) []Suggestion {
+	opts = withSuggestDefaults(opts)
+
+	labelled := make([]*sqlc.Transaction, 0, len(txs))
+	categoryTotals := make(map[int64]int)
+	for i := range txs {
+		tx := &txs[i]
+		if !tx.CategoryManuallySet || tx.CategoryID == nil {
+			continue
+		}
+		labelled = append(labelled, tx)
+		categoryTotals[*tx.CategoryID]++
+	}
+
+	// cheap pre-pass: count (pattern, category) pairs so only patterns with
+	// enough support get evaluated against every transaction
+	counts := make(map[candidate]int)
+	for _, tx := range labelled {
+		for _, c := range candidatesFor(tx) {
+			counts[c]++
+		}
+	}
+
+	var suggestions []Suggestion
+	for c, n := range counts {
+		if n < opts.MinSupport {
+			continue
+		}
+
+		caseSensitive := false
+		conditions := RuleConditions{
+			Logic: string(LogicAND),
+			Conditions: []Condition{{
+				Field:         string(c.field),
+				Operator:      string(c.operator),
+				Value:         c.value,
+				CaseSensitive: &caseSensitive,
+			}},
+		}
+		compiled, err := CompileConditions(&conditions)
+		if err != nil {
+			continue
+		}
+
+		s, ok := scoreCandidate(compiled, c.categoryID, labelled, txs, existing)
+		if !ok || s.Support < opts.MinSupport || s.Precision < opts.MinPrecision {
+			continue
+		}
+
+		s.Name = suggestionName(c)
+		s.Conditions = conditions
+		s.CategoryID = c.categoryID
+		s.Coverage = float64(s.Support) / float64(categoryTotals[c.categoryID])
+		suggestions = append(suggestions, s)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Support != b.Support {
+			return a.Support > b.Support
+		}
+		if a.Precision != b.Precision {
+			return a.Precision > b.Precision
+		}
+		// prefer merchant rules, then order by name so output is stable
+		if a.Conditions.Conditions[0].Field != b.Conditions.Conditions[0].Field {
+			return a.Conditions.Conditions[0].Field == string(FieldMerchant)
+		}
+		return a.Name < b.Name
+	})
+
+	suggestions = dedupeSuggestions(suggestions)
+
+	if opts.Limit > 0 && len(suggestions) > opts.Limit {
+		suggestions = suggestions[:opts.Limit]
+	}
+
+	return suggestions
+}
+
+// ----- internal helpers --------------------------------------------------------------------
+
+func withSuggestDefaults(opts SuggestOptions) SuggestOptions {
+	if opts.MinSupport <= 0 {
+		opts.MinSupport = DefaultSuggestOptions.MinSupport
+	}
+	if opts.MinPrecision <= 0 {
+		opts.MinPrecision = DefaultSuggestOptions.MinPrecision
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultSuggestOptions.Limit
+	}
+	return opts
+}
+
+func candidatesFor(tx *sqlc.Transaction) []candidate {
+	var out []candidate
+
+	if tx.Merchant != nil {
+		if merchant := strings.ToLower(strings.TrimSpace(*tx.Merchant)); merchant != "" {
+			out = append(out, candidate{field: FieldMerchant, operator: OpEquals, value: merchant, categoryID: *tx.CategoryID})
+		}
+	}
+
+	if tx.TxDesc != nil {
+		seen := make(map[string]bool)
+		for _, token := range Tokenize(*tx.TxDesc) {
+			if seen[token] {
+				continue
+			}
+			seen[token] = true
+			out = append(out, candidate{field: FieldTxDesc, operator: OpContains, value: token, categoryID: *tx.CategoryID})
+		}
+	}
+
+	return out
+}
+
+// Tokenize splits a description into lowercase alphabetic-ish tokens, dropping
+// short tokens, pure numbers and processor boilerplate
+func Tokenize(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := fields[:0]
+	for _, f := range fields {
+		if len(f) < 3 || ignoredTokens[f] || isNumeric(f) {
+			continue
+		}
+		tokens = append(tokens, f)
+	}
+
+	return tokens
+}
+
+func suggestionName(c candidate) string {
+	if c.field == FieldMerchant {
+		return fmt.Sprintf("merchant is %s", c.value)
+	}
+	return fmt.Sprintf("description contains %s", c.value)
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func scoreCandidate(rule *CompiledRule, categoryID int64, labelled []*sqlc.Transaction, all []sqlc.Transaction, existing *RuleSet) (Suggestion, bool) {
+	var s Suggestion
+
+	matched := 0
+	alreadyHandled := 0
+	for _, tx := range labelled {
+		if !rule.Matches(tx, nil) {
+			continue
+		}
+		matched++
+
+		if *tx.CategoryID != categoryID {
+			continue
+		}
+		s.Support++
+		s.supporting = append(s.supporting, tx.ID)
+
+		if len(s.SampleTransactionIDs) < maxSuggestionSamples {
+			s.SampleTransactionIDs = append(s.SampleTransactionIDs, tx.ID)
+		}
+
+		if m := existing.Match(tx, nil); m.CategoryID != nil && *m.CategoryID == categoryID {
+			alreadyHandled++
+		}
+	}
+
+	if matched == 0 || alreadyHandled == s.Support {
+		return s, false
+	}
+
+	for i := range all {
+		if rule.Matches(&all[i], nil) {
+			s.TotalMatches++
+		}
+	}
+
+	s.Precision = float64(s.Support) / float64(matched)
+	return s, true
+}
+
+// dedupeSuggestions drops suggestions (already sorted best-first) whose
+// supporting transactions are mostly explained by a better suggestion
+// for the same category
+func dedupeSuggestions(suggestions []Suggestion) []Suggestion {
+	explained := make(map[int64]map[int64]bool)
+
+	out := suggestions[:0]
+	for _, s := range suggestions {
+		seen := explained[s.CategoryID]
+		if seen == nil {
+			seen = make(map[int64]bool)
+			explained[s.CategoryID] = seen
+		}
+
+		overlap := 0
+		for _, id := range s.supporting {
+			if seen[id] {
+				overlap++
+			}
+		}
+		if overlap*2 > len(s.supporting) {
+			continue
+		}
+
+		for _, id := range s.supporting {
+			seen[id] = true
+		}
+		out = append(out, s)
+	}
+
+	return out
+}
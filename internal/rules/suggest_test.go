@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"testing"
+
+	"null-core/internal/db/sqlc"
+)
+
+func labelledTx(id int64, desc, merchant string, categoryID int64) sqlc.Transaction {
+	tx := sqlc.Transaction{ID: id, TxDesc: &desc, TxCurrency: "CAD"}
+	if merchant != "" {
+		tx.Merchant = &merchant
+	}
+	if categoryID > 0 {
+		tx.CategoryID = &categoryID
+		tx.CategoryManuallySet = true
+	}
+	return tx
+}
+
+func TestSuggestRules(t *testing.T) {
+	txs := []sqlc.Transaction{
+		labelledTx(1, "POS STARBUCKS #1234 TORONTO", "Starbucks", 10),
+		labelledTx(2, "POS STARBUCKS #9921 TORONTO", "Starbucks", 10),
+		labelledTx(3, "STARBUCKS ONLINE RELOAD", "Starbucks", 10),
+		labelledTx(4, "UBER *TRIP HELP.UBER.COM", "", 20),
+		labelledTx(5, "UBER *TRIP HELP.UBER.COM", "", 20),
+		labelledTx(6, "UBER *EATS HELP.UBER.COM", "", 30),
+		labelledTx(7, "UBER *TRIP HELP.UBER.COM", "", 20),
+		labelledTx(8, "UBER *TRIP HELP.UBER.COM", "", 20),
+		// uncategorized, counted in total matches only
+		labelledTx(9, "STARBUCKS #5555", "Starbucks", 0),
+	}
+
+	suggestions := SuggestRules(txs, nil, SuggestOptions{MinSupport: 3, MinPrecision: 0.75})
+
+	byName := make(map[string]Suggestion)
+	for _, s := range suggestions {
+		byName[s.Name] = s
+	}
+
+	starbucks, ok := byName["merchant is starbucks"]
+	if !ok {
+		t.Fatalf("Expected merchant suggestion for starbucks, got %v", suggestions)
+	}
+	if starbucks.CategoryID != 10 || starbucks.Support != 3 || starbucks.Precision != 1 || starbucks.TotalMatches != 4 {
+		t.Errorf("Unexpected starbucks suggestion: %+v", starbucks)
+	}
+
+	// the description token covers the same transactions as the merchant rule
+	if _, ok := byName["description contains starbucks"]; ok {
+		t.Errorf("Expected description suggestion to be deduplicated against merchant suggestion")
+	}
+
+	trip, ok := byName["description contains trip"]
+	if !ok {
+		t.Fatalf("Expected description suggestion for trip, got %v", suggestions)
+	}
+	if trip.CategoryID != 20 || trip.Precision != 1 || trip.Coverage != 1 {
+		t.Errorf("Unexpected trip suggestion: %+v", trip)
+	}
+
+	// "uber" maps to 20 four times out of five: 0.8 precision, above the threshold
+	// but explained by the better "trip" suggestion
+	if _, ok := byName["description contains uber"]; ok {
+		t.Errorf("Expected uber suggestion to be deduplicated against trip suggestion")
+	}
+}
+
+func TestSuggestRulesSkipsExistingRules(t *testing.T) {
+	txs := []sqlc.Transaction{
+		labelledTx(1, "NETFLIX.COM", "Netflix", 5),
+		labelledTx(2, "NETFLIX.COM", "Netflix", 5),
+		labelledTx(3, "NETFLIX.COM", "Netflix", 5),
+	}
+
+	categoryID := int64(5)
+	existing, err := CompileRuleSet([]sqlc.TransactionRule{{
+		CategoryID: &categoryID,
+		Conditions: []byte(`{"logic":"AND","conditions":[{"field":"merchant","operator":"equals","value":"netflix"}]}`),
+	}})
+	if err != nil {
+		t.Fatalf("Unexpected compile error: %v", err)
+	}
+
+	if suggestions := SuggestRules(txs, existing, SuggestOptions{}); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions when an existing rule already handles them, got %v", suggestions)
+	}
+}
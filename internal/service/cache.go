@@ -0,0 +1,71 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// userCache holds one computed value per user for ttl. Every invalidate
+// bumps the user's generation; a value loaded under an older generation is
+// not stored, so a load racing an edit can't put stale data back.
+type userCache[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	next    uint64
+	entries map[uuid.UUID]userCacheEntry[T]
+}
+
+type userCacheEntry[T any] struct {
+	value  T
+	loaded bool
+	gen    uint64
+	at     time.Time // when value was stored, or the user was invalidated
+}
+
+func newUserCache[T any](ttl time.Duration) *userCache[T] {
+	return &userCache[T]{ttl: ttl, entries: make(map[uuid.UUID]userCacheEntry[T])}
+}
+
+// get returns the user's value if fresh, and either way the generation to
+// pass to put after loading it
+func (c *userCache[T]) get(userID uuid.UUID) (T, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entries[userID]
+	if !entry.loaded || time.Since(entry.at) > c.ttl {
+		var zero T
+		return zero, entry.gen, false
+	}
+	return entry.value, entry.gen, true
+}
+
+// put stores value unless the user was invalidated since get returned gen,
+// and drops whatever has expired
+func (c *userCache[T]) put(userID uuid.UUID, gen uint64, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// generation markers are swept too; a load would have to outlive the
+	// ttl for that to matter
+	for id, entry := range c.entries {
+		if time.Since(entry.at) > c.ttl {
+			delete(c.entries, id)
+		}
+	}
+
+	if c.entries[userID].gen != gen {
+		return
+	}
+	c.entries[userID] = userCacheEntry[T]{value: value, loaded: true, gen: gen, at: time.Now()}
+}
+
+func (c *userCache[T]) invalidate(userID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.next++
+	c.entries[userID] = userCacheEntry[T]{gen: c.next, at: time.Now()}
+}
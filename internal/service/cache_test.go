@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestUserCacheDropsLoadRacingInvalidate(t *testing.T) {
+	c := newUserCache[string](time.Minute)
+	user := uuid.New()
+
+	_, gen, ok := c.get(user)
+	if ok {
+		t.Fatal("Expected a miss on an empty cache")
+	}
+
+	// a rule edit lands while the old rules are being compiled
+	c.invalidate(user)
+	c.put(user, gen, "stale")
+
+	if v, _, ok := c.get(user); ok {
+		t.Errorf("Expected the stale load to be dropped, got %q", v)
+	}
+
+	_, gen, _ = c.get(user)
+	c.put(user, gen, "fresh")
+	if v, _, ok := c.get(user); !ok || v != "fresh" {
+		t.Errorf("Expected the fresh load to be cached, got %q, %v", v, ok)
+	}
+}
+
+func TestUserCacheSweepsExpired(t *testing.T) {
+	c := newUserCache[string](time.Millisecond)
+	old, user := uuid.New(), uuid.New()
+
+	c.put(old, 0, "old")
+	time.Sleep(5 * time.Millisecond)
+	c.put(user, 0, "new")
+
+	if _, ok := c.entries[old]; ok {
+		t.Error("Expected the expired entry to be swept on put")
+	}
+	if len(c.entries) != 1 {
+		t.Errorf("Expected one entry left, got %d", len(c.entries))
+	}
+}
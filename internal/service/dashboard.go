@@ -75,6 +75,7 @@ type DashboardService interface {
 	GetCategorySpendingComparison(ctx context.Context, params CategorySpendingParams) (*CategorySpendingResult, error)
 	GetNetWorthHistory(ctx context.Context, params NetWorthHistoryParams) ([]*pb.NetWorthPoint, error)
 	GetEarliestTransactionDate(ctx context.Context, userID uuid.UUID) (time.Time, error)
+	ItemCategorySpending(ctx context.Context, userID uuid.UUID, req *pb.GetItemCategorySpendingRequest) ([]*pb.ItemCategorySpending, error)
 }
 
 type dashSvc struct {
@@ -192,6 +193,25 @@ func (s *dashSvc) TopMerchants(ctx context.Context, userID uuid.UUID, req *pb.Ge
 	return result, nil
 }
 
+func (s *dashSvc) ItemCategorySpending(ctx context.Context, userID uuid.UUID, req *pb.GetItemCategorySpendingRequest) ([]*pb.ItemCategorySpending, error) {
+	rows, err := s.queries.GetItemCategorySpending(ctx, sqlc.GetItemCategorySpendingParams{
+		UserID:                userID,
+		Start:                 dateToTime(req.StartDate),
+		End:                   dateToTime(req.EndDate),
+		TransactionCategoryID: req.TransactionCategoryId,
+		Limit:                 req.Limit,
+	})
+	if err != nil {
+		return nil, wrapErr("DashboardService.ItemCategorySpending", err)
+	}
+
+	result := make([]*pb.ItemCategorySpending, len(rows))
+	for i := range rows {
+		result[i] = itemCategorySpendingToPb(&rows[i])
+	}
+	return result, nil
+}
+
 func (s *dashSvc) NetBalance(ctx context.Context, userID uuid.UUID) (*money.Money, error) {
 	balances, err := s.queries.GetAccountBalances(ctx, userID)
 	if err != nil {
@@ -535,6 +555,17 @@ func monthlyComparisonToPb(comp *sqlc.GetMonthlyComparisonRow) *pb.MonthlyCompar
 	}
 }
 
+func itemCategorySpendingToPb(row *sqlc.GetItemCategorySpendingRow) *pb.ItemCategorySpending {
+	return &pb.ItemCategorySpending{
+		CategoryId:   row.CategoryID,
+		Slug:         row.Slug,
+		Color:        row.Color,
+		ItemCount:    row.ItemCount,
+		ReceiptCount: row.ReceiptCount,
+		TotalAmount:  centsToMoney(row.TotalAmountCents, row.Currency),
+	}
+}
+
 func topCategoryToPb(cat *sqlc.GetTopCategoriesRow) *pb.TopCategory {
 	if cat == nil {
 		return nil
@@ -563,6 +594,9 @@ func topMerchantToPb(merchant *sqlc.GetTopMerchantsRow) *pb.TopMerchant {
 		TransactionCount: merchant.TransactionCount,
 		TotalAmount:      centsToMoney(merchant.TotalAmountCents, "CAD"),
 		AvgAmount:        centsToMoney(merchant.AvgAmountCents, "CAD"),
+		MerchantId:       merchant.MerchantID,
+		Color:            merchant.Color,
+		LogoUrl:          merchant.LogoUrl,
 	}
 }
 
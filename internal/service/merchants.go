@@ -0,0 +1,460 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"null-core/internal/db/sqlc"
+	pb "null-core/internal/gen/null/v1"
+	"null-core/internal/merchants"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ----- interface ---------------------------------------------------------------------------
+
+type MerchantService interface {
+	List(ctx context.Context, userID uuid.UUID) ([]*pb.Merchant, error)
+	Get(ctx context.Context, userID uuid.UUID, merchantID int64) (*pb.Merchant, error)
+	Create(ctx context.Context, userID uuid.UUID, req *pb.CreateMerchantRequest) (*pb.Merchant, int64, error)
+	Update(ctx context.Context, userID uuid.UUID, req *pb.UpdateMerchantRequest) (int64, error)
+	Delete(ctx context.Context, userID uuid.UUID, merchantID int64) (int64, error)
+	Merge(ctx context.Context, userID uuid.UUID, targetID int64, sourceIDs []int64) (*pb.Merchant, int64, error)
+	ApplyToExisting(ctx context.Context, userID uuid.UUID) (int64, error)
+	Resolve(ctx context.Context, userID uuid.UUID, tx *sqlc.Transaction) (*sqlc.Merchant, error)
+}
+
+type merchantSvc struct {
+	queries *sqlc.Queries
+	pool    *pgxpool.Pool
+	log     *log.Logger
+	cache   *userCache[*merchants.Matcher]
+}
+
+func newMerchantSvc(queries *sqlc.Queries, pool *pgxpool.Pool, logger *log.Logger) MerchantService {
+	return &merchantSvc{queries: queries, pool: pool, log: logger, cache: newUserCache[*merchants.Matcher](matcherCacheTTL)}
+}
+
+// matchers are invalidated on merchant CRUD; the TTL only bounds staleness
+// from other replicas
+const matcherCacheTTL = 5 * time.Minute
+
+// ----- methods -----------------------------------------------------------------------------
+
+func (s *merchantSvc) List(ctx context.Context, userID uuid.UUID) ([]*pb.Merchant, error) {
+	rows, err := s.queries.ListMerchants(ctx, userID)
+	if err != nil {
+		return nil, wrapErr("MerchantService.List", err)
+	}
+
+	result := make([]*pb.Merchant, len(rows))
+	for i := range rows {
+		result[i] = merchantToPb(&rows[i])
+	}
+
+	return result, nil
+}
+
+func (s *merchantSvc) Get(ctx context.Context, userID uuid.UUID, merchantID int64) (*pb.Merchant, error) {
+	merchant, err := s.queries.GetMerchant(ctx, sqlc.GetMerchantParams{
+		ID:     merchantID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, wrapErr("MerchantService.Get", err)
+	}
+
+	return merchantToPb(&merchant), nil
+}
+
+func (s *merchantSvc) Create(ctx context.Context, userID uuid.UUID, req *pb.CreateMerchantRequest) (*pb.Merchant, int64, error) {
+	if err := validatePatterns(req.GetPatterns()); err != nil {
+		return nil, 0, wrapErr("MerchantService.Create", err)
+	}
+	if err := validateColor(req.Color); err != nil {
+		return nil, 0, wrapErr("MerchantService.Create", err)
+	}
+
+	merchant, err := s.queries.CreateMerchant(ctx, buildCreateMerchantParams(userID, req))
+	if err != nil {
+		return nil, 0, wrapErr("MerchantService.Create", err)
+	}
+	s.cache.invalidate(userID)
+
+	var linked int64
+	if req.GetApplyToExisting() {
+		linked, err = s.ApplyToExisting(ctx, userID)
+		if err != nil {
+			s.log.Warn("failed to link existing transactions", "merchant_id", merchant.ID, "error", err)
+		}
+	}
+
+	return merchantToPb(&merchant), linked, nil
+}
+
+func (s *merchantSvc) Update(ctx context.Context, userID uuid.UUID, req *pb.UpdateMerchantRequest) (int64, error) {
+	if err := validatePatterns(req.GetPatterns()); err != nil {
+		return 0, wrapErr("MerchantService.Update", err)
+	}
+	if err := validateColor(req.Color); err != nil {
+		return 0, wrapErr("MerchantService.Update", err)
+	}
+
+	err := s.queries.UpdateMerchant(ctx, buildUpdateMerchantParams(userID, req))
+	if err != nil {
+		return 0, wrapErr("MerchantService.Update", err)
+	}
+	s.cache.invalidate(userID)
+
+	var linked int64
+	if req.GetApplyToExisting() {
+		linked, err = s.ApplyToExisting(ctx, userID)
+		if err != nil {
+			s.log.Warn("failed to link existing transactions", "merchant_id", req.GetId(), "error", err)
+		}
+	}
+
+	return linked, nil
+}
+
+func (s *merchantSvc) Delete(ctx context.Context, userID uuid.UUID, merchantID int64) (int64, error) {
+	affected, err := s.queries.DeleteMerchants(ctx, sqlc.DeleteMerchantsParams{
+		Ids:    []int64{merchantID},
+		UserID: userID,
+	})
+	if err != nil {
+		return 0, wrapErr("MerchantService.Delete", err)
+	}
+	s.cache.invalidate(userID)
+
+	return affected, nil
+}
+
+// Merge folds the sources' names, aliases and patterns into the target,
+// moves their transactions over and deletes them. Display fields the target
+// lacks (default category, logo, color) are taken from the first source
+// that has them. It all happens in one transaction, so a failure leaves
+// every merchant as it was.
+func (s *merchantSvc) Merge(ctx context.Context, userID uuid.UUID, targetID int64, sourceIDs []int64) (*pb.Merchant, int64, error) {
+	if len(sourceIDs) == 0 {
+		return nil, 0, wrapErr("MerchantService.Merge", fmt.Errorf("no source merchants: %w", ErrValidation))
+	}
+	if slices.Contains(sourceIDs, targetID) {
+		return nil, 0, wrapErr("MerchantService.Merge", fmt.Errorf("cannot merge merchant %d into itself: %w", targetID, ErrValidation))
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, 0, wrapErr("MerchantService.Merge.Begin", err)
+	}
+	defer tx.Rollback(ctx)
+	queries := s.queries.WithTx(tx)
+
+	rows, err := queries.ListMerchants(ctx, userID)
+	if err != nil {
+		return nil, 0, wrapErr("MerchantService.Merge.FetchMerchants", err)
+	}
+
+	byID := make(map[int64]*sqlc.Merchant, len(rows))
+	for i := range rows {
+		byID[rows[i].ID] = &rows[i]
+	}
+
+	target, ok := byID[targetID]
+	if !ok {
+		return nil, 0, wrapErr("MerchantService.Merge", fmt.Errorf("merchant %d not found: %w", targetID, ErrValidation))
+	}
+
+	sources := make([]*sqlc.Merchant, 0, len(sourceIDs))
+	for _, id := range sourceIDs {
+		source, ok := byID[id]
+		if !ok {
+			return nil, 0, wrapErr("MerchantService.Merge", fmt.Errorf("merchant %d not found: %w", id, ErrValidation))
+		}
+		sources = append(sources, source)
+	}
+
+	err = queries.UpdateMerchant(ctx, buildMergeMerchantParams(userID, target, sources))
+	if err != nil {
+		return nil, 0, wrapErr("MerchantService.Merge.FoldAliases", err)
+	}
+
+	moved, err := queries.ReassignMerchantTransactions(ctx, sqlc.ReassignMerchantTransactionsParams{
+		TargetID:  targetID,
+		Merchant:  target.Name,
+		SourceIds: sourceIDs,
+		UserID:    userID,
+	})
+	if err != nil {
+		return nil, 0, wrapErr("MerchantService.Merge.MoveTransactions", err)
+	}
+
+	_, err = queries.DeleteMerchants(ctx, sqlc.DeleteMerchantsParams{
+		Ids:    sourceIDs,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, 0, wrapErr("MerchantService.Merge.DeleteSources", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, 0, wrapErr("MerchantService.Merge.Commit", err)
+	}
+	s.cache.invalidate(userID)
+
+	merged, err := s.Get(ctx, userID, targetID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return merged, moved, nil
+}
+
+// ApplyToExisting links every transaction whose descriptor resolves to a
+// merchant other than the one it's already linked to
+func (s *merchantSvc) ApplyToExisting(ctx context.Context, userID uuid.UUID) (int64, error) {
+	matcher, err := s.matcher(ctx, userID)
+	if err != nil {
+		return 0, wrapErr("MerchantService.ApplyToExisting.FetchMerchants", err)
+	}
+
+	transactions, err := s.queries.ListAllTransactions(ctx, userID)
+	if err != nil {
+		return 0, wrapErr("MerchantService.ApplyToExisting.FetchTransactions", err)
+	}
+
+	resolved := make(map[int64]*sqlc.Merchant)
+	groups := make(map[int64][]int64)
+
+	for i := range transactions {
+		tx := &transactions[i]
+
+		merchant := matcher.Match(descriptorOf(tx))
+		if merchant == nil {
+			continue
+		}
+
+		alreadyLinked := tx.MerchantID != nil && *tx.MerchantID == merchant.ID
+		if alreadyLinked {
+			continue
+		}
+
+		resolved[merchant.ID] = merchant
+		groups[merchant.ID] = append(groups[merchant.ID], tx.ID)
+	}
+
+	var total int64
+	for merchantID, txIDs := range groups {
+		merchant := resolved[merchantID]
+
+		affected, err := s.queries.BulkSetTransactionMerchant(ctx, sqlc.BulkSetTransactionMerchantParams{
+			MerchantID:        merchant.ID,
+			Merchant:          merchant.Name,
+			DefaultCategoryID: merchant.DefaultCategoryID,
+			TransactionIds:    txIDs,
+			UserID:            userID,
+		})
+		if err != nil {
+			return total, wrapErr("MerchantService.ApplyToExisting.Update", err)
+		}
+		total += affected
+	}
+
+	return total, nil
+}
+
+// Resolve finds the merchant for a transaction's descriptor, or nil
+func (s *merchantSvc) Resolve(ctx context.Context, userID uuid.UUID, tx *sqlc.Transaction) (*sqlc.Merchant, error) {
+	matcher, err := s.matcher(ctx, userID)
+	if err != nil {
+		return nil, wrapErr("MerchantService.Resolve", err)
+	}
+
+	return matcher.Match(descriptorOf(tx)), nil
+}
+
+// ----- param builders ----------------------------------------------------------------------
+
+func buildCreateMerchantParams(userID uuid.UUID, req *pb.CreateMerchantRequest) sqlc.CreateMerchantParams {
+	return sqlc.CreateMerchantParams{
+		UserID:            userID,
+		Name:              strings.TrimSpace(req.GetName()),
+		Aliases:           nonNil(req.GetAliases()),
+		Patterns:          nonNil(req.GetPatterns()),
+		DefaultCategoryID: req.DefaultCategoryId,
+		LogoUrl:           req.LogoUrl,
+		Color:             nilIfEmpty(req.Color),
+	}
+}
+
+func buildUpdateMerchantParams(userID uuid.UUID, req *pb.UpdateMerchantRequest) sqlc.UpdateMerchantParams {
+	params := sqlc.UpdateMerchantParams{
+		ID:                req.GetId(),
+		UserID:            userID,
+		DefaultCategoryID: req.DefaultCategoryId,
+		LogoUrl:           req.LogoUrl,
+		Color:             req.Color,
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		params.Name = &name
+	}
+
+	// repeated fields have no presence, so an empty list only clears when masked
+	paths := req.GetUpdateMask().GetPaths()
+	if len(req.GetAliases()) > 0 || slices.Contains(paths, "aliases") {
+		params.Aliases = nonNil(req.GetAliases())
+	}
+	if len(req.GetPatterns()) > 0 || slices.Contains(paths, "patterns") {
+		params.Patterns = nonNil(req.GetPatterns())
+	}
+
+	return params
+}
+
+func buildMergeMerchantParams(userID uuid.UUID, target *sqlc.Merchant, sources []*sqlc.Merchant) sqlc.UpdateMerchantParams {
+	aliases := slices.Clone(target.Aliases)
+	patterns := slices.Clone(target.Patterns)
+
+	params := sqlc.UpdateMerchantParams{
+		ID:     target.ID,
+		UserID: userID,
+	}
+
+	for _, source := range sources {
+		aliases = append(aliases, source.Name)
+		aliases = append(aliases, source.Aliases...)
+		patterns = append(patterns, source.Patterns...)
+
+		if target.DefaultCategoryID == nil && params.DefaultCategoryID == nil {
+			params.DefaultCategoryID = source.DefaultCategoryID
+		}
+		if target.LogoUrl == nil && params.LogoUrl == nil {
+			params.LogoUrl = source.LogoUrl
+		}
+		if target.Color == nil && params.Color == nil {
+			params.Color = source.Color
+		}
+	}
+
+	params.Aliases = dedupeFold(aliases, target.Name)
+	params.Patterns = dedupeFold(patterns, "")
+
+	return params
+}
+
+// ----- conversion helpers ------------------------------------------------------------------
+
+func merchantToPb(m *sqlc.Merchant) *pb.Merchant {
+	merchant := &pb.Merchant{
+		Id:                m.ID,
+		Name:              m.Name,
+		Aliases:           m.Aliases,
+		Patterns:          m.Patterns,
+		DefaultCategoryId: m.DefaultCategoryID,
+		LogoUrl:           m.LogoUrl,
+		Color:             m.Color,
+	}
+
+	if !m.CreatedAt.IsZero() {
+		merchant.CreatedAt = timestamppb.New(m.CreatedAt)
+	}
+	if !m.UpdatedAt.IsZero() {
+		merchant.UpdatedAt = timestamppb.New(m.UpdatedAt)
+	}
+
+	return merchant
+}
+
+// ----- internal helpers --------------------------------------------------------------------
+
+func (s *merchantSvc) matcher(ctx context.Context, userID uuid.UUID) (*merchants.Matcher, error) {
+	matcher, gen, ok := s.cache.get(userID)
+	if ok {
+		return matcher, nil
+	}
+
+	rows, err := s.queries.ListMerchants(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher, err = merchants.NewMatcher(rows)
+	if err != nil {
+		s.log.Warn("skipping invalid merchant patterns", "user_id", userID, "error", err)
+	}
+
+	s.cache.put(userID, gen, matcher)
+	return matcher, nil
+}
+
+// descriptorOf is what a transaction is matched on: a manually entered
+// merchant name wins over the bank's raw description
+func descriptorOf(tx *sqlc.Transaction) string {
+	if tx.MerchantManuallySet && tx.Merchant != nil {
+		return *tx.Merchant
+	}
+	if tx.TxDesc != nil {
+		return *tx.TxDesc
+	}
+	return ""
+}
+
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %v: %w", pattern, err, ErrValidation)
+		}
+	}
+	return nil
+}
+
+var hexColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// validateColor accepts a #rrggbb color, or empty to leave it unset
+func validateColor(color *string) error {
+	if color == nil || *color == "" || hexColor.MatchString(*color) {
+		return nil
+	}
+	return fmt.Errorf("invalid color %q, expected #rrggbb: %w", *color, ErrValidation)
+}
+
+// nilIfEmpty stores an empty optional string as NULL
+func nilIfEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+// dedupeFold drops blanks, case-insensitive duplicates and anything equal to exclude
+func dedupeFold(values []string, exclude string) []string {
+	seen := map[string]bool{strings.ToLower(exclude): true}
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		key := strings.ToLower(v)
+		if v == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// nonNil keeps NOT NULL text[] columns from receiving NULL
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
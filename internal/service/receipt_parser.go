@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	pb "null-core/internal/gen/null/v1"
+	"null-core/internal/gen/null/v1/nullv1connect"
+	"null-core/internal/tracing"
+
+	"connectrpc.com/connect"
+	"golang.org/x/net/http2"
+	"google.golang.org/protobuf/proto"
+)
+
+// ReceiptParser turns a receipt's pages into structured data
+type ReceiptParser interface {
+	Parse(ctx context.Context, req *pb.ParseReceiptRequest) (*pb.ParsedReceipt, error)
+}
+
+// ErrManualEntry is returned by parsers that leave reading the receipt to
+// the user; the worker sends such receipts straight to review
+var ErrManualEntry = errors.New("receipt needs manual entry")
+
+// ----- ocr service -------------------------------------------------------------------------
+
+type ocrParser struct {
+	client nullv1connect.ReceiptOCRServiceClient
+}
+
+// NewOCRParser parses receipts with the null-receipts OCR service
+func NewOCRParser(url string) (ReceiptParser, error) {
+	// gRPC requires HTTP/2; over plaintext that means h2c,
+	// which http.DefaultClient doesn't support.
+	h2cClient := &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		},
+	}
+
+	tracer, err := tracing.ConnectInterceptor()
+	if err != nil {
+		return nil, fmt.Errorf("OCR client tracing: %w", err)
+	}
+
+	return &ocrParser{
+		client: nullv1connect.NewReceiptOCRServiceClient(
+			h2cClient,
+			url,
+			connect.WithGRPC(),
+			connect.WithInterceptors(tracer),
+		),
+	}, nil
+}
+
+func (p *ocrParser) Parse(ctx context.Context, req *pb.ParseReceiptRequest) (*pb.ParsedReceipt, error) {
+	resp, err := p.client.ParseReceipt(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, fmt.Errorf("OCR request: %w", err)
+	}
+
+	if !resp.Msg.Success || resp.Msg.Data == nil {
+		errMsg := "unknown"
+		if resp.Msg.Error != nil {
+			errMsg = resp.Msg.Error.Message
+		}
+		return nil, fmt.Errorf("OCR parsing: %s", errMsg)
+	}
+
+	return resp.Msg.Data, nil
+}
+
+// Health asks the OCR service whether it's up
+func (p *ocrParser) Health(ctx context.Context) error {
+	_, err := p.client.Health(ctx, connect.NewRequest(&pb.HealthRequest{}))
+	return err
+}
+
+// ----- manual entry ------------------------------------------------------------------------
+
+type manualParser struct{}
+
+// NewManualEntryParser never reads anything: receipts go straight to
+// NEEDS_REVIEW for the user to fill in. Used when no OCR service is set up.
+func NewManualEntryParser() ReceiptParser {
+	return manualParser{}
+}
+
+func (manualParser) Parse(context.Context, *pb.ParseReceiptRequest) (*pb.ParsedReceipt, error) {
+	return nil, ErrManualEntry
+}
+
+// ----- fake --------------------------------------------------------------------------------
+
+// FakeReceiptParser returns the same result for every receipt and records
+// what it was asked to parse. With no Result or Err set it returns
+// FakeParsedReceipt, which passes every review check.
+type FakeReceiptParser struct {
+	Result *pb.ParsedReceipt
+	Err    error
+
+	mu       sync.Mutex
+	requests []*pb.ParseReceiptRequest
+}
+
+func (f *FakeReceiptParser) Parse(ctx context.Context, req *pb.ParseReceiptRequest) (*pb.ParsedReceipt, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if f.Result != nil {
+		return proto.Clone(f.Result).(*pb.ParsedReceipt), nil
+	}
+	return FakeParsedReceipt(time.Now()), nil
+}
+
+// Requests returns every request parsed so far
+func (f *FakeReceiptParser) Requests() []*pb.ParseReceiptRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*pb.ParseReceiptRequest(nil), f.requests...)
+}
+
+// FakeParsedReceipt is a small, internally consistent grocery receipt dated on
+func FakeParsedReceipt(on time.Time) *pb.ParsedReceipt {
+	merchant := "Fake Market"
+	date := on.Format(time.DateOnly)
+	currency := "CAD"
+	subtotal, tax, total := 7.5, 0.0, 7.5
+	bananas, milk := "Bananas", "2% Milk 4L"
+
+	return &pb.ParsedReceipt{
+		Merchant: &merchant,
+		Date:     &date,
+		Currency: &currency,
+		Items: []*pb.ParsedItem{
+			{Raw: "BANANAS 4011", Name: &bananas, Qty: 2, UnitPrice: 0.75},
+			{Raw: "2% MILK 4L", Name: &milk, Qty: 1, UnitPrice: 6},
+		},
+		Subtotal:   &subtotal,
+		Tax:        &tax,
+		Total:      &total,
+		Confidence: 1,
+	}
+}
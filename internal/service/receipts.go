@@ -3,28 +3,36 @@ package service
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
+	"errors"
 	"fmt"
 	"image"
 	"image/jpeg"
 	"image/png"
+	"maps"
 	"math"
-	"net"
-	"net/http"
-	"os"
-	"path/filepath"
+	"path"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"null-core/internal/db/sqlc"
 	pb "null-core/internal/gen/null/v1"
-	"null-core/internal/gen/null/v1/nullv1connect"
+	"null-core/internal/logging"
+	"null-core/internal/metrics"
+	"null-core/internal/receipts"
+	"null-core/internal/storage"
+	"null-core/internal/tracing"
 
-	"connectrpc.com/connect"
 	"github.com/charmbracelet/log"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/image/draw"
 	_ "golang.org/x/image/webp"
-	"golang.org/x/net/http2"
 	"google.golang.org/genproto/googleapis/type/money"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -32,109 +40,135 @@ import (
 // ----- interface ---------------------------------------------------------------------------
 
 type ReceiptService interface {
-	Upload(ctx context.Context, userID uuid.UUID, imageData []byte, contentType string) (*pb.Receipt, error)
+	Upload(ctx context.Context, userID uuid.UUID, pages []ReceiptUpload) (*pb.Receipt, error)
 	Get(ctx context.Context, userID uuid.UUID, id int64) (*pb.Receipt, []*pb.ReceiptLinkCandidate, error)
 	List(ctx context.Context, userID uuid.UUID, req *pb.ListReceiptsRequest) ([]*pb.Receipt, int64, error)
 	Update(ctx context.Context, userID uuid.UUID, id int64, req *pb.UpdateReceiptRequest) (*pb.Receipt, error)
 	Delete(ctx context.Context, userID uuid.UUID, id int64) error
+	Retry(ctx context.Context, userID uuid.UUID, id int64) (*pb.Receipt, error)
+	GetImage(ctx context.Context, userID uuid.UUID, req *pb.GetReceiptImageRequest) (*ReceiptImage, error)
+	PriceHistory(ctx context.Context, userID uuid.UUID, req *pb.GetPriceHistoryRequest) ([]*pb.ItemPriceHistory, error)
 	StartWorker(ctx context.Context)
 }
 
 type rcptSvc struct {
-	queries   *sqlc.Queries
-	log       *log.Logger
-	ocrClient nullv1connect.ReceiptOCRServiceClient
-	dataDir   string
-}
-
-func newRcptSvc(queries *sqlc.Queries, logger *log.Logger, ocrURL string, dataDir string) ReceiptService {
-	var ocrClient nullv1connect.ReceiptOCRServiceClient
-	if ocrURL != "" {
-		// gRPC requires HTTP/2; over plaintext that means h2c,
-		// which http.DefaultClient doesn't support.
-		h2cClient := &http.Client{
-			Transport: &http2.Transport{
-				AllowHTTP: true,
-				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
-					var d net.Dialer
-					return d.DialContext(ctx, network, addr)
-				},
-			},
-		}
-		ocrClient = nullv1connect.NewReceiptOCRServiceClient(
-			h2cClient,
-			ocrURL,
-			connect.WithGRPC(),
-		)
-	}
+	queries  *sqlc.Queries
+	pool     *pgxpool.Pool
+	log      *log.Logger
+	parser   ReceiptParser
+	notifier jobNotifier
+	store    storage.BlobStore
+	rcptConfig
+
+	currencies currencyChecker
+}
+
+// rcptConfig tunes the OCR worker and upload handling
+type rcptConfig struct {
+	workers          int     // concurrent OCR jobs per replica
+	reviewConfidence float32 // parses below this go to NEEDS_REVIEW
+	pollInterval     time.Duration
+	ocrTimeout       time.Duration
+	maxAttempts      int32
+	maxImageDim      int // longest side uploads are scaled down to
+}
+
+// jobLease must outlast the OCR timeout or a slow job gets claimed twice
+func (c rcptConfig) jobLease() time.Duration {
+	return 2 * c.ocrTimeout
+}
+
+// ReceiptUpload is one page of an upload: an image or a PDF
+type ReceiptUpload struct {
+	Data        []byte
+	ContentType string
+}
+
+// ReceiptImage is either the image bytes or, when the blob store can sign
+// links and the caller asked for one, a url valid until ExpiresAt
+type ReceiptImage struct {
+	Data        []byte
+	ContentType string
+	URL         string
+	ExpiresAt   time.Time
+}
+
+// currencyChecker is satisfied by *exchange.Client
+type currencyChecker interface {
+	IsValidCurrency(ctx context.Context, code string) (bool, error)
+}
+
+// jobNotifier delivers postgres NOTIFY payloads; satisfied by *db.DB
+type jobNotifier interface {
+	Listen(ctx context.Context, channel string, onNotify func(payload string))
+}
+
+const (
+	receiptJobsChannel = "receipt_jobs"
+	receiptBaseBackoff = 30 * time.Second
+	receiptMaxBackoff  = time.Hour
+	receiptImageURLTTL = 15 * time.Minute
+	receiptMaxPages    = 20
+	priceHistoryLimit  = 20
+)
+
+func newRcptSvc(queries *sqlc.Queries, pool *pgxpool.Pool, logger *log.Logger, notifier jobNotifier, parser ReceiptParser, store storage.BlobStore, currencies currencyChecker, cfg rcptConfig) ReceiptService {
+	cfg.workers = max(cfg.workers, 1)
+
 	return &rcptSvc{
-		queries:   queries,
-		log:       logger,
-		ocrClient: ocrClient,
-		dataDir:   dataDir,
+		queries:    queries,
+		pool:       pool,
+		log:        logger,
+		parser:     parser,
+		notifier:   notifier,
+		store:      store,
+		rcptConfig: cfg,
+
+		currencies: currencies,
 	}
 }
 
 // ----- content type helpers ----------------------------------------------------------------
 
 var contentTypeToExt = map[string]string{
-	"image/jpeg": "jpg",
-	"image/png":  "png",
-	"image/webp": "webp",
-	"image/heic": "heic",
-}
-
-func extToContentType(ext string) string {
-	switch ext {
-	case ".jpg", ".jpeg":
-		return "image/jpeg"
-	case ".png":
-		return "image/png"
-	case ".webp":
-		return "image/webp"
-	case ".heic":
-		return "image/heic"
-	default:
-		return "application/octet-stream"
-	}
+	"image/jpeg":      "jpg",
+	"image/png":       "png",
+	"image/webp":      "webp",
+	"image/heic":      "heic",
+	"image/heif":      "heif",
+	"application/pdf": "pdf",
 }
 
 // ----- image resize ------------------------------------------------------------------------
 
-const maxImageDim = 1920
+// thumbnailDims is the longest side, in pixels, of each thumbnail size
+var thumbnailDims = map[pb.ReceiptImageSize]int{
+	pb.ReceiptImageSize_RECEIPT_IMAGE_SIZE_SMALL:  160,
+	pb.ReceiptImageSize_RECEIPT_IMAGE_SIZE_MEDIUM: 480,
+	pb.ReceiptImageSize_RECEIPT_IMAGE_SIZE_LARGE:  1024,
+}
 
-// resizeImage downscales an image so its longest side is at most maxImageDim.
-// Supported inputs: JPEG, PNG, WebP (via registered decoder).
-// Output is always JPEG. Returns original bytes unchanged for HEIC or on error.
-func resizeImage(data []byte, contentType string) ([]byte, string) {
-	// HEIC has no pure-Go decoder — pass through
-	if contentType == "image/heic" {
+// resizeImage downscales an image so its longest side is at most maxDim
+// and turns it upright per its EXIF orientation.
+// Supported inputs: JPEG, PNG, WebP, HEIC/HEIF.
+// Output is PNG for PNG input and JPEG otherwise. Returns original bytes
+// unchanged for PDF, for upright images that already fit, or on error.
+func resizeImage(data []byte, contentType string, maxDim int) ([]byte, string) {
+	if contentType == "application/pdf" {
 		return data, contentType
 	}
 
-	img, _, err := image.Decode(bytes.NewReader(data))
+	img, format, rotated, err := receipts.DecodeImage(data)
 	if err != nil {
 		return data, contentType
 	}
 
-	bounds := img.Bounds()
-	w, h := bounds.Dx(), bounds.Dy()
-
-	longest := w
-	if h > longest {
-		longest = h
-	}
-	if longest <= maxImageDim {
+	dst, scaled := scaleToFit(img, maxDim)
+	// HEIC is always re-encoded; the OCR service and browsers can't read it
+	if !scaled && !rotated && format != "heic" {
 		return data, contentType
 	}
 
-	scale := float64(maxImageDim) / float64(longest)
-	newW := int(math.Round(float64(w) * scale))
-	newH := int(math.Round(float64(h) * scale))
-
-	dst := image.NewRGBA(image.Rect(0, 0, newW, newH))
-	draw.BiLinear.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
-
 	var buf bytes.Buffer
 	if contentType == "image/png" {
 		if err := png.Encode(&buf, dst); err != nil {
@@ -143,46 +177,211 @@ func resizeImage(data []byte, contentType string) ([]byte, string) {
 		return buf.Bytes(), "image/png"
 	}
 
-	// JPEG for everything else (jpeg, webp input)
+	// JPEG for everything else (jpeg, webp, heic input)
 	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
 		return data, contentType
 	}
 	return buf.Bytes(), "image/jpeg"
 }
 
+// makeThumbnail renders an upright JPEG whose longest side is at most
+// maxDim; ok is false when the image can't be decoded (PDF)
+func makeThumbnail(data []byte, maxDim int) ([]byte, bool) {
+	img, _, _, err := receipts.DecodeImage(data)
+	if err != nil {
+		return nil, false
+	}
+
+	dst, _ := scaleToFit(img, maxDim)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, false
+	}
+	return buf.Bytes(), true
+}
+
+// thumbnailKey stores thumbnails next to the original: <hash>_<dim>.jpg
+func thumbnailKey(original string, maxDim int) string {
+	return fmt.Sprintf("%s_%d.jpg", strings.TrimSuffix(original, path.Ext(original)), maxDim)
+}
+
+// scaleToFit downscales img so its longest side is at most maxDim; images
+// that already fit are returned as-is with scaled false
+func scaleToFit(img image.Image, maxDim int) (image.Image, bool) {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	longest := max(w, h)
+	if longest <= maxDim {
+		return img, false
+	}
+
+	scale := float64(maxDim) / float64(longest)
+	newW := max(int(math.Round(float64(w)*scale)), 1)
+	newH := max(int(math.Round(float64(h)*scale)), 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, newW, newH))
+	draw.BiLinear.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	return dst, true
+}
+
 // ----- methods -----------------------------------------------------------------------------
 
-func (s *rcptSvc) Upload(ctx context.Context, userID uuid.UUID, imageData []byte, contentType string) (*pb.Receipt, error) {
-	ext, ok := contentTypeToExt[contentType]
-	if !ok {
-		return nil, fmt.Errorf("ReceiptService.Upload: unsupported content type %q: %w", contentType, ErrValidation)
+func (s *rcptSvc) Upload(ctx context.Context, userID uuid.UUID, pages []ReceiptUpload) (*pb.Receipt, error) {
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("ReceiptService.Upload: no image: %w", ErrValidation)
+	}
+	if len(pages) > receiptMaxPages {
+		return nil, fmt.Errorf("ReceiptService.Upload: more than %d pages: %w", receiptMaxPages, ErrValidation)
+	}
+	for _, page := range pages {
+		if _, ok := contentTypeToExt[page.ContentType]; !ok {
+			return nil, fmt.Errorf("ReceiptService.Upload: unsupported content type %q: %w", page.ContentType, ErrValidation)
+		}
 	}
 
-	imageData, contentType = resizeImage(imageData, contentType)
-	ext = contentTypeToExt[contentType]
+	blobs := make([]storedBlob, len(pages))
+	keys := make(map[string]bool, len(pages))
+	for i, page := range pages {
+		blobs[i] = s.prepareBlob(userID, page)
+		keys[blobs[i].key] = true
+	}
+
+	// the blob locks are held until the pages referencing them commit, so a
+	// concurrent Delete can't count zero references and remove a blob this
+	// upload is reusing
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, wrapErr("ReceiptService.Upload.Begin", err)
+	}
+	defer tx.Rollback(ctx)
+	queries := s.queries.WithTx(tx)
 
-	relPath := filepath.Join("receipts", userID.String(), uuid.New().String()+"."+ext)
-	absPath := filepath.Join(s.dataDir, relPath)
+	if err := lockBlobs(ctx, queries, keys); err != nil {
+		return nil, wrapErr("ReceiptService.Upload.Lock", err)
+	}
 
-	if err := os.MkdirAll(filepath.Dir(absPath), 0755); err != nil {
-		return nil, fmt.Errorf("ReceiptService.Upload: mkdir: %w", err)
+	// clean up blobs on error, unless an older receipt owns them
+	cleanup := func() {
+		for _, blob := range blobs {
+			if blob.created {
+				s.store.Delete(ctx, blob.key)
+			}
+		}
 	}
-	if err := os.WriteFile(absPath, imageData, 0644); err != nil {
-		return nil, fmt.Errorf("ReceiptService.Upload: write file: %w", err)
+
+	for i := range blobs {
+		if err := s.storeBlob(ctx, &blobs[i]); err != nil {
+			cleanup()
+			return nil, fmt.Errorf("ReceiptService.Upload: %w", err)
+		}
 	}
 
-	row, err := s.queries.CreateReceipt(ctx, sqlc.CreateReceiptParams{
+	row, err := queries.CreateReceipt(ctx, sqlc.CreateReceiptParams{
 		UserID:    userID,
-		ImagePath: relPath,
+		ImagePath: blobs[0].key,
 		Status:    int16(pb.ReceiptStatus_RECEIPT_STATUS_PENDING),
 	})
 	if err != nil {
-		// clean up file on DB error
-		os.Remove(absPath)
+		cleanup()
 		return nil, wrapErr("ReceiptService.Upload", err)
 	}
 
-	return s.receiptToPb(&row, nil), nil
+	pageRows := make([]sqlc.ReceiptPage, len(blobs))
+	for i, blob := range blobs {
+		pageRows[i], err = queries.CreateReceiptPage(ctx, sqlc.CreateReceiptPageParams{
+			ReceiptID:   row.ID,
+			PageNo:      int32(i + 1),
+			ImagePath:   blob.key,
+			ContentType: blob.contentType,
+		})
+		if err != nil {
+			cleanup()
+			return nil, wrapErr("ReceiptService.Upload.Page", err)
+		}
+	}
+
+	// no cleanup past this point: the locks are gone once the commit fails,
+	// and an orphaned blob is better than deleting one another upload reused
+	if err := tx.Commit(ctx); err != nil {
+		return nil, wrapErr("ReceiptService.Upload.Commit", err)
+	}
+
+	return s.receiptToPb(&row, nil, pageRows), nil
+}
+
+type storedBlob struct {
+	key         string
+	contentType string
+	data        []byte
+	created     bool
+}
+
+// prepareBlob downsizes one page and derives its content-addressed key, so
+// re-uploading the same photo reuses the existing blob
+func (s *rcptSvc) prepareBlob(userID uuid.UUID, page ReceiptUpload) storedBlob {
+	data, contentType := resizeImage(page.Data, page.ContentType, s.maxImageDim)
+	key := storage.ContentKey(path.Join("receipts", userID.String()), data, contentTypeToExt[contentType])
+
+	return storedBlob{key: key, contentType: contentType, data: data}
+}
+
+// storeBlob writes a prepared page unless its blob already exists. Callers
+// hold the blob's lock.
+func (s *rcptSvc) storeBlob(ctx context.Context, blob *storedBlob) error {
+	exists, err := s.store.Exists(ctx, blob.key)
+	if err != nil {
+		return fmt.Errorf("stat blob: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if err := s.store.Put(ctx, blob.key, blob.data, blob.contentType); err != nil {
+		return fmt.Errorf("store blob: %w", err)
+	}
+	blob.created = true
+
+	return nil
+}
+
+// lockBlobs takes the advisory lock for each blob key in a fixed order, so
+// two transactions locking overlapping pages can't deadlock
+func lockBlobs(ctx context.Context, queries *sqlc.Queries, keys map[string]bool) error {
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		if err := queries.LockBlob(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseBlob deletes a page's blob and its thumbnails once no receipt
+// references it anymore. Callers hold the blob's lock.
+func (s *rcptSvc) releaseBlob(ctx context.Context, queries *sqlc.Queries, userID uuid.UUID, key string) {
+	refs, err := queries.CountBlobReferences(ctx, sqlc.CountBlobReferencesParams{
+		UserID:    userID,
+		ImagePath: key,
+	})
+	if err != nil {
+		s.log.Warn("failed to count receipt image references", "key", key, "error", err)
+		return
+	}
+	if refs > 0 {
+		return
+	}
+
+	keys := []string{key}
+	for _, dim := range thumbnailDims {
+		keys = append(keys, thumbnailKey(key, dim))
+	}
+	for _, k := range keys {
+		if err := s.store.Delete(ctx, k); err != nil {
+			s.log.Warn("failed to remove receipt image", "key", k, "error", err)
+		}
+	}
 }
 
 func (s *rcptSvc) Get(ctx context.Context, userID uuid.UUID, id int64) (*pb.Receipt, []*pb.ReceiptLinkCandidate, error) {
@@ -199,10 +398,28 @@ func (s *rcptSvc) Get(ctx context.Context, userID uuid.UUID, id int64) (*pb.Rece
 		return nil, nil, wrapErr("ReceiptService.Get.Items", err)
 	}
 
-	receipt := s.receiptToPb(&row, items)
+	pages, err := s.queries.ListReceiptPages(ctx, row.ID)
+	if err != nil {
+		return nil, nil, wrapErr("ReceiptService.Get.Pages", err)
+	}
 
-	// link_candidates is out of scope — return empty
-	return receipt, nil, nil
+	receipt := s.receiptToPb(&row, items, pages)
+
+	if row.TransactionID != nil {
+		return receipt, nil, nil
+	}
+
+	candidates, err := s.linkCandidates(ctx, &row)
+	if err != nil {
+		return nil, nil, wrapErr("ReceiptService.Get.LinkCandidates", err)
+	}
+
+	result := make([]*pb.ReceiptLinkCandidate, len(candidates))
+	for i := range candidates {
+		result[i] = linkCandidateToPb(&candidates[i])
+	}
+
+	return receipt, result, nil
 }
 
 func (s *rcptSvc) List(ctx context.Context, userID uuid.UUID, req *pb.ListReceiptsRequest) ([]*pb.Receipt, int64, error) {
@@ -249,20 +466,59 @@ func (s *rcptSvc) List(ctx context.Context, userID uuid.UUID, req *pb.ListReceip
 			return nil, 0, wrapErr("ReceiptService.List.Items", err)
 		}
 
-		receipts[i] = s.listRowToPb(&rows[i], items)
+		pages, err := s.queries.ListReceiptPages(ctx, rows[i].ID)
+		if err != nil {
+			return nil, 0, wrapErr("ReceiptService.List.Pages", err)
+		}
+
+		receipts[i] = s.listRowToPb(&rows[i], items, pages)
 	}
 
 	return receipts, totalCount, nil
 }
 
 func (s *rcptSvc) Update(ctx context.Context, userID uuid.UUID, id int64, req *pb.UpdateReceiptRequest) (*pb.Receipt, error) {
-	params := sqlc.UpdateReceiptParams{
-		ID:     id,
-		UserID: userID,
+	params, err := buildUpdateReceiptParams(userID, id, req)
+	if err != nil {
+		return nil, fmt.Errorf("ReceiptService.Update: %w", err)
 	}
 
-	if req.TransactionId != nil {
-		params.TransactionID = req.TransactionId
+	current, err := s.queries.GetReceipt(ctx, sqlc.GetReceiptParams{ID: id, UserID: userID})
+	if err != nil {
+		return nil, wrapErr("ReceiptService.Update", err)
+	}
+
+	// a receipt still queued for OCR only records the link; the worker
+	// overwrites the header and items when it finishes and marks it LINKED
+	if current.Status == pb.ReceiptStatus_RECEIPT_STATUS_PENDING && editsContent(req) {
+		return nil, fmt.Errorf("ReceiptService.Update: receipt is still being processed, only transaction_id can be set: %w", ErrValidation)
+	}
+	params.TransactionID = req.TransactionId
+	linked := req.TransactionId != nil || current.TransactionID != nil
+	linkedStatus := int16(pb.ReceiptStatus_RECEIPT_STATUS_LINKED)
+
+	switch current.Status {
+	case pb.ReceiptStatus_RECEIPT_STATUS_PARSED:
+		if linked {
+			params.Status = &linkedStatus
+		}
+	case pb.ReceiptStatus_RECEIPT_STATUS_NEEDS_REVIEW:
+		// a user editing a flagged receipt is the review; take it off the queue
+		parsedStatus := int16(pb.ReceiptStatus_RECEIPT_STATUS_PARSED)
+		if linked {
+			parsedStatus = linkedStatus
+		}
+		params.Status = &parsedStatus
+		params.ReviewReasons = []string{}
+	}
+
+	for _, item := range req.Items {
+		if item.CategoryId == nil {
+			continue
+		}
+		if _, err := s.queries.GetCategory(ctx, sqlc.GetCategoryParams{ID: *item.CategoryId, UserID: userID}); err != nil {
+			return nil, fmt.Errorf("ReceiptService.Update: category %d not found: %w", *item.CategoryId, ErrValidation)
+		}
 	}
 
 	row, err := s.queries.UpdateReceipt(ctx, params)
@@ -270,16 +526,24 @@ func (s *rcptSvc) Update(ctx context.Context, userID uuid.UUID, id int64, req *p
 		return nil, wrapErr("ReceiptService.Update", err)
 	}
 
+	// the worker may have finished parsing since current was read
+	if req.TransactionId != nil {
+		if err := s.promoteLinked(ctx, &row); err != nil {
+			return nil, wrapErr("ReceiptService.Update.Link", err)
+		}
+	}
+
+	currency := "CAD"
+	if row.Currency != nil {
+		currency = *row.Currency
+	}
+
 	// if items provided, replace all items
 	if len(req.Items) > 0 {
 		if err := s.queries.DeleteReceiptItemsByReceipt(ctx, row.ID); err != nil {
 			return nil, wrapErr("ReceiptService.Update.DeleteItems", err)
 		}
 		for i, item := range req.Items {
-			currency := "CAD"
-			if row.Currency != nil {
-				currency = *row.Currency
-			}
 			_, err := s.queries.CreateReceiptItem(ctx, sqlc.CreateReceiptItemParams{
 				ReceiptID:      row.ID,
 				RawName:        item.RawName,
@@ -288,11 +552,21 @@ func (s *rcptSvc) Update(ctx context.Context, userID uuid.UUID, id int64, req *p
 				UnitPriceCents: item.UnitPriceCents,
 				UnitCurrency:   currency,
 				SortOrder:      int32(i),
+				CategoryID:     item.CategoryId,
 			})
 			if err != nil {
 				return nil, wrapErr("ReceiptService.Update.CreateItem", err)
 			}
 		}
+	} else if params.Currency != nil {
+		// existing items are priced in the receipt's currency
+		err := s.queries.UpdateReceiptItemsCurrency(ctx, sqlc.UpdateReceiptItemsCurrencyParams{
+			ReceiptID:    row.ID,
+			UnitCurrency: currency,
+		})
+		if err != nil {
+			return nil, wrapErr("ReceiptService.Update.ItemsCurrency", err)
+		}
 	}
 
 	items, err := s.queries.ListReceiptItems(ctx, row.ID)
@@ -300,7 +574,12 @@ func (s *rcptSvc) Update(ctx context.Context, userID uuid.UUID, id int64, req *p
 		return nil, wrapErr("ReceiptService.Update.ListItems", err)
 	}
 
-	return s.receiptToPb(&row, items), nil
+	pages, err := s.queries.ListReceiptPages(ctx, row.ID)
+	if err != nil {
+		return nil, wrapErr("ReceiptService.Update.ListPages", err)
+	}
+
+	return s.receiptToPb(&row, items, pages), nil
 }
 
 func (s *rcptSvc) Delete(ctx context.Context, userID uuid.UUID, id int64) error {
@@ -312,92 +591,398 @@ func (s *rcptSvc) Delete(ctx context.Context, userID uuid.UUID, id int64) error
 		return wrapErr("ReceiptService.Delete", err)
 	}
 
-	if err := s.queries.DeleteReceipt(ctx, sqlc.DeleteReceiptParams{
+	pages, err := s.queries.ListReceiptPages(ctx, row.ID)
+	if err != nil {
+		return wrapErr("ReceiptService.Delete.Pages", err)
+	}
+
+	keys := map[string]bool{row.ImagePath: true}
+	for _, page := range pages {
+		keys[page.ImagePath] = true
+	}
+
+	// count references and delete blobs under their locks, so an upload
+	// reusing one either commits first and is counted or waits and re-puts it
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return wrapErr("ReceiptService.Delete.Begin", err)
+	}
+	defer tx.Rollback(ctx)
+	queries := s.queries.WithTx(tx)
+
+	if err := lockBlobs(ctx, queries, keys); err != nil {
+		return wrapErr("ReceiptService.Delete.Lock", err)
+	}
+
+	if err := queries.DeleteReceipt(ctx, sqlc.DeleteReceiptParams{
 		ID:     id,
 		UserID: userID,
 	}); err != nil {
 		return wrapErr("ReceiptService.Delete", err)
 	}
 
-	absPath := filepath.Join(s.dataDir, row.ImagePath)
-	if err := os.Remove(absPath); err != nil {
-		s.log.Warn("failed to remove receipt image", "path", absPath, "error", err)
+	for key := range keys {
+		s.releaseBlob(ctx, queries, userID, key)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return wrapErr("ReceiptService.Delete.Commit", err)
 	}
 
 	return nil
 }
 
+func (s *rcptSvc) Retry(ctx context.Context, userID uuid.UUID, id int64) (*pb.Receipt, error) {
+	row, err := s.queries.GetReceipt(ctx, sqlc.GetReceiptParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, wrapErr("ReceiptService.Retry", err)
+	}
+
+	// parsed receipts already have items; re-running OCR would clobber edits.
+	// flagged ones haven't been touched yet, so a second read is fair game
+	retryable := row.Status == pb.ReceiptStatus_RECEIPT_STATUS_FAILED ||
+		row.Status == pb.ReceiptStatus_RECEIPT_STATUS_PENDING ||
+		row.Status == pb.ReceiptStatus_RECEIPT_STATUS_NEEDS_REVIEW
+	if !retryable {
+		return nil, fmt.Errorf("ReceiptService.Retry: receipt is %s: %w", row.Status, ErrValidation)
+	}
+
+	row, err = s.queries.RetryReceipt(ctx, sqlc.RetryReceiptParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("ReceiptService.Retry: receipt is being processed: %w", ErrValidation)
+	}
+	if err != nil {
+		return nil, wrapErr("ReceiptService.Retry", err)
+	}
+
+	items, err := s.queries.ListReceiptItems(ctx, row.ID)
+	if err != nil {
+		return nil, wrapErr("ReceiptService.Retry.Items", err)
+	}
+
+	pages, err := s.queries.ListReceiptPages(ctx, row.ID)
+	if err != nil {
+		return nil, wrapErr("ReceiptService.Retry.Pages", err)
+	}
+
+	return s.receiptToPb(&row, items, pages), nil
+}
+
+func (s *rcptSvc) GetImage(ctx context.Context, userID uuid.UUID, req *pb.GetReceiptImageRequest) (*ReceiptImage, error) {
+	row, err := s.queries.GetReceipt(ctx, sqlc.GetReceiptParams{
+		ID:     req.GetId(),
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, wrapErr("ReceiptService.GetImage", err)
+	}
+
+	key := row.ImagePath
+	if req.GetPage() > 1 {
+		page, err := s.queries.GetReceiptPage(ctx, sqlc.GetReceiptPageParams{
+			ReceiptID: row.ID,
+			PageNo:    req.GetPage(),
+		})
+		if err != nil {
+			return nil, wrapErr("ReceiptService.GetImage.Page", err)
+		}
+		key = page.ImagePath
+	}
+
+	var data []byte
+
+	if dim, ok := thumbnailDims[req.GetSize()]; ok {
+		key, data, err = s.thumbnail(ctx, key, dim)
+		if err != nil {
+			return nil, fmt.Errorf("ReceiptService.GetImage: thumbnail: %w", err)
+		}
+	}
+
+	if req.GetPreferUrl() {
+		url, err := s.store.SignedURL(ctx, key, receiptImageURLTTL)
+		if err == nil {
+			return &ReceiptImage{
+				ContentType: storage.ContentType(key),
+				URL:         url,
+				ExpiresAt:   time.Now().Add(receiptImageURLTTL),
+			}, nil
+		}
+		if !errors.Is(err, storage.ErrUnsupported) {
+			return nil, fmt.Errorf("ReceiptService.GetImage: sign url: %w", err)
+		}
+	}
+
+	if data != nil {
+		return &ReceiptImage{Data: data, ContentType: storage.ContentType(key)}, nil
+	}
+
+	data, contentType, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("ReceiptService.GetImage: %w", err)
+	}
+
+	return &ReceiptImage{Data: data, ContentType: contentType}, nil
+}
+
+// thumbnail returns the cached thumbnail of original, rendering and storing
+// it on first use. Images that can't be decoded fall back to the original.
+func (s *rcptSvc) thumbnail(ctx context.Context, original string, maxDim int) (string, []byte, error) {
+	key := thumbnailKey(original, maxDim)
+
+	data, _, err := s.store.Get(ctx, key)
+	if err == nil {
+		return key, data, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return "", nil, err
+	}
+
+	src, _, err := s.store.Get(ctx, original)
+	if err != nil {
+		return "", nil, err
+	}
+
+	thumb, ok := makeThumbnail(src, maxDim)
+	if !ok {
+		return original, src, nil
+	}
+
+	if err := s.store.Put(ctx, key, thumb, "image/jpeg"); err != nil {
+		return "", nil, fmt.Errorf("cache: %w", err)
+	}
+
+	return key, thumb, nil
+}
+
+// ----- price history -----------------------------------------------------------------------
+
+func (s *rcptSvc) PriceHistory(ctx context.Context, userID uuid.UUID, req *pb.GetPriceHistoryRequest) ([]*pb.ItemPriceHistory, error) {
+	params := sqlc.ListItemPricesParams{
+		UserID: userID,
+		Start:  dateToTime(req.StartDate),
+		End:    dateToTime(req.EndDate),
+	}
+
+	rows, err := s.queries.ListItemPrices(ctx, params)
+	if err != nil {
+		return nil, wrapErr("ReceiptService.PriceHistory", err)
+	}
+
+	obs := make([]receipts.PriceObservation, len(rows))
+	for i, row := range rows {
+		name := row.RawName
+		if row.Name != nil && *row.Name != "" {
+			name = *row.Name
+		}
+		merchant := ""
+		if row.Merchant != nil {
+			merchant = *row.Merchant
+		}
+		obs[i] = receipts.PriceObservation{
+			ReceiptID:      row.ReceiptID,
+			Name:           name,
+			Merchant:       merchant,
+			Date:           row.PurchasedOn,
+			UnitPriceCents: row.UnitPriceCents,
+			Currency:       row.UnitCurrency,
+		}
+	}
+
+	limit := priceHistoryLimit
+	if req.Limit != nil {
+		limit = int(*req.Limit)
+	}
+
+	history := receipts.PriceHistory(obs, req.Query, limit)
+
+	items := make([]*pb.ItemPriceHistory, len(history))
+	for i := range history {
+		items[i] = itemPriceHistoryToPb(&history[i])
+	}
+	return items, nil
+}
+
 // ----- background worker -------------------------------------------------------------------
 
+// StartWorker runs the OCR job queue until ctx is cancelled. Jobs are claimed
+// with SKIP LOCKED so several replicas can share the queue; new receipts are
+// picked up immediately via NOTIFY, and the ticker catches retries whose
+// backoff has elapsed plus anything missed while the listener was down.
+// Cancelling ctx stops new claims; jobs already running are finished first.
 func (s *rcptSvc) StartWorker(ctx context.Context) {
-	if s.ocrClient == nil {
-		s.log.Warn("OCR client not configured, receipt worker disabled")
-		return
+	s.log.Info("receipt OCR worker started", "concurrency", s.workers, "parser", fmt.Sprintf("%T", s.parser))
+
+	wake := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
 	}
 
-	s.log.Info("receipt OCR worker started")
+	if s.notifier != nil {
+		go s.notifier.Listen(ctx, receiptJobsChannel, func(string) { notify() })
+	}
 
-	ticker := time.NewTicker(30 * time.Second)
+	ticker := time.NewTicker(s.pollInterval)
 	defer ticker.Stop()
 
+	slots := make(chan struct{}, s.workers)
+	var wg sync.WaitGroup
+
 	for {
+		s.dispatchReceiptJobs(ctx, slots, &wg, notify)
+
 		select {
 		case <-ctx.Done():
+			s.log.Info("receipt OCR worker draining", "in_flight", len(slots))
+			wg.Wait()
 			s.log.Info("receipt OCR worker stopped")
 			return
 		case <-ticker.C:
-			s.processPendingReceipts(ctx)
+		case <-wake:
 		}
 	}
 }
 
-func (s *rcptSvc) processPendingReceipts(ctx context.Context) {
-	pending, err := s.queries.GetPendingReceipts(ctx)
+// dispatchReceiptJobs claims as many due jobs as there are free slots and
+// runs each in its own goroutine; a finished job signals for more. Jobs
+// don't see ctx's cancellation so a shutdown never cuts one off mid-write;
+// the OCR timeout still bounds them.
+func (s *rcptSvc) dispatchReceiptJobs(ctx context.Context, slots chan struct{}, wg *sync.WaitGroup, done func()) {
+	jobCtx := context.WithoutCancel(ctx)
+
+	// jobs whose last attempt died with its worker are never claimed again
+	expired, err := s.queries.FailExpiredReceiptLeases(ctx, s.maxAttempts)
 	if err != nil {
-		s.log.Error("failed to query pending receipts", "error", err)
-		return
+		s.log.Error("failed to expire receipt leases", "error", err)
+	} else if expired > 0 {
+		s.log.Warn("receipts failed after their last lease expired", "count", expired)
 	}
 
-	for _, receipt := range pending {
-		s.processOneReceipt(ctx, receipt)
+	for {
+		free := cap(slots) - len(slots)
+		if free == 0 || ctx.Err() != nil {
+			return
+		}
+
+		claimed, err := s.queries.ClaimPendingReceipts(ctx, sqlc.ClaimPendingReceiptsParams{
+			LeaseSeconds: int32(s.jobLease().Seconds()),
+			MaxAttempts:  s.maxAttempts,
+			Lim:          int32(free),
+		})
+		if err != nil {
+			s.log.Error("failed to claim pending receipts", "error", err)
+			return
+		}
+
+		for _, receipt := range claimed {
+			slots <- struct{}{}
+			wg.Add(1)
+			go func(receipt sqlc.Receipt) {
+				defer func() {
+					<-slots
+					wg.Done()
+					done()
+				}()
+				s.runReceiptJob(jobCtx, receipt)
+			}(receipt)
+		}
+
+		if len(claimed) < free {
+			return
+		}
 	}
 }
 
-func (s *rcptSvc) processOneReceipt(ctx context.Context, receipt sqlc.Receipt) {
-	absPath := filepath.Join(s.dataDir, receipt.ImagePath)
-	imageData, err := os.ReadFile(absPath)
-	if err != nil {
-		s.log.Error("failed to read receipt image", "id", receipt.ID, "path", absPath, "error", err)
-		s.setReceiptFailed(ctx, receipt)
+// errReceiptLeaseLost means the job was requeued, deleted or its lease ran
+// out while OCR was running
+var errReceiptLeaseLost = errors.New("receipt job no longer leased")
+
+func (s *rcptSvc) runReceiptJob(ctx context.Context, receipt sqlc.Receipt) {
+	// each job is its own trace; the upload that queued it finished long ago
+	ctx, span := tracing.Tracer().Start(ctx, "ProcessReceipt",
+		trace.WithNewRoot(),
+		trace.WithAttributes(
+			attribute.Int64("receipt.id", receipt.ID),
+			attribute.Int("receipt.attempt", int(receipt.Attempts)),
+		),
+	)
+	defer span.End()
+	logger := logging.Logger(ctx, s.log)
+
+	jobErr := s.processOneReceipt(ctx, receipt)
+	if jobErr == nil {
+		if err := s.queries.CompleteReceiptJob(ctx, receipt.ID); err != nil {
+			logger.Error("failed to release receipt job", "id", receipt.ID, "error", err)
+		}
+		return
+	}
+	if errors.Is(jobErr, errReceiptLeaseLost) {
+		// whoever holds the job now records its outcome
+		logger.Warn("receipt job lost its lease, dropping the result", "id", receipt.ID, "attempt", receipt.Attempts)
 		return
 	}
 
-	contentType := extToContentType(filepath.Ext(receipt.ImagePath))
+	span.RecordError(jobErr)
+	span.SetStatus(codes.Error, jobErr.Error())
 
-	ocrCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
-	defer cancel()
+	backoff := receiptBackoff(receipt.Attempts)
+	if receipt.Attempts >= s.maxAttempts {
+		logger.Error("receipt OCR failed, giving up", "id", receipt.ID, "attempts", receipt.Attempts, "error", jobErr)
+	} else {
+		logger.Warn("receipt OCR attempt failed", "id", receipt.ID, "attempt", receipt.Attempts, "retry_in", backoff, "error", jobErr)
+	}
 
-	resp, err := s.ocrClient.ParseReceipt(ocrCtx, connect.NewRequest(&pb.ParseReceiptRequest{
-		ImageData:   imageData,
-		ContentType: contentType,
-	}))
+	err := s.queries.FailReceiptAttempt(ctx, sqlc.FailReceiptAttemptParams{
+		ID:             receipt.ID,
+		LastError:      jobErr.Error(),
+		BackoffSeconds: int32(backoff.Seconds()),
+		MaxAttempts:    s.maxAttempts,
+	})
 	if err != nil {
-		s.log.Error("OCR request failed", "id", receipt.ID, "error", err)
-		s.setReceiptFailed(ctx, receipt)
-		return
+		logger.Error("failed to record receipt OCR failure", "id", receipt.ID, "error", err)
 	}
+}
 
-	if !resp.Msg.Success || resp.Msg.Data == nil {
-		errMsg := "unknown"
-		if resp.Msg.Error != nil {
-			errMsg = resp.Msg.Error.Message
-		}
-		s.log.Error("OCR parsing failed", "id", receipt.ID, "error", errMsg)
-		s.setReceiptFailed(ctx, receipt)
-		return
+// receiptBackoff doubles from receiptBaseBackoff per attempt, capped at receiptMaxBackoff
+func receiptBackoff(attempts int32) time.Duration {
+	backoff := receiptBaseBackoff
+	for i := int32(1); i < attempts && backoff < receiptMaxBackoff; i++ {
+		backoff *= 2
 	}
+	return min(backoff, receiptMaxBackoff)
+}
 
-	parsed := resp.Msg.Data
+func (s *rcptSvc) processOneReceipt(ctx context.Context, receipt sqlc.Receipt) error {
+	req, err := s.parseRequest(ctx, receipt)
+	if err != nil {
+		return err
+	}
+
+	ocrCtx, cancel := context.WithTimeout(ctx, s.ocrTimeout)
+	defer cancel()
+
+	start := time.Now()
+	parsed, err := s.parser.Parse(ocrCtx, req)
+	result := metrics.Result(err)
+	if errors.Is(err, ErrManualEntry) {
+		result = "manual"
+	}
+	metrics.OCRDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+
+	if errors.Is(err, ErrManualEntry) {
+		return s.awaitManualEntry(ctx, receipt)
+	}
+	if err != nil {
+		return err
+	}
 
 	updateParams := sqlc.UpdateReceiptParams{
 		ID:     receipt.ID,
@@ -406,6 +991,7 @@ func (s *rcptSvc) processOneReceipt(ctx context.Context, receipt sqlc.Receipt) {
 
 	parsedStatus := int16(pb.ReceiptStatus_RECEIPT_STATUS_PARSED)
 	updateParams.Status = &parsedStatus
+	updateParams.ReviewReasons = []string{}
 
 	if parsed.Merchant != nil {
 		updateParams.Merchant = parsed.Merchant
@@ -442,14 +1028,34 @@ func (s *rcptSvc) processOneReceipt(ctx context.Context, receipt sqlc.Receipt) {
 		updateParams.TotalCents = &cents
 	}
 
-	if _, err := s.queries.UpdateReceipt(ctx, updateParams); err != nil {
-		s.log.Error("failed to update receipt from OCR", "id", receipt.ID, "error", err)
-		return
+	// the header, items and review flag land together, and only while this
+	// worker still owns the job; a retry or an expired lease means someone
+	// else's results win
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	queries := s.queries.WithTx(tx)
+
+	if _, err := queries.LockReceiptLease(ctx, receipt.ID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errReceiptLeaseLost
+		}
+		return fmt.Errorf("lock lease: %w", err)
+	}
+
+	updated, err := queries.UpdateReceipt(ctx, updateParams)
+	if err != nil {
+		return fmt.Errorf("update receipt: %w", err)
+	}
+
+	if err := queries.DeleteReceiptItemsByReceipt(ctx, receipt.ID); err != nil {
+		return fmt.Errorf("clear items: %w", err)
 	}
 
-	// insert parsed items
 	for i, item := range parsed.Items {
-		_, err := s.queries.CreateReceiptItem(ctx, sqlc.CreateReceiptItemParams{
+		_, err := queries.CreateReceiptItem(ctx, sqlc.CreateReceiptItemParams{
 			ReceiptID:      receipt.ID,
 			RawName:        item.Raw,
 			Name:           item.Name,
@@ -459,23 +1065,272 @@ func (s *rcptSvc) processOneReceipt(ctx context.Context, receipt sqlc.Receipt) {
 			SortOrder:      int32(i),
 		})
 		if err != nil {
-			s.log.Error("failed to create receipt item from OCR", "receipt_id", receipt.ID, "error", err)
+			return fmt.Errorf("create item %d: %w", i, err)
 		}
 	}
 
-	s.log.Info("receipt parsed successfully", "id", receipt.ID, "merchant", parsed.GetMerchant(), "items", len(parsed.Items))
+	flagged, err := s.review(ctx, queries, &updated)
+	if err != nil {
+		return fmt.Errorf("review: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	logging.Logger(ctx, s.log).Info("receipt parsed successfully", "id", receipt.ID, "merchant", parsed.GetMerchant(), "items", len(parsed.Items))
+
+	if flagged {
+		// don't link a receipt whose total we don't trust
+		return nil
+	}
+
+	s.autoLink(ctx, &updated)
+
+	return nil
 }
 
-func (s *rcptSvc) setReceiptFailed(ctx context.Context, receipt sqlc.Receipt) {
-	failedStatus := int16(pb.ReceiptStatus_RECEIPT_STATUS_FAILED)
+// awaitManualEntry queues a receipt nobody is going to parse for the user
+// to fill in by hand
+func (s *rcptSvc) awaitManualEntry(ctx context.Context, receipt sqlc.Receipt) error {
+	reviewStatus := int16(pb.ReceiptStatus_RECEIPT_STATUS_NEEDS_REVIEW)
 	_, err := s.queries.UpdateReceipt(ctx, sqlc.UpdateReceiptParams{
+		ID:            receipt.ID,
+		UserID:        receipt.UserID,
+		Status:        &reviewStatus,
+		ReviewReasons: []string{"not parsed automatically, enter the details by hand"},
+	})
+	if err != nil {
+		return fmt.Errorf("queue for manual entry: %w", err)
+	}
+
+	logging.Logger(ctx, s.log).Info("receipt awaiting manual entry", "id", receipt.ID)
+	return nil
+}
+
+// review runs the post-OCR checks and moves a doubtful receipt to
+// NEEDS_REVIEW, reporting whether it did
+func (s *rcptSvc) review(ctx context.Context, queries *sqlc.Queries, receipt *sqlc.Receipt) (bool, error) {
+	items, err := queries.ListReceiptItems(ctx, receipt.ID)
+	if err != nil {
+		return false, fmt.Errorf("list items: %w", err)
+	}
+
+	reasons := receipts.Review(receipt, items, receipts.ReviewOptions{
+		MinConfidence: s.reviewConfidence,
+		Now:           time.Now(),
+		KnownCurrency: func(code string) bool { return s.knownCurrency(ctx, code) },
+	})
+	if len(reasons) == 0 {
+		return false, nil
+	}
+
+	reviewStatus := int16(pb.ReceiptStatus_RECEIPT_STATUS_NEEDS_REVIEW)
+	_, err = queries.UpdateReceipt(ctx, sqlc.UpdateReceiptParams{
+		ID:            receipt.ID,
+		UserID:        receipt.UserID,
+		Status:        &reviewStatus,
+		ReviewReasons: reasons,
+	})
+	if err != nil {
+		return false, fmt.Errorf("flag for review: %w", err)
+	}
+
+	logging.Logger(ctx, s.log).Info("receipt needs review", "id", receipt.ID, "reasons", reasons)
+	return true, nil
+}
+
+// knownCurrency gives the benefit of the doubt when the exchange API is
+// unreachable; a flaky upstream shouldn't flood the review queue
+func (s *rcptSvc) knownCurrency(ctx context.Context, code string) bool {
+	if s.currencies == nil {
+		return true
+	}
+	ok, err := s.currencies.IsValidCurrency(ctx, code)
+	if err != nil {
+		logging.Logger(ctx, s.log).Warn("failed to check receipt currency", "currency", code, "error", err)
+		return true
+	}
+	return ok
+}
+
+// parseRequest loads every page of the receipt for the OCR service. Text
+// PDFs also carry their embedded text so the parser can skip OCR.
+func (s *rcptSvc) parseRequest(ctx context.Context, receipt sqlc.Receipt) (*pb.ParseReceiptRequest, error) {
+	pages, err := s.queries.ListReceiptPages(ctx, receipt.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list pages: %w", err)
+	}
+	if len(pages) == 0 {
+		pages = []sqlc.ReceiptPage{{PageNo: 1, ImagePath: receipt.ImagePath, ContentType: storage.ContentType(receipt.ImagePath)}}
+	}
+
+	req := &pb.ParseReceiptRequest{}
+	var texts []string
+
+	for _, page := range pages {
+		data, _, err := s.store.Get(ctx, page.ImagePath)
+		if err != nil {
+			return nil, fmt.Errorf("read page %d: %w", page.PageNo, err)
+		}
+
+		if page.ContentType == "application/pdf" {
+			if text, ok := receipts.PDFText(data); ok {
+				texts = append(texts, text)
+			}
+		}
+
+		req.Pages = append(req.Pages, &pb.ParseReceiptPage{
+			Data:        data,
+			ContentType: page.ContentType,
+		})
+	}
+
+	req.ImageData = req.Pages[0].Data
+	req.ContentType = req.Pages[0].ContentType
+	if len(req.Pages) == 1 {
+		req.Pages = nil
+	}
+
+	if len(texts) > 0 {
+		text := strings.Join(texts, "\n\n")
+		req.Text = &text
+	}
+
+	return req, nil
+}
+
+// autoLink links the receipt when exactly one transaction is a confident match
+func (s *rcptSvc) autoLink(ctx context.Context, receipt *sqlc.Receipt) {
+	// linked by the user while it was queued; keep their link
+	if receipt.TransactionID != nil {
+		if err := s.promoteLinked(ctx, receipt); err != nil {
+			logging.Logger(ctx, s.log).Error("failed to mark receipt linked", "id", receipt.ID, "error", err)
+		}
+		return
+	}
+
+	candidates, err := s.linkCandidates(ctx, receipt)
+	if err != nil {
+		logging.Logger(ctx, s.log).Warn("failed to find link candidates", "id", receipt.ID, "error", err)
+		return
+	}
+
+	target, ok := receipts.AutoLinkTarget(candidates)
+	if !ok {
+		return
+	}
+
+	linkedStatus := int16(pb.ReceiptStatus_RECEIPT_STATUS_LINKED)
+	_, err = s.queries.UpdateReceipt(ctx, sqlc.UpdateReceiptParams{
+		ID:            receipt.ID,
+		UserID:        receipt.UserID,
+		TransactionID: &target.Tx.ID,
+		Status:        &linkedStatus,
+	})
+	if err != nil {
+		logging.Logger(ctx, s.log).Error("failed to auto-link receipt", "id", receipt.ID, "transaction_id", target.Tx.ID, "error", err)
+		return
+	}
+
+	logging.Logger(ctx, s.log).Info("receipt auto-linked", "id", receipt.ID, "transaction_id", target.Tx.ID, "score", target.Score)
+}
+
+// promoteLinked moves a parsed receipt that already has a transaction to
+// LINKED, updating receipt in place
+func (s *rcptSvc) promoteLinked(ctx context.Context, receipt *sqlc.Receipt) error {
+	if receipt.TransactionID == nil || receipt.Status != pb.ReceiptStatus_RECEIPT_STATUS_PARSED {
+		return nil
+	}
+
+	linkedStatus := int16(pb.ReceiptStatus_RECEIPT_STATUS_LINKED)
+	updated, err := s.queries.UpdateReceipt(ctx, sqlc.UpdateReceiptParams{
 		ID:     receipt.ID,
 		UserID: receipt.UserID,
-		Status: &failedStatus,
+		Status: &linkedStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	*receipt = updated
+	return nil
+}
+
+// linkCandidates returns unlinked transactions that could be the receipt's
+// purchase, best match first
+func (s *rcptSvc) linkCandidates(ctx context.Context, receipt *sqlc.Receipt) ([]receipts.Candidate, error) {
+	start, end, minCents, maxCents, ok := receipts.SearchWindow(receipt)
+	if !ok {
+		return nil, nil
+	}
+
+	rows, err := s.queries.ListReceiptLinkCandidates(ctx, sqlc.ListReceiptLinkCandidatesParams{
+		UserID:    receipt.UserID,
+		StartDate: start,
+		EndDate:   end,
+		MinCents:  minCents,
+		MaxCents:  maxCents,
+		ReceiptID: receipt.ID,
 	})
 	if err != nil {
-		s.log.Error("failed to set receipt status to FAILED", "id", receipt.ID, "error", err)
+		return nil, err
+	}
+
+	return receipts.Rank(receipt, rows), nil
+}
+
+// buildUpdateReceiptParams maps header corrections onto the update; amounts
+// must agree with the receipt currency when both are given
+func buildUpdateReceiptParams(userID uuid.UUID, id int64, req *pb.UpdateReceiptRequest) (sqlc.UpdateReceiptParams, error) {
+	params := sqlc.UpdateReceiptParams{
+		ID:          id,
+		UserID:      userID,
+		Merchant:    req.Merchant,
+		ReceiptDate: dateToTime(req.ReceiptDate),
+	}
+
+	var currency string
+	if req.Currency != nil {
+		currency = strings.ToUpper(*req.Currency)
+	}
+
+	amounts := []struct {
+		money *money.Money
+		dst   **int64
+	}{
+		{req.Subtotal, &params.SubtotalCents},
+		{req.Tax, &params.TaxCents},
+		{req.Total, &params.TotalCents},
+	}
+	for _, a := range amounts {
+		if a.money == nil {
+			continue
+		}
+		if code := strings.ToUpper(a.money.CurrencyCode); code != "" {
+			if currency != "" && code != currency {
+				return params, fmt.Errorf("amount in %s on a %s receipt: %w", code, currency, ErrValidation)
+			}
+			currency = code
+		}
+		cents := moneyToCents(a.money)
+		*a.dst = &cents
 	}
+
+	if currency != "" {
+		if len(currency) != 3 {
+			return params, fmt.Errorf("invalid currency %q: %w", currency, ErrValidation)
+		}
+		params.Currency = &currency
+	}
+
+	return params, nil
+}
+
+// editsContent reports whether req changes anything OCR fills in
+func editsContent(req *pb.UpdateReceiptRequest) bool {
+	return len(req.Items) > 0 || req.Merchant != nil || req.ReceiptDate != nil || req.Currency != nil ||
+		req.Subtotal != nil || req.Tax != nil || req.Total != nil
 }
 
 // ----- conversion helpers ------------------------------------------------------------------
@@ -484,18 +1339,20 @@ func dollarsToCents(dollars float64) int64 {
 	return int64(math.Round(dollars * 100))
 }
 
-func (s *rcptSvc) receiptToPb(r *sqlc.Receipt, items []sqlc.ReceiptItem) *pb.Receipt {
+func (s *rcptSvc) receiptToPb(r *sqlc.Receipt, items []sqlc.ReceiptItem, pages []sqlc.ReceiptPage) *pb.Receipt {
 	proto := &pb.Receipt{
 		Id:            r.ID,
 		UserId:        r.UserID.String(),
 		TransactionId: r.TransactionID,
-		ImagePath:     r.ImagePath,
 		Merchant:      r.Merchant,
 		Currency:      r.Currency,
 		Confidence:    r.Confidence,
 		Status:        pb.ReceiptStatus(r.Status),
 		CreatedAt:     timestamppb.New(r.CreatedAt),
 		UpdatedAt:     timestamppb.New(r.UpdatedAt),
+		Attempts:      r.Attempts,
+		LastError:     r.LastError,
+		ReviewReasons: r.ReviewReasons,
 	}
 
 	if r.ReceiptDate != nil {
@@ -522,21 +1379,31 @@ func (s *rcptSvc) receiptToPb(r *sqlc.Receipt, items []sqlc.ReceiptItem) *pb.Rec
 		proto.Items[i] = receiptItemToPb(&items[i])
 	}
 
+	proto.Pages = make([]*pb.ReceiptPage, len(pages))
+	for i := range pages {
+		proto.Pages[i] = &pb.ReceiptPage{
+			PageNo:      pages[i].PageNo,
+			ContentType: pages[i].ContentType,
+		}
+	}
+
 	return proto
 }
 
-func (s *rcptSvc) listRowToPb(r *sqlc.ListReceiptsRow, items []sqlc.ReceiptItem) *pb.Receipt {
+func (s *rcptSvc) listRowToPb(r *sqlc.ListReceiptsRow, items []sqlc.ReceiptItem, pages []sqlc.ReceiptPage) *pb.Receipt {
 	proto := &pb.Receipt{
 		Id:            r.ID,
 		UserId:        r.UserID.String(),
 		TransactionId: r.TransactionID,
-		ImagePath:     r.ImagePath,
 		Merchant:      r.Merchant,
 		Currency:      r.Currency,
 		Confidence:    r.Confidence,
 		Status:        pb.ReceiptStatus(r.Status),
 		CreatedAt:     timestamppb.New(r.CreatedAt),
 		UpdatedAt:     timestamppb.New(r.UpdatedAt),
+		Attempts:      r.Attempts,
+		LastError:     r.LastError,
+		ReviewReasons: r.ReviewReasons,
 	}
 
 	if r.ReceiptDate != nil {
@@ -563,6 +1430,69 @@ func (s *rcptSvc) listRowToPb(r *sqlc.ListReceiptsRow, items []sqlc.ReceiptItem)
 		proto.Items[i] = receiptItemToPb(&items[i])
 	}
 
+	proto.Pages = make([]*pb.ReceiptPage, len(pages))
+	for i := range pages {
+		proto.Pages[i] = &pb.ReceiptPage{
+			PageNo:      pages[i].PageNo,
+			ContentType: pages[i].ContentType,
+		}
+	}
+
+	return proto
+}
+
+func linkCandidateToPb(c *receipts.Candidate) *pb.ReceiptLinkCandidate {
+	merchant := ""
+	if c.Tx.Merchant != nil {
+		merchant = *c.Tx.Merchant
+	} else if c.Tx.TxDesc != nil {
+		merchant = *c.Tx.TxDesc
+	}
+
+	return &pb.ReceiptLinkCandidate{
+		TransactionId:   c.Tx.ID,
+		Merchant:        merchant,
+		Amount:          centsToMoney(c.Tx.TxAmountCents, c.Tx.TxCurrency),
+		TxDate:          timestamppb.New(c.Tx.TxDate),
+		AccountId:       c.Tx.AccountID,
+		AccountName:     c.Tx.AccountName,
+		DateDiffDays:    c.DateDiffDays,
+		AmountDiffCents: c.AmountDiffCents,
+		Score:           c.Score,
+	}
+}
+
+func itemPriceHistoryToPb(h *receipts.ItemHistory) *pb.ItemPriceHistory {
+	proto := &pb.ItemPriceHistory{
+		NormalizedName: h.Key,
+		DisplayName:    h.Name,
+		Currency:       h.Currency,
+		ChangePercent:  h.ChangePercent,
+	}
+
+	proto.Points = make([]*pb.ItemPricePoint, len(h.Points))
+	for i, p := range h.Points {
+		proto.Points[i] = &pb.ItemPricePoint{
+			Date:      timeToDate(p.Date),
+			UnitPrice: centsToMoney(p.UnitPriceCents, p.Currency),
+			Merchant:  p.Merchant,
+			ReceiptId: p.ReceiptID,
+		}
+	}
+
+	proto.Merchants = make([]*pb.MerchantItemPrice, len(h.Merchants))
+	for i, m := range h.Merchants {
+		proto.Merchants[i] = &pb.MerchantItemPrice{
+			Merchant:      m.Merchant,
+			LatestPrice:   centsToMoney(m.Latest.UnitPriceCents, h.Currency),
+			LatestDate:    timeToDate(m.Latest.Date),
+			MinPrice:      centsToMoney(m.MinCents, h.Currency),
+			AvgPrice:      centsToMoney(m.AvgCents, h.Currency),
+			PurchaseCount: int32(m.Count),
+			ChangePercent: m.ChangePercent,
+		}
+	}
+
 	return proto
 }
 
@@ -578,6 +1508,7 @@ func receiptItemToPb(item *sqlc.ReceiptItem) *pb.ReceiptItem {
 			Units:        item.UnitPriceCents / 100,
 			Nanos:        int32((item.UnitPriceCents % 100) * 10_000_000),
 		},
-		SortOrder: item.SortOrder,
+		SortOrder:  item.SortOrder,
+		CategoryId: item.CategoryID,
 	}
 }
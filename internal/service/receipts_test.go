@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"null-core/internal/db"
+	"null-core/internal/db/sqlc"
+	pb "null-core/internal/gen/null/v1"
+	"null-core/internal/storage"
+
+	"github.com/charmbracelet/log"
+)
+
+type anyCurrency struct{}
+
+func (anyCurrency) IsValidCurrency(context.Context, string) (bool, error) { return true, nil }
+
+func newTestRcptSvc(tdb *db.TestDB, store storage.BlobStore) *rcptSvc {
+	return newRcptSvc(tdb.Queries, tdb.Pool(), log.New(io.Discard), nil, &FakeReceiptParser{}, store, anyCurrency{}, rcptConfig{
+		workers:          1,
+		reviewConfidence: 0.6,
+		ocrTimeout:       time.Minute,
+		maxAttempts:      5,
+		maxImageDim:      1920,
+	}).(*rcptSvc)
+}
+
+// TestLinkPendingReceipt links a receipt before OCR has run: the link is
+// stored but the receipt stays queued, and the worker marks it LINKED
+// once parsed instead of dropping it from the queue.
+func TestLinkPendingReceipt(t *testing.T) {
+	tdb := db.SetupTestDB(t)
+	ctx := context.Background()
+
+	userID := tdb.CreateTestUser(ctx)
+	account := tdb.CreateTestAccount(ctx, sqlc.CreateAccountParams{
+		OwnerID:        userID,
+		Name:           "test-receipt-link",
+		Bank:           "Test Bank",
+		AnchorCurrency: "CAD",
+		MainCurrency:   "CAD",
+		Colors:         []string{"#1f2937", "#3b82f6", "#10b981"},
+	})
+
+	var txID int64
+	err := tdb.Pool().QueryRow(ctx, `
+		INSERT INTO transactions (account_id, tx_date, tx_amount_cents, tx_currency, tx_direction)
+		VALUES ($1, $2, 750, 'CAD', 2)
+		RETURNING id
+	`, account.ID, time.Now()).Scan(&txID)
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+
+	store := storage.NewFSStore(t.TempDir())
+	imagePath := "receipts/" + userID.String() + "/test.png"
+	if err := store.Put(ctx, imagePath, []byte("not really a png"), "image/png"); err != nil {
+		t.Fatal(err)
+	}
+
+	receipt, err := tdb.Queries.CreateReceipt(ctx, sqlc.CreateReceiptParams{
+		UserID:    userID,
+		ImagePath: imagePath,
+		Status:    int16(pb.ReceiptStatus_RECEIPT_STATUS_PENDING),
+	})
+	if err != nil {
+		t.Fatalf("failed to create receipt: %v", err)
+	}
+
+	svc := newTestRcptSvc(tdb, store)
+
+	// OCR would overwrite the merchant, so it can't be edited yet
+	merchant := "Corner Store"
+	_, err = svc.Update(ctx, userID, receipt.ID, &pb.UpdateReceiptRequest{TransactionId: &txID, Merchant: &merchant})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected editing a PENDING receipt's merchant to fail validation, got %v", err)
+	}
+
+	updated, err := svc.Update(ctx, userID, receipt.ID, &pb.UpdateReceiptRequest{TransactionId: &txID})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated.Status != pb.ReceiptStatus_RECEIPT_STATUS_PENDING {
+		t.Errorf("Expected the receipt to stay PENDING, got %s", updated.Status)
+	}
+	if updated.GetTransactionId() != txID {
+		t.Errorf("Expected transaction %d to be stored, got %d", txID, updated.GetTransactionId())
+	}
+
+	claimed, err := tdb.Queries.ClaimPendingReceipts(ctx, sqlc.ClaimPendingReceiptsParams{
+		LeaseSeconds: 60,
+		MaxAttempts:  5,
+		Lim:          1,
+	})
+	if err != nil {
+		t.Fatalf("ClaimPendingReceipts failed: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != receipt.ID {
+		t.Fatalf("Expected the linked receipt to still be claimable, got %d receipts", len(claimed))
+	}
+
+	if err := svc.processOneReceipt(ctx, claimed[0]); err != nil {
+		t.Fatalf("processOneReceipt failed: %v", err)
+	}
+
+	row, err := tdb.Queries.GetReceipt(ctx, sqlc.GetReceiptParams{ID: receipt.ID, UserID: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row.Status != pb.ReceiptStatus_RECEIPT_STATUS_LINKED {
+		t.Errorf("Expected LINKED after parsing, got %s", row.Status)
+	}
+	if row.TransactionID == nil || *row.TransactionID != txID {
+		t.Errorf("Expected the user's link to be kept, got %v", row.TransactionID)
+	}
+}
+
+// TestRetryLeasedReceipt refuses to requeue a receipt a worker is still
+// parsing, which would let a second worker claim it alongside the first
+func TestRetryLeasedReceipt(t *testing.T) {
+	tdb := db.SetupTestDB(t)
+	ctx := context.Background()
+
+	userID := tdb.CreateTestUser(ctx)
+	receipt, err := tdb.Queries.CreateReceipt(ctx, sqlc.CreateReceiptParams{
+		UserID:    userID,
+		ImagePath: "receipts/" + userID.String() + "/leased.png",
+		Status:    int16(pb.ReceiptStatus_RECEIPT_STATUS_PENDING),
+	})
+	if err != nil {
+		t.Fatalf("failed to create receipt: %v", err)
+	}
+
+	claimed, err := tdb.Queries.ClaimPendingReceipts(ctx, sqlc.ClaimPendingReceiptsParams{
+		LeaseSeconds: 60,
+		MaxAttempts:  5,
+		Lim:          1,
+	})
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("Expected to claim the receipt, got %d (%v)", len(claimed), err)
+	}
+
+	svc := newTestRcptSvc(tdb, storage.NewFSStore(t.TempDir()))
+	if _, err := svc.Retry(ctx, userID, receipt.ID); !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected ErrValidation retrying a leased receipt, got %v", err)
+	}
+
+	row, err := tdb.Queries.GetReceipt(ctx, sqlc.GetReceiptParams{ID: receipt.ID, UserID: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row.LockedUntil == nil || row.Attempts != claimed[0].Attempts {
+		t.Errorf("Expected the lease and attempts to be left alone, got locked_until=%v attempts=%d", row.LockedUntil, row.Attempts)
+	}
+
+	if err := tdb.Queries.FailReceiptAttempt(ctx, sqlc.FailReceiptAttemptParams{
+		LastError:   "ocr down",
+		MaxAttempts: 1,
+		ID:          receipt.ID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	retried, err := svc.Retry(ctx, userID, receipt.ID)
+	if err != nil {
+		t.Fatalf("Expected a failed receipt to be retryable, got %v", err)
+	}
+	if retried.Status != pb.ReceiptStatus_RECEIPT_STATUS_PENDING {
+		t.Errorf("Expected PENDING after retry, got %s", retried.Status)
+	}
+}
+
+// TestProcessReceiptLostLease drops OCR results once the job's lease is gone
+// instead of writing over whoever owns the receipt now
+func TestProcessReceiptLostLease(t *testing.T) {
+	tdb := db.SetupTestDB(t)
+	ctx := context.Background()
+
+	userID := tdb.CreateTestUser(ctx)
+	store := storage.NewFSStore(t.TempDir())
+	imagePath := "receipts/" + userID.String() + "/expired.png"
+	if err := store.Put(ctx, imagePath, []byte("not really a png"), "image/png"); err != nil {
+		t.Fatal(err)
+	}
+
+	receipt, err := tdb.Queries.CreateReceipt(ctx, sqlc.CreateReceiptParams{
+		UserID:    userID,
+		ImagePath: imagePath,
+		Status:    int16(pb.ReceiptStatus_RECEIPT_STATUS_PENDING),
+	})
+	if err != nil {
+		t.Fatalf("failed to create receipt: %v", err)
+	}
+
+	claimed, err := tdb.Queries.ClaimPendingReceipts(ctx, sqlc.ClaimPendingReceiptsParams{
+		LeaseSeconds: 60,
+		MaxAttempts:  5,
+		Lim:          1,
+	})
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("Expected to claim the receipt, got %d (%v)", len(claimed), err)
+	}
+
+	if _, err := tdb.Pool().Exec(ctx, `UPDATE receipts SET locked_until = NOW() - interval '1 second' WHERE id = $1`, receipt.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := newTestRcptSvc(tdb, store)
+	if err := svc.processOneReceipt(ctx, claimed[0]); !errors.Is(err, errReceiptLeaseLost) {
+		t.Fatalf("Expected errReceiptLeaseLost, got %v", err)
+	}
+
+	row, err := tdb.Queries.GetReceipt(ctx, sqlc.GetReceiptParams{ID: receipt.ID, UserID: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row.Status != pb.ReceiptStatus_RECEIPT_STATUS_PENDING {
+		t.Errorf("Expected the receipt to stay PENDING, got %s", row.Status)
+	}
+	items, err := tdb.Queries.ListReceiptItems(ctx, receipt.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Errorf("Expected no items written, got %d", len(items))
+	}
+}
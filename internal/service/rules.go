@@ -2,13 +2,19 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"null-core/internal/db/sqlc"
 	pb "null-core/internal/gen/null/v1"
+	"null-core/internal/metrics"
 	"null-core/internal/rules"
+	"null-core/internal/tracing"
 
 	"github.com/charmbracelet/log"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -16,7 +22,7 @@ import (
 // ----- interface ---------------------------------------------------------------------------
 
 type RuleService interface {
-	Create(ctx context.Context, userID uuid.UUID, ruleName string, conditions []byte, categoryID *int64, merchant *string) (*pb.Rule, error)
+	Create(ctx context.Context, userID uuid.UUID, ruleName string, conditions []byte, categoryID *int64, merchant *string, ruleSource *string) (*pb.Rule, error)
 	Get(ctx context.Context, userID uuid.UUID, ruleID uuid.UUID) (*pb.Rule, error)
 	Update(ctx context.Context, userID uuid.UUID, ruleID uuid.UUID, ruleName *string, conditions []byte, categoryID *int64, merchant *string) error
 	Delete(ctx context.Context, userID uuid.UUID, ruleID uuid.UUID) (int64, error)
@@ -24,29 +30,39 @@ type RuleService interface {
 
 	ApplyToTransaction(ctx context.Context, userID uuid.UUID, tx *sqlc.Transaction, account *sqlc.GetAccountRow) (*RuleMatchResult, error)
 	ApplyToExisting(ctx context.Context, userID uuid.UUID, transactionIDs []int64) (int, error)
+	RecordApplications(ctx context.Context, userID uuid.UUID, ruleIDs ...uuid.UUID) error
+	Suggest(ctx context.Context, userID uuid.UUID, opts rules.SuggestOptions) ([]*pb.RuleSuggestion, error)
 }
 
 type catRuleSvc struct {
 	queries *sqlc.Queries
 	log     *log.Logger
+	cache   *userCache[*rules.RuleSet]
 }
 
 func newCatRuleSvc(queries *sqlc.Queries, logger *log.Logger) RuleService {
-	return &catRuleSvc{queries: queries, log: logger}
+	return &catRuleSvc{queries: queries, log: logger, cache: newUserCache[*rules.RuleSet](ruleSetCacheTTL)}
 }
 
+// rule sets are invalidated on rule CRUD; the TTL only bounds staleness from
+// changes made elsewhere (category deletes cascading to rules, other replicas)
+const ruleSetCacheTTL = 5 * time.Minute
+
 // ----- methods -----------------------------------------------------------------------------
 
 type RuleMatchResult struct {
-	CategoryID *int64
-	Merchant   *string
+	CategoryID     *int64
+	CategoryRuleID *uuid.UUID
+	Merchant       *string
+	MerchantRuleID *uuid.UUID
 }
 
-func (s *catRuleSvc) Create(ctx context.Context, userID uuid.UUID, ruleName string, conditions []byte, categoryID *int64, merchant *string) (*pb.Rule, error) {
+func (s *catRuleSvc) Create(ctx context.Context, userID uuid.UUID, ruleName string, conditions []byte, categoryID *int64, merchant *string, ruleSource *string) (*pb.Rule, error) {
 	params := sqlc.CreateRuleParams{
 		UserID:     userID,
 		RuleName:   ruleName,
 		Conditions: conditions,
+		RuleSource: ruleSource,
 	}
 	if categoryID != nil {
 		params.CategoryID = *categoryID
@@ -59,6 +75,7 @@ func (s *catRuleSvc) Create(ctx context.Context, userID uuid.UUID, ruleName stri
 	if err != nil {
 		return nil, wrapErr("RuleService.Create", err)
 	}
+	s.cache.invalidate(userID)
 
 	return ruleToPb(&rule), nil
 }
@@ -96,6 +113,7 @@ func (s *catRuleSvc) Update(ctx context.Context, userID uuid.UUID, ruleID uuid.U
 	if err != nil {
 		return wrapErr("RuleService.Update", err)
 	}
+	s.cache.invalidate(userID)
 
 	return nil
 }
@@ -108,6 +126,7 @@ func (s *catRuleSvc) Delete(ctx context.Context, userID uuid.UUID, ruleID uuid.U
 	if err != nil {
 		return 0, wrapErr("RuleService.Delete", err)
 	}
+	s.cache.invalidate(userID)
 
 	return affected, nil
 }
@@ -127,12 +146,23 @@ func (s *catRuleSvc) List(ctx context.Context, userID uuid.UUID) ([]*pb.Rule, er
 }
 
 func (s *catRuleSvc) ApplyToTransaction(ctx context.Context, userID uuid.UUID, tx *sqlc.Transaction, account *sqlc.GetAccountRow) (*RuleMatchResult, error) {
-	activeRules, err := s.queries.GetActiveRules(ctx, userID)
+	ruleSet, err := s.activeRuleSet(ctx, userID)
 	if err != nil {
 		return nil, wrapErr("RuleService.ApplyToTransaction", err)
 	}
 
-	return s.evaluateRulesForTransaction(activeRules, tx, account), nil
+	var acc *sqlc.Account
+	if account != nil {
+		acc = &account.Account
+	}
+
+	_, span := tracing.Tracer().Start(ctx, "MatchRules", trace.WithAttributes(attribute.Int("rules.count", ruleSet.Len())))
+	start := time.Now()
+	match := ruleSet.Match(tx, acc)
+	metrics.RuleEvaluation.WithLabelValues("single").Observe(time.Since(start).Seconds())
+	span.End()
+
+	return matchToResult(match), nil
 }
 
 func (s *catRuleSvc) ApplyToExisting(ctx context.Context, userID uuid.UUID, transactionIDs []int64) (int, error) {
@@ -150,29 +180,49 @@ func (s *catRuleSvc) ApplyToExisting(ctx context.Context, userID uuid.UUID, tran
 		return 0, nil
 	}
 
-	activeRules, err := s.queries.GetActiveRules(ctx, userID)
+	ruleSet, err := s.activeRuleSet(ctx, userID)
 	if err != nil {
 		return 0, wrapErr("RuleService.ApplyToExisting.FetchRules", err)
 	}
 
+	if ruleSet.Len() == 0 {
+		return 0, nil
+	}
+
+	accountRows, err := s.queries.ListAccounts(ctx, userID)
+	if err != nil {
+		return 0, wrapErr("RuleService.ApplyToExisting.FetchAccounts", err)
+	}
+
+	accounts := make(map[int64]*sqlc.Account, len(accountRows))
+	for i := range accountRows {
+		accounts[accountRows[i].Account.ID] = &accountRows[i].Account
+	}
+
 	type updateKey struct {
-		categoryID int64
-		merchant   string
+		categoryID     int64
+		categoryRuleID uuid.UUID
+		merchant       string
+		merchantRuleID uuid.UUID
 	}
 
 	updateGroups := make(map[updateKey][]int64)
 
-	for _, tx := range transactions {
-		account, err := s.queries.GetAccount(ctx, sqlc.GetAccountParams{
-			UserID: userID,
-			ID:     tx.AccountID,
-		})
-		if err != nil {
-			s.log.Warn("failed to fetch account for rule application", "account_id", tx.AccountID, "error", err)
+	_, span := tracing.Tracer().Start(ctx, "MatchRules", trace.WithAttributes(
+		attribute.Int("rules.count", ruleSet.Len()),
+		attribute.Int("transactions.count", len(transactions)),
+	))
+	start := time.Now()
+	for i := range transactions {
+		tx := &transactions[i]
+
+		account, ok := accounts[tx.AccountID]
+		if !ok {
+			s.log.Warn("account not found for rule application", "account_id", tx.AccountID)
 			continue
 		}
 
-		ruleResult := s.evaluateRulesForTransaction(activeRules, &tx, &account)
+		ruleResult := matchToResult(ruleSet.Match(tx, account))
 
 		noMatch := ruleResult.CategoryID == nil && ruleResult.Merchant == nil
 		if noMatch {
@@ -182,33 +232,97 @@ func (s *catRuleSvc) ApplyToExisting(ctx context.Context, userID uuid.UUID, tran
 		key := updateKey{}
 		if ruleResult.CategoryID != nil {
 			key.categoryID = *ruleResult.CategoryID
+			key.categoryRuleID = *ruleResult.CategoryRuleID
 		}
 		if ruleResult.Merchant != nil {
 			key.merchant = *ruleResult.Merchant
+			key.merchantRuleID = *ruleResult.MerchantRuleID
 		}
 
 		updateGroups[key] = append(updateGroups[key], tx.ID)
 	}
+	metrics.RuleEvaluation.WithLabelValues("bulk").Observe(time.Since(start).Seconds())
+	span.End()
 
 	totalUpdated := 0
+	applied := make(map[uuid.UUID]int32)
 	for key, txIDs := range updateGroups {
-		affected, err := s.queries.BulkApplyRuleToTransactions(ctx, sqlc.BulkApplyRuleToTransactionsParams{
+		params := sqlc.BulkApplyRuleToTransactionsParams{
 			CategoryID:     key.categoryID,
 			Merchant:       key.merchant,
 			TransactionIds: txIDs,
 			UserID:         userID,
-		})
+		}
+		if key.categoryID > 0 {
+			params.CategoryRuleID = &key.categoryRuleID
+		}
+		if key.merchant != "" {
+			params.MerchantRuleID = &key.merchantRuleID
+		}
+
+		affected, err := s.queries.BulkApplyRuleToTransactions(ctx, params)
 		if err != nil {
 			s.log.Warn("failed to bulk apply rules", "error", err)
 			continue
 		}
 
 		totalUpdated += int(affected)
+
+		// a rule gets credit once per transaction, even if it set both fields
+		if params.CategoryRuleID != nil {
+			applied[key.categoryRuleID] += int32(affected)
+		}
+		if params.MerchantRuleID != nil && key.merchantRuleID != key.categoryRuleID {
+			applied[key.merchantRuleID] += int32(affected)
+		}
+	}
+
+	if err := s.incrementApplied(ctx, userID, applied); err != nil {
+		s.log.Warn("failed to record rule applications", "error", err)
 	}
 
 	return totalUpdated, nil
 }
 
+func (s *catRuleSvc) RecordApplications(ctx context.Context, userID uuid.UUID, ruleIDs ...uuid.UUID) error {
+	applied := make(map[uuid.UUID]int32, len(ruleIDs))
+	for _, id := range ruleIDs {
+		applied[id] = 1
+	}
+
+	if err := s.incrementApplied(ctx, userID, applied); err != nil {
+		return wrapErr("RuleService.RecordApplications", err)
+	}
+
+	return nil
+}
+
+func (s *catRuleSvc) Suggest(ctx context.Context, userID uuid.UUID, opts rules.SuggestOptions) ([]*pb.RuleSuggestion, error) {
+	transactions, err := s.queries.ListAllTransactions(ctx, userID)
+	if err != nil {
+		return nil, wrapErr("RuleService.Suggest.FetchTransactions", err)
+	}
+
+	ruleSet, err := s.activeRuleSet(ctx, userID)
+	if err != nil {
+		return nil, wrapErr("RuleService.Suggest.FetchRules", err)
+	}
+
+	suggestions := rules.SuggestRules(transactions, ruleSet, opts)
+
+	result := make([]*pb.RuleSuggestion, 0, len(suggestions))
+	for i := range suggestions {
+		suggestion, err := ruleSuggestionToPb(&suggestions[i])
+		if err != nil {
+			s.log.Warn("failed to convert rule suggestion", "rule_name", suggestions[i].Name, "error", err)
+			continue
+		}
+		result = append(result, suggestion)
+	}
+
+	return result, nil
+}
+
 // ----- conversion helpers ------------------------------------------------------------------
 
 func ruleToPb(r *sqlc.TransactionRule) *pb.Rule {
@@ -256,34 +370,84 @@ func ruleToPb(r *sqlc.TransactionRule) *pb.Rule {
 	return rule
 }
 
+func ruleSuggestionToPb(sg *rules.Suggestion) (*pb.RuleSuggestion, error) {
+	conditionsJSON, err := json.Marshal(sg.Conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := &structpb.Struct{}
+	if err := conditions.UnmarshalJSON(conditionsJSON); err != nil {
+		return nil, err
+	}
+
+	return &pb.RuleSuggestion{
+		RuleName:             sg.Name,
+		Conditions:           conditions,
+		CategoryId:           sg.CategoryID,
+		Support:              int32(sg.Support),
+		Precision:            sg.Precision,
+		Coverage:             sg.Coverage,
+		TotalMatches:         int32(sg.TotalMatches),
+		SampleTransactionIds: sg.SampleTransactionIDs,
+	}, nil
+}
+
+func matchToResult(m rules.Match) *RuleMatchResult {
+	return &RuleMatchResult{
+		CategoryID:     m.CategoryID,
+		CategoryRuleID: m.CategoryRuleID,
+		Merchant:       m.Merchant,
+		MerchantRuleID: m.MerchantRuleID,
+	}
+}
+
 // ----- internal helpers --------------------------------------------------------------------
 
-func (s *catRuleSvc) evaluateRulesForTransaction(activeRules []sqlc.TransactionRule, tx *sqlc.Transaction, account *sqlc.GetAccountRow) *RuleMatchResult {
-	result := &RuleMatchResult{}
+// activeRuleSet returns the user's compiled active rules, compiling and caching on miss
+func (s *catRuleSvc) activeRuleSet(ctx context.Context, userID uuid.UUID) (*rules.RuleSet, error) {
+	set, gen, ok := s.cache.get(userID)
+	if ok {
+		return set, nil
+	}
 
-	for _, rule := range activeRules {
-		conditions, err := rules.ParseRuleConditions(rule.Conditions)
-		if err != nil {
-			continue
-		}
+	activeRules, err := s.queries.GetActiveRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
-		matches, err := rules.EvaluateRule(conditions, tx, account)
-		if err != nil || !matches {
-			continue
-		}
+	set, err = rules.CompileRuleSet(activeRules)
+	if err != nil {
+		s.log.Warn("skipping invalid rules", "user_id", userID, "error", err)
+	}
 
-		if result.CategoryID == nil && rule.CategoryID != nil {
-			result.CategoryID = rule.CategoryID
-		}
+	s.cache.put(userID, gen, set)
+	return set, nil
+}
 
-		if result.Merchant == nil && rule.Merchant != nil {
-			result.Merchant = rule.Merchant
-		}
+// incrementApplied bumps times_applied / last_applied_at for each rule by its count
+func (s *catRuleSvc) incrementApplied(ctx context.Context, userID uuid.UUID, applied map[uuid.UUID]int32) error {
+	if len(applied) == 0 {
+		return nil
+	}
 
-		if result.CategoryID != nil && result.Merchant != nil {
-			break
+	ruleIDs := make([]uuid.UUID, 0, len(applied))
+	counts := make([]int32, 0, len(applied))
+	for id, n := range applied {
+		if n <= 0 {
+			continue
 		}
+		ruleIDs = append(ruleIDs, id)
+		counts = append(counts, n)
+	}
+
+	if len(ruleIDs) == 0 {
+		return nil
 	}
 
-	return result
+	return s.queries.IncrementRuleApplications(ctx, sqlc.IncrementRuleApplicationsParams{
+		RuleIds: ruleIDs,
+		Counts:  counts,
+		UserID:  userID,
+	})
 }
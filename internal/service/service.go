@@ -1,9 +1,15 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"null-core/internal/config"
 	"null-core/internal/db"
 	"null-core/internal/exchange"
+	"null-core/internal/health"
+	"null-core/internal/storage"
 
 	"github.com/charmbracelet/log"
 )
@@ -17,22 +23,113 @@ type Services struct {
 	Users        UserService
 	Backup       BackupService
 	Receipts     ReceiptService
+	Merchants    MerchantService
+
+	db       *db.DB
+	parser   ReceiptParser
+	exchange *exchange.Client
 }
 
 func New(database *db.DB, logger *log.Logger, cfg *config.Config) (*Services, error) {
 	queries := database.Queries
 	catSvc := newCatSvc(queries, logger.WithPrefix("cat"))
 	ruleSvc := newCatRuleSvc(queries, logger.WithPrefix("rules"))
-	exchangeClient := exchange.NewClient(cfg.ExchangeAPIURL)
+	merchantSvc := newMerchantSvc(queries, database.Pool(), logger.WithPrefix("merch"))
+	exchangeClient := exchange.NewClient(cfg.ExchangeAPIURL, cfg.ExchangeTimeout)
+
+	parser, err := newReceiptParser(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs, err := newBlobStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptCfg := rcptConfig{
+		workers:          cfg.ReceiptWorkers,
+		reviewConfidence: cfg.ReceiptReviewConfidence,
+		pollInterval:     cfg.ReceiptPollInterval,
+		ocrTimeout:       cfg.ReceiptOCRTimeout,
+		maxAttempts:      cfg.ReceiptMaxAttempts,
+		maxImageDim:      cfg.ReceiptMaxImageDim,
+	}
 
 	return &Services{
-		Transactions: newTxnSvc(queries, logger.WithPrefix("txn"), catSvc, ruleSvc, exchangeClient),
+		Transactions: newTxnSvc(queries, logger.WithPrefix("txn"), catSvc, ruleSvc, merchantSvc, exchangeClient),
 		Categories:   catSvc,
 		Rules:        ruleSvc,
 		Accounts:     newAcctSvc(queries, logger.WithPrefix("acct")),
 		Dashboard:    newDashSvc(queries),
 		Users:        newUserSvc(queries, logger.WithPrefix("user")),
 		Backup:       newBackupSvc(queries),
-		Receipts:     newRcptSvc(queries, logger.WithPrefix("rcpt"), cfg.NullReceiptsURL, cfg.DataDir),
+		Receipts:     newRcptSvc(queries, database.Pool(), logger.WithPrefix("rcpt"), database, parser, blobs, exchangeClient, receiptCfg),
+		Merchants:    merchantSvc,
+
+		db:       database,
+		parser:   parser,
+		exchange: exchangeClient,
 	}, nil
 }
+
+// HealthChecks lists what the health checker should probe and which
+// services can't work while each one is down
+func (s *Services) HealthChecks() []health.Dependency {
+	deps := []health.Dependency{
+		{
+			Name:     "database",
+			Probe:    s.db.Pool().Ping,
+			Critical: true,
+		},
+		{
+			Name:     "exchange",
+			Probe:    s.exchange.Ping,
+			Services: []string{"null.v1.TransactionService"},
+		},
+	}
+
+	// manual and fake parsers have nothing to probe
+	if p, ok := s.parser.(interface{ Health(context.Context) error }); ok {
+		deps = append(deps, health.Dependency{
+			Name:     "ocr",
+			Probe:    p.Health,
+			Services: []string{"null.v1.ReceiptService"},
+		})
+	}
+
+	return deps
+}
+
+func newReceiptParser(cfg *config.Config) (ReceiptParser, error) {
+	switch cfg.ReceiptParser {
+	case "ocr":
+		return NewOCRParser(cfg.NullReceiptsURL)
+	case "fake":
+		return &FakeReceiptParser{}, nil
+	default:
+		return NewManualEntryParser(), nil
+	}
+}
+
+func newBlobStore(cfg *config.Config) (storage.BlobStore, error) {
+	if cfg.BlobStore != "s3" {
+		return storage.NewFSStore(cfg.DataDir), nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	store, err := storage.NewS3Store(ctx, storage.S3Config{
+		Endpoint:  cfg.S3Endpoint,
+		Bucket:    cfg.S3Bucket,
+		AccessKey: cfg.S3AccessKey,
+		SecretKey: cfg.S3SecretKey,
+		Region:    cfg.S3Region,
+		UseSSL:    cfg.S3UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("blob store: %w", err)
+	}
+	return store, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"null-core/internal/classifier"
+	"null-core/internal/db/sqlc"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// how many categories to store in transactions.suggestions
+	suggestionCount = 3
+	// below this many categorized transactions the model is mostly noise
+	minSuggestionHistory = 10
+	// models are retrained after this long even without invalidation, so
+	// rule-applied categories and imports eventually feed back in
+	suggestionModelTTL = 15 * time.Minute
+)
+
+// categorySuggester predicts categories from a per-user naive Bayes model,
+// trained lazily from the user's categorized transactions and kept in memory
+type categorySuggester struct {
+	queries *sqlc.Queries
+	cache   *userCache[*classifier.Model]
+	loads   singleflight.Group // one training run per user at a time
+}
+
+func newCategorySuggester(queries *sqlc.Queries) *categorySuggester {
+	return &categorySuggester{
+		queries: queries,
+		cache:   newUserCache[*classifier.Model](suggestionModelTTL),
+	}
+}
+
+// suggest returns up to suggestionCount category IDs, most likely first,
+// formatted for the suggestions TEXT[] column
+func (c *categorySuggester) suggest(ctx context.Context, userID uuid.UUID, tx *sqlc.Transaction) ([]string, error) {
+	model, err := c.model(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.Docs() < minSuggestionHistory {
+		return nil, nil
+	}
+
+	predictions := model.Predict(tx, suggestionCount)
+
+	suggestions := make([]string, len(predictions))
+	for i, p := range predictions {
+		suggestions[i] = strconv.FormatInt(p.CategoryID, 10)
+	}
+
+	return suggestions, nil
+}
+
+// invalidate drops the user's model so the next suggestion retrains it
+func (c *categorySuggester) invalidate(userID uuid.UUID) {
+	c.cache.invalidate(userID)
+}
+
+func (c *categorySuggester) model(ctx context.Context, userID uuid.UUID) (*classifier.Model, error) {
+	if model, _, ok := c.cache.get(userID); ok {
+		return model, nil
+	}
+
+	// concurrent misses share one training run over the user's history
+	v, err, _ := c.loads.Do(userID.String(), func() (any, error) {
+		model, gen, ok := c.cache.get(userID)
+		if ok {
+			return model, nil
+		}
+
+		history, err := c.queries.ListAllTransactions(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		model = classifier.Train(history)
+		c.cache.put(userID, gen, model)
+		return model, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*classifier.Model), nil
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"null-core/internal/db/sqlc"
 	"null-core/internal/exchange"
@@ -21,6 +22,7 @@ type TransactionService interface {
 	Update(ctx context.Context, userID uuid.UUID, req *pb.UpdateTransactionRequest) error
 	Delete(ctx context.Context, userID uuid.UUID, ids []int64) error
 	List(ctx context.Context, userID uuid.UUID, req *pb.ListTransactionsRequest) ([]*pb.Transaction, *pb.Cursor, error)
+	ListUncategorized(ctx context.Context, userID uuid.UUID, limit *int32, cursor *pb.Cursor) ([]*pb.Transaction, *pb.Cursor, error)
 	Categorize(ctx context.Context, userID uuid.UUID, transactionIDs []int64, categoryID int64) error
 }
 
@@ -29,7 +31,9 @@ type txnSvc struct {
 	log            *log.Logger
 	catSvc         CategoryService
 	ruleSvc        RuleService
+	merchantSvc    MerchantService
 	exchangeClient *exchange.Client
+	suggester      *categorySuggester
 }
 
 func newTxnSvc(
@@ -37,6 +41,7 @@ func newTxnSvc(
 	logger *log.Logger,
 	catSvc CategoryService,
 	ruleSvc RuleService,
+	merchantSvc MerchantService,
 	exchangeClient *exchange.Client,
 ) TransactionService {
 	return &txnSvc{
@@ -44,7 +49,9 @@ func newTxnSvc(
 		log:            logger,
 		catSvc:         catSvc,
 		ruleSvc:        ruleSvc,
+		merchantSvc:    merchantSvc,
 		exchangeClient: exchangeClient,
+		suggester:      newCategorySuggester(queries),
 	}
 }
 
@@ -142,6 +149,10 @@ func (s *txnSvc) Update(ctx context.Context, userID uuid.UUID, req *pb.UpdateTra
 		return wrapErr("TransactionService.Update", err)
 	}
 
+	if params.CategoryID != nil {
+		s.suggester.invalidate(userID)
+	}
+
 	// sync balances if amount, date, direction, or account changed
 	balanceFieldsChanged := params.TxAmountCents != nil || params.TxDate != nil || params.TxDirection != nil
 	accountChanged := params.AccountID != nil && *params.AccountID != tx.AccountID
@@ -236,9 +247,49 @@ func (s *txnSvc) Categorize(ctx context.Context, userID uuid.UUID, transactionID
 	if err != nil {
 		return wrapErr("TransactionService.Categorize", err)
 	}
+	s.suggester.invalidate(userID)
 	return nil
 }
 
+func (s *txnSvc) ListUncategorized(ctx context.Context, userID uuid.UUID, limit *int32, cursor *pb.Cursor) ([]*pb.Transaction, *pb.Cursor, error) {
+	uncategorized := true
+	req := &pb.ListTransactionsRequest{
+		Limit:         limit,
+		Cursor:        cursor,
+		Uncategorized: &uncategorized,
+	}
+
+	rows, err := s.queries.ListTransactions(ctx, buildListTxParams(userID, req))
+	if err != nil {
+		return nil, nil, wrapErr("TransactionService.ListUncategorized", err)
+	}
+
+	// fill in suggestions for rows that predate the classifier or had too
+	// little history at the time; computed only, a list never writes
+	for i := range rows {
+		if len(rows[i].Suggestions) == 0 {
+			rows[i].Suggestions = s.suggestCategories(ctx, userID, &rows[i])
+		}
+	}
+
+	result := make([]*pb.Transaction, len(rows))
+	for i := range rows {
+		result[i] = txToPb(&rows[i])
+	}
+
+	var nextCursor *pb.Cursor
+	noMore := len(result) == 0 || limit == nil || len(result) != int(*limit)
+	if !noMore {
+		lastTx := result[len(result)-1]
+		nextCursor = &pb.Cursor{
+			Date: lastTx.TxDate,
+			Id:   &lastTx.Id,
+		}
+	}
+
+	return result, nextCursor, nil
+}
+
 // ----- param builders ----------------------------------------------------------------------
 
 func buildListTxParams(userID uuid.UUID, req *pb.ListTransactionsRequest) sqlc.ListTransactionsParams {
@@ -454,6 +505,22 @@ func txToPb(tx *sqlc.Transaction) *pb.Transaction {
 		proto.ExchangeRate = tx.ExchangeRate
 	}
 
+	for _, suggestion := range tx.Suggestions {
+		if categoryID, err := strconv.ParseInt(suggestion, 10, 64); err == nil {
+			proto.SuggestedCategoryIds = append(proto.SuggestedCategoryIds, categoryID)
+		}
+	}
+
+	if tx.CategoryRuleID != nil {
+		id := tx.CategoryRuleID.String()
+		proto.CategoryRuleId = &id
+	}
+	if tx.MerchantRuleID != nil {
+		id := tx.MerchantRuleID.String()
+		proto.MerchantRuleId = &id
+	}
+	proto.MerchantId = tx.MerchantID
+
 	return proto
 }
 
@@ -492,7 +559,7 @@ func (s *txnSvc) processForeignCurrency(ctx context.Context, userID uuid.UUID, p
 	foreignAmountCents := params.TxAmountCents
 	foreignCurrency := params.TxCurrency
 
-	rate, err := s.exchangeClient.GetExchangeRate(foreignCurrency, account.Account.AnchorCurrency, &params.TxDate)
+	rate, err := s.exchangeClient.GetExchangeRate(ctx, foreignCurrency, account.Account.AnchorCurrency, &params.TxDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get exchange rate from %s to %s: %w", foreignCurrency, account.Account.AnchorCurrency, err)
 	}
@@ -516,6 +583,8 @@ func (s *txnSvc) applyRulesToTransaction(ctx context.Context, userID uuid.UUID,
 		return
 	}
 
+	s.resolveMerchant(ctx, userID, &tx)
+
 	bothManuallySet := tx.CategoryManuallySet && tx.MerchantManuallySet
 	if bothManuallySet {
 		return
@@ -536,25 +605,114 @@ func (s *txnSvc) applyRulesToTransaction(ctx context.Context, userID uuid.UUID,
 		return
 	}
 
+	// nothing categorized it; fall back to suggestions from the user's history
+	categorizedByRule := !tx.CategoryManuallySet && result.CategoryID != nil
+	if !categorizedByRule && tx.CategoryID == nil {
+		s.storeSuggestions(ctx, userID, &tx)
+	}
+
 	noMatch := result.CategoryID == nil && result.Merchant == nil
 	if noMatch {
 		return
 	}
 
-	updateParams := sqlc.UpdateTransactionParams{
-		ID:     txID,
-		UserID: userID,
+	params := sqlc.BulkApplyRuleToTransactionsParams{
+		TransactionIds: []int64{txID},
+		UserID:         userID,
 	}
 
+	var appliedRules []uuid.UUID
 	if !tx.CategoryManuallySet && result.CategoryID != nil {
-		updateParams.CategoryID = result.CategoryID
+		params.CategoryID = *result.CategoryID
+		params.CategoryRuleID = result.CategoryRuleID
+		appliedRules = append(appliedRules, *result.CategoryRuleID)
 	}
 
 	if !tx.MerchantManuallySet && result.Merchant != nil {
-		updateParams.Merchant = result.Merchant
+		params.Merchant = *result.Merchant
+		params.MerchantRuleID = result.MerchantRuleID
+		if len(appliedRules) == 0 || appliedRules[0] != *result.MerchantRuleID {
+			appliedRules = append(appliedRules, *result.MerchantRuleID)
+		}
+	}
+
+	if len(appliedRules) == 0 {
+		return
 	}
 
-	if err := s.queries.UpdateTransaction(ctx, updateParams); err != nil {
+	if _, err := s.queries.BulkApplyRuleToTransactions(ctx, params); err != nil {
 		s.log.Warn("failed to update transaction with rule results", "tx_id", txID, "error", err)
+		return
 	}
+
+	if err := s.ruleSvc.RecordApplications(ctx, userID, appliedRules...); err != nil {
+		s.log.Warn("failed to record rule applications", "tx_id", txID, "error", err)
+	}
+}
+
+// resolveMerchant links the transaction to a canonical merchant before rules
+// run, so rules see the normalized name and can still override it
+func (s *txnSvc) resolveMerchant(ctx context.Context, userID uuid.UUID, tx *sqlc.Transaction) {
+	merchant, err := s.merchantSvc.Resolve(ctx, userID, tx)
+	if err != nil {
+		s.log.Warn("failed to resolve merchant", "tx_id", tx.ID, "error", err)
+		return
+	}
+
+	alreadyLinked := merchant != nil && tx.MerchantID != nil && *tx.MerchantID == merchant.ID
+	if merchant == nil || alreadyLinked {
+		return
+	}
+
+	_, err = s.queries.BulkSetTransactionMerchant(ctx, sqlc.BulkSetTransactionMerchantParams{
+		MerchantID:        merchant.ID,
+		Merchant:          merchant.Name,
+		DefaultCategoryID: merchant.DefaultCategoryID,
+		TransactionIds:    []int64{tx.ID},
+		UserID:            userID,
+	})
+	if err != nil {
+		s.log.Warn("failed to link transaction to merchant", "tx_id", tx.ID, "merchant_id", merchant.ID, "error", err)
+		return
+	}
+
+	// mirror what the update did so rule evaluation sees it
+	tx.MerchantID = &merchant.ID
+	if !tx.MerchantManuallySet && tx.MerchantRuleID == nil {
+		tx.Merchant = &merchant.Name
+	}
+	if tx.CategoryID == nil && !tx.CategoryManuallySet {
+		tx.CategoryID = merchant.DefaultCategoryID
+	}
+}
+
+// suggestCategories predicts likely categories for tx without storing them
+func (s *txnSvc) suggestCategories(ctx context.Context, userID uuid.UUID, tx *sqlc.Transaction) []string {
+	suggestions, err := s.suggester.suggest(ctx, userID, tx)
+	if err != nil {
+		s.log.Warn("failed to compute category suggestions", "tx_id", tx.ID, "error", err)
+		return nil
+	}
+	return suggestions
+}
+
+// storeSuggestions predicts likely categories and persists them on the
+// transaction, returning what was stored. Only the write path calls it.
+func (s *txnSvc) storeSuggestions(ctx context.Context, userID uuid.UUID, tx *sqlc.Transaction) []string {
+	suggestions := s.suggestCategories(ctx, userID, tx)
+	if len(suggestions) == 0 {
+		return nil
+	}
+
+	err := s.queries.UpdateTransaction(ctx, sqlc.UpdateTransactionParams{
+		ID:          tx.ID,
+		UserID:      userID,
+		Suggestions: suggestions,
+	})
+	if err != nil {
+		s.log.Warn("failed to store category suggestions", "tx_id", tx.ID, "error", err)
+		return nil
+	}
+
+	return suggestions
 }
@@ -2,16 +2,37 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"null-core/internal/db/sqlc"
 	pb "null-core/internal/gen/null/v1"
 
 	"github.com/charmbracelet/log"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
+// APITokenPrefix starts every personal API token, so the auth middleware can
+// tell them from gateway JWTs without parsing anything
+const APITokenPrefix = "nul_"
+
+// how much of the secret is kept in the clear for listings
+const apiTokenPrefixLen = 12
+
+// scopes as stored in api_tokens.scopes
+var apiTokenScopeNames = map[pb.ApiTokenScope]string{
+	pb.ApiTokenScope_API_TOKEN_SCOPE_READ:               "read",
+	pb.ApiTokenScope_API_TOKEN_SCOPE_TRANSACTIONS_WRITE: "transactions:write",
+	pb.ApiTokenScope_API_TOKEN_SCOPE_RECEIPTS_UPLOAD:    "receipts:upload",
+}
+
 // ----- interface ---------------------------------------------------------------------------
 
 type UserService interface {
@@ -21,6 +42,11 @@ type UserService interface {
 	Update(ctx context.Context, req *pb.UpdateUserRequest) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context) ([]*pb.User, error)
+
+	CreateAPIToken(ctx context.Context, userID uuid.UUID, req *pb.CreateApiTokenRequest) (*pb.ApiToken, string, error)
+	ListAPITokens(ctx context.Context, userID uuid.UUID) ([]*pb.ApiToken, error)
+	DeleteAPIToken(ctx context.Context, userID uuid.UUID, id int64) error
+	AuthenticateAPIToken(ctx context.Context, secret string) (uuid.UUID, *pb.ApiToken, error)
 }
 
 type userSvc struct {
@@ -137,6 +163,103 @@ func (s *userSvc) List(ctx context.Context) ([]*pb.User, error) {
 	return pbUsers, nil
 }
 
+// ----- api tokens --------------------------------------------------------------------------
+
+func (s *userSvc) CreateAPIToken(ctx context.Context, userID uuid.UUID, req *pb.CreateApiTokenRequest) (*pb.ApiToken, string, error) {
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return nil, "", wrapErr("UserService.CreateAPIToken", fmt.Errorf("name is required: %w", ErrValidation))
+	}
+
+	scopes, err := apiTokenScopesToDB(req.GetScopes())
+	if err != nil {
+		return nil, "", wrapErr("UserService.CreateAPIToken", err)
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		t := req.ExpiresAt.AsTime()
+		if !t.After(time.Now()) {
+			return nil, "", wrapErr("UserService.CreateAPIToken", fmt.Errorf("expires_at is in the past: %w", ErrValidation))
+		}
+		expiresAt = &t
+	}
+
+	secret, err := newAPITokenSecret()
+	if err != nil {
+		return nil, "", wrapErr("UserService.CreateAPIToken", err)
+	}
+
+	hash := sha256.Sum256([]byte(secret))
+	token, err := s.queries.CreateApiToken(ctx, sqlc.CreateApiTokenParams{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: hash[:],
+		Prefix:    secret[:apiTokenPrefixLen],
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, "", wrapErr("UserService.CreateAPIToken", err)
+	}
+
+	s.log.Info("api token created", "user_id", userID, "token_id", token.ID, "scopes", scopes)
+
+	return apiTokenToPb(&token), secret, nil
+}
+
+func (s *userSvc) ListAPITokens(ctx context.Context, userID uuid.UUID) ([]*pb.ApiToken, error) {
+	tokens, err := s.queries.ListApiTokens(ctx, userID)
+	if err != nil {
+		return nil, wrapErr("UserService.ListAPITokens", err)
+	}
+
+	pbTokens := make([]*pb.ApiToken, len(tokens))
+	for i := range tokens {
+		pbTokens[i] = apiTokenToPb(&tokens[i])
+	}
+
+	return pbTokens, nil
+}
+
+func (s *userSvc) DeleteAPIToken(ctx context.Context, userID uuid.UUID, id int64) error {
+	affected, err := s.queries.DeleteApiToken(ctx, sqlc.DeleteApiTokenParams{ID: id, UserID: userID})
+	if err != nil {
+		return wrapErr("UserService.DeleteAPIToken", err)
+	}
+	if affected == 0 {
+		return wrapErr("UserService.DeleteAPIToken", fmt.Errorf("token %d not found: %w", id, ErrValidation))
+	}
+
+	s.log.Info("api token deleted", "user_id", userID, "token_id", id)
+
+	return nil
+}
+
+// AuthenticateAPIToken resolves a bearer secret to its owner; unknown and
+// expired tokens are both errors
+func (s *userSvc) AuthenticateAPIToken(ctx context.Context, secret string) (uuid.UUID, *pb.ApiToken, error) {
+	if !strings.HasPrefix(secret, APITokenPrefix) {
+		return uuid.Nil, nil, errors.New("not an api token")
+	}
+
+	hash := sha256.Sum256([]byte(secret))
+	token, err := s.queries.GetApiTokenByHash(ctx, hash[:])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, nil, errors.New("unknown or expired api token")
+		}
+		return uuid.Nil, nil, wrapErr("UserService.AuthenticateAPIToken", err)
+	}
+
+	if err := s.queries.TouchApiToken(ctx, token.ID); err != nil {
+		// not worth failing the request over
+		s.log.Warn("failed to record api token use", "token_id", token.ID, "error", err)
+	}
+
+	return token.UserID, apiTokenToPb(&token), nil
+}
+
 // ----- param builders ----------------------------------------------------------------------
 
 func buildCreateUserParams(req *pb.CreateUserRequest) (sqlc.CreateUserParams, error) {
@@ -184,3 +307,53 @@ func userToPb(u *sqlc.User) *pb.User {
 		UpdatedAt:       toProtoTimestamp(&u.UpdatedAt),
 	}
 }
+
+func apiTokenToPb(t *sqlc.ApiToken) *pb.ApiToken {
+	scopes := make([]pb.ApiTokenScope, 0, len(t.Scopes))
+	for _, name := range t.Scopes {
+		for scope, dbName := range apiTokenScopeNames {
+			if dbName == name {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	slices.Sort(scopes)
+
+	return &pb.ApiToken{
+		Id:         t.ID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  toProtoTimestamp(t.ExpiresAt),
+		LastUsedAt: toProtoTimestamp(t.LastUsedAt),
+		CreatedAt:  toProtoTimestamp(&t.CreatedAt),
+	}
+}
+
+func apiTokenScopesToDB(scopes []pb.ApiTokenScope) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required: %w", ErrValidation)
+	}
+
+	names := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		name, ok := apiTokenScopeNames[scope]
+		if !ok {
+			return nil, fmt.Errorf("invalid scope %v: %w", scope, ErrValidation)
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// newAPITokenSecret returns APITokenPrefix followed by 256 random bits
+func newAPITokenSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return APITokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
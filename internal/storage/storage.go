@@ -0,0 +1,68 @@
+// Package storage keeps uploaded blobs (receipt images) out of the database.
+// Keys are slash-separated paths relative to the store root; ContentKey
+// derives them from the blob's hash so identical uploads share one object.
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("blob not found")
+	// ErrUnsupported is returned by SignedURL when the backend can't hand out
+	// direct links; callers fall back to streaming the blob themselves
+	ErrUnsupported = errors.New("operation not supported by blob store")
+)
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, string, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// ContentKey returns prefix/<sha256 of data>.<ext>
+func ContentKey(prefix string, data []byte, ext string) string {
+	sum := sha256.Sum256(data)
+	name := hex.EncodeToString(sum[:])
+	if ext != "" {
+		name += "." + strings.TrimPrefix(ext, ".")
+	}
+	return path.Join(prefix, name)
+}
+
+// ContentType guesses a blob's content type from its key's extension
+func ContentType(key string) string {
+	switch strings.ToLower(path.Ext(key)) {
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	case ".png":
+		return "image/png"
+	case ".webp":
+		return "image/webp"
+	case ".heic":
+		return "image/heic"
+	case ".heif":
+		return "image/heif"
+	case ".pdf":
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// cleanKey rejects keys that could escape the store root
+func cleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(key, "\\", "/")), "/")
+	if key == "" || key == "." {
+		return "", errors.New("empty blob key")
+	}
+	return key, nil
+}
@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a tiny path-style S3 stand-in covering the calls the store makes
+type fakeS3 struct {
+	mu      sync.Mutex
+	buckets map[string]bool
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		buckets: make(map[string]bool),
+		objects: make(map[string][]byte),
+		types:   make(map[string]string),
+	}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	if key == "" {
+		switch r.Method {
+		case http.MethodHead:
+			if !f.buckets[bucket] {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case http.MethodPut:
+			f.buckets[bucket] = true
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+		return
+	}
+
+	id := bucket + "/" + key
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+			body = decodeChunks(body)
+		}
+		f.objects[id] = body
+		f.types[id] = r.Header.Get("Content-Type")
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[id]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", f.types[id])
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// decodeChunks strips aws-chunked framing: <hex size>;chunk-signature=...\r\n<data>\r\n
+func decodeChunks(body []byte) []byte {
+	var out []byte
+	for len(body) > 0 {
+		header, rest, ok := bytes.Cut(body, []byte("\r\n"))
+		if !ok {
+			break
+		}
+		sizeHex, _, _ := bytes.Cut(header, []byte(";"))
+		size, err := strconv.ParseInt(string(sizeHex), 16, 64)
+		if err != nil || size == 0 || int(size) > len(rest) {
+			break
+		}
+		out = append(out, rest[:size]...)
+		body = bytes.TrimPrefix(rest[size:], []byte("\r\n"))
+	}
+	return out
+}
+
+func stores(t *testing.T) map[string]BlobStore {
+	t.Helper()
+
+	srv := httptest.NewServer(newFakeS3())
+	t.Cleanup(srv.Close)
+
+	s3, err := NewS3Store(context.Background(), S3Config{
+		Endpoint:  strings.TrimPrefix(srv.URL, "http://"),
+		Bucket:    "receipts",
+		AccessKey: "test",
+		SecretKey: "testtest",
+		Region:    "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected s3 store, got %v", err)
+	}
+
+	return map[string]BlobStore{
+		"fs": NewFSStore(t.TempDir()),
+		"s3": s3,
+	}
+}
+
+func TestBlobStores(t *testing.T) {
+	ctx := context.Background()
+	data := []byte("not really a jpeg")
+	key := ContentKey("receipts/user", data, "jpg")
+
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			if ok, err := store.Exists(ctx, key); err != nil || ok {
+				t.Fatalf("Expected missing blob, got exists=%v err=%v", ok, err)
+			}
+			if _, _, err := store.Get(ctx, key); err != ErrNotFound {
+				t.Fatalf("Expected ErrNotFound, got %v", err)
+			}
+
+			if err := store.Put(ctx, key, data, "image/jpeg"); err != nil {
+				t.Fatalf("Expected put to succeed, got %v", err)
+			}
+
+			got, contentType, err := store.Get(ctx, key)
+			if err != nil {
+				t.Fatalf("Expected get to succeed, got %v", err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("Expected %q, got %q", data, got)
+			}
+			if contentType != "image/jpeg" {
+				t.Errorf("Expected image/jpeg, got %q", contentType)
+			}
+
+			if ok, err := store.Exists(ctx, key); err != nil || !ok {
+				t.Errorf("Expected blob to exist, got exists=%v err=%v", ok, err)
+			}
+
+			if err := store.Delete(ctx, key); err != nil {
+				t.Fatalf("Expected delete to succeed, got %v", err)
+			}
+			if err := store.Delete(ctx, key); err != nil {
+				t.Errorf("Expected deleting a missing blob to be a no-op, got %v", err)
+			}
+			if ok, _ := store.Exists(ctx, key); ok {
+				t.Errorf("Expected blob to be gone after delete")
+			}
+		})
+	}
+}
+
+func TestSignedURL(t *testing.T) {
+	all := stores(t)
+
+	if _, err := all["fs"].SignedURL(context.Background(), "a/b.jpg", time.Minute); err != ErrUnsupported {
+		t.Errorf("Expected ErrUnsupported from fs store, got %v", err)
+	}
+
+	u, err := all["s3"].SignedURL(context.Background(), "a/b.jpg", time.Minute)
+	if err != nil {
+		t.Fatalf("Expected signed url, got %v", err)
+	}
+	if !strings.Contains(u, "/receipts/a/b.jpg") || !strings.Contains(u, "X-Amz-Signature=") {
+		t.Errorf("Expected presigned object url, got %s", u)
+	}
+}
+
+func TestContentKey(t *testing.T) {
+	a := ContentKey("receipts/u1", []byte("same"), "png")
+	b := ContentKey("receipts/u1", []byte("same"), ".png")
+	c := ContentKey("receipts/u1", []byte("different"), "png")
+
+	if a != b {
+		t.Errorf("Expected identical content to share a key, got %s and %s", a, b)
+	}
+	if a == c {
+		t.Errorf("Expected different content to get different keys")
+	}
+	if !strings.HasPrefix(a, "receipts/u1/") || !strings.HasSuffix(a, ".png") {
+		t.Errorf("Expected receipts/u1/<hash>.png, got %s", a)
+	}
+}
+
+func TestFSKeyEscape(t *testing.T) {
+	root := t.TempDir()
+	store := NewFSStore(root)
+
+	if err := store.Put(context.Background(), "../../etc/evil", []byte("x"), ""); err != nil {
+		t.Fatalf("Expected put to succeed inside root, got %v", err)
+	}
+	if ok, _ := store.Exists(context.Background(), "etc/evil"); !ok {
+		t.Errorf("Expected traversal key to be confined to the store root")
+	}
+}
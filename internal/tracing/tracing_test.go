@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Setup(context.Background(), Config{Exporter: "file", File: path, SampleRatio: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the gateway's trace is continued, not replaced
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(),
+		propagation.MapCarrier{"traceparent": parent})
+
+	ctx, span := Tracer().Start(ctx, "CreateTransaction")
+
+	fields := LogFields(ctx)
+	span.End()
+
+	if len(fields) != 4 || fields[1] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the gateway's trace id in the log fields, got %v", fields)
+	}
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"Name":"CreateTransaction"`) || !strings.Contains(string(data), "4bf92f3577b34da6a3ce929d0e0e4736") {
+		t.Errorf("Expected the span in the trace file, got %s", data)
+	}
+}
+
+func TestLogFieldsWithoutSpan(t *testing.T) {
+	if fields := LogFields(context.Background()); fields != nil {
+		t.Errorf("Expected no fields without a span, got %v", fields)
+	}
+}